@@ -0,0 +1,39 @@
+package api
+
+import (
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+func (c *communityController) AcceptAnswer(ctx *gin.Context) {
+	var requestBody dto.RequestAcceptAnswer
+	log := logger.GetLogInstance(ctx, "AcceptAnswer")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[AcceptAnswerController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[AcceptAnswerController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[AcceptAnswerController] Error occured while binding json"))
+		return
+	}
+	if requestBody.QuestionID == 0 {
+		log.Errorf("[AcceptAnswerController] question id isn't found in request body")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "Question id is required"))
+		return
+	}
+
+	res, exp := c.communityService.AcceptAnswer(ctx.Request.Context(), &requestBody, userID)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
@@ -3,10 +3,12 @@ package api
 import (
 	"strconv"
 	"strings"
+	"time"
 	"github.com/Abhishekjha321/community_service/dto"
 	"github.com/Abhishekjha321/community_service/exceptions"
 	"github.com/Abhishekjha321/community_service/internal/common"
 	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	"github.com/Abhishekjha321/community_service/internal/richtext"
 	logger "github.com/Abhishekjha321/community_service/log"
 	"github.com/gin-gonic/gin"
 )
@@ -20,6 +22,11 @@ const (
 	DefaultPageLimit                = "10"
 	QueryParamPageSize              = "pageSize"
 	QueryParamSortBy                = "sort_by"
+	QueryParamFlow                  = "flow"
+	QueryParamWindow                = "window"
+	QueryParamIdeaStatus            = "idea_status"
+	QueryParamAnswered              = "answered"
+	QueryParamTag                   = "tag"
 	QueryParamsBookmarksOnly        = "bookmarks_only"
 	CHANNEL_ID                      = "channel_id"
 	POST_ID                         = "post_id"
@@ -94,9 +101,10 @@ func (c *communityController) GetPosts(ctx *gin.Context) {
 		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.QueryParamsIncorrectErrorCode))
 		return
 	}
-	sortBy := ctx.Query(QueryParamSortBy)
-	if len(sortBy) == 0 {
-		sortBy = common.USER_BASED_FLOW
+	sortBy, feedSort, exp := parseFeedSort(ctx)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
 	}
 	bookMarksOnlyParam := ctx.Query(QueryParamsBookmarksOnly)
 	bookMarksOnly := false
@@ -108,6 +116,7 @@ func (c *communityController) GetPosts(ctx *gin.Context) {
 			bookMarksOnly = false
 		}
 	}
+	feedSort.Viewer.ModeratorView, _ = strconv.ParseBool(strings.ToLower(ctx.Query(MODERATOR_VIEW)))
 
 	limit := ctx.Query(LIMIT)
 	if limit == "" {
@@ -131,8 +140,13 @@ func (c *communityController) GetPosts(ctx *gin.Context) {
 			exceptions.BadRequestErrorCode, "CurrentPage is missing"))
 		return
 	}
-	log.Infof("channelID: %s, limit: %s, currentPage: %s", channelID, limit, currentPage)
-	res, errGetPosts := c.communityService.GetPosts(ctx, channelID, userID, convertedLimit, convertedCurrentPage, sortBy, bookMarksOnly)
+	cursor, exp := parseFeedCursor(ctx)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	log.Infof("channelID: %s, limit: %s, currentPage: %s, flow: %s, order: %s", channelID, limit, currentPage, sortBy, feedSort.Order)
+	res, errGetPosts := c.communityService.GetPosts(ctx, channelID, userID, convertedLimit, convertedCurrentPage, sortBy, feedSort, bookMarksOnly, cursor)
 	if errGetPosts != nil {
 		SendApiResponseV1(ctx, nil, errGetPosts)
 		return
@@ -141,6 +155,98 @@ func (c *communityController) GetPosts(ctx *gin.Context) {
 	SendApiResponseV1(ctx, res, nil)
 }
 
+// parseFeedCursor decodes the optional cursor query param. When it is present
+// the page param is ignored and the next page is read after the cursor.
+func parseFeedCursor(ctx *gin.Context) (*model.FeedCursor, *exceptions.Exception) {
+	cursor := ctx.Query(CURSOR)
+	if cursor == "" {
+		return nil, nil
+	}
+	var feedCursor model.FeedCursor
+	if err := common.DecodeCursor(cursor, &feedCursor); err != nil {
+		logger.GetLogInstance(ctx, "parseFeedCursor").Errorf("invalid cursor: %s, err: %v", cursor, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.InvalidCursorErrorCode)
+	}
+	return &feedCursor, nil
+}
+
+// topWindows maps the window query param to how far back the top order looks.
+// A zero duration means all time.
+var topWindows = map[string]time.Duration{
+	"day":   24 * time.Hour,
+	"week":  7 * 24 * time.Hour,
+	"month": 30 * 24 * time.Hour,
+	"year":  365 * 24 * time.Hour,
+	"all":   0,
+}
+
+// parseFeedSort resolves the flow, sort order and filters of a feed request. The
+// flow comes from the flow param; sort_by carries the order, but older clients
+// still send the flow in sort_by so those values are accepted there as well.
+// Without a flow the channel's default flow is used.
+func parseFeedSort(ctx *gin.Context) (string, model.FeedSort, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "parseFeedSort")
+	var feedSort model.FeedSort
+
+	flow := ctx.Query(QueryParamFlow)
+	sortBy := strings.ToLower(ctx.Query(QueryParamSortBy))
+	switch sortBy {
+	case "":
+	case common.USER_BASED_FLOW, common.IDEAS_BASED_FLOW:
+		if flow == "" {
+			flow = sortBy
+		}
+	case common.SORT_NEWEST, common.SORT_TOP, common.SORT_HOT, common.SORT_CONTROVERSIAL, common.SORT_SCORE:
+		feedSort.Order = sortBy
+	default:
+		// clients sent free-form sort_by values before sort orders existed;
+		// those fell back to the default flow and still do
+		log.Infof("ignoring unknown sort_by: %s in query params", sortBy)
+	}
+	if flow != "" && flow != common.USER_BASED_FLOW && flow != common.IDEAS_BASED_FLOW {
+		log.Errorf("invalid flow: %s in query params", flow)
+		return "", feedSort, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.QueryParamsIncorrectErrorCode, "Flow is not correct")
+	}
+
+	if feedSort.Order == common.SORT_TOP {
+		window := strings.ToLower(ctx.Query(QueryParamWindow))
+		if window == "" {
+			window = "week"
+		}
+		duration, ok := topWindows[window]
+		if !ok {
+			log.Errorf("invalid window: %s in query params", window)
+			return "", feedSort, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+				exceptions.QueryParamsIncorrectErrorCode, "Window should be one of day, week, month, year or all")
+		}
+		if duration > 0 {
+			feedSort.Since = time.Now().Add(-duration)
+		}
+	}
+
+	if ideaStatus := strings.ToUpper(ctx.Query(QueryParamIdeaStatus)); ideaStatus != "" {
+		if !common.IsValidIdeaStatus(ideaStatus) {
+			log.Errorf("invalid idea_status: %s in query params", ideaStatus)
+			return "", feedSort, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+				exceptions.QueryParamsIncorrectErrorCode, "Idea status is not correct")
+		}
+		feedSort.IdeaStatus = ideaStatus
+	}
+
+	if answered := ctx.Query(QueryParamAnswered); answered != "" {
+		value, err := strconv.ParseBool(answered)
+		if err != nil {
+			log.Errorf("invalid answered: %s in query params", answered)
+			return "", feedSort, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+				exceptions.QueryParamsIncorrectErrorCode, "Answered should be true or false")
+		}
+		feedSort.Answered = &value
+	}
+	feedSort.Tag = richtext.NormalizeTag(ctx.Query(QueryParamTag))
+	return flow, feedSort, nil
+}
+
 func getValidAction(action string) bool {
 	switch strings.ToLower(action) {
 	case LIKE, UNLIKE, BOOKMARK:
@@ -204,7 +310,7 @@ func (c *communityController) DeletePost(ctx *gin.Context) {
 		return
 	}
 	log.Infof("[DeletePostController] postID: %s", postID)
-	err := c.communityService.DeletePost(ctx, postID, userID)
+	err := c.communityService.DeletePost(ctx, postID, userID, ctx.Query(REASON))
 	if err != nil {
 		SendApiResponseV1(ctx, nil, err)
 		return
@@ -296,12 +402,21 @@ func (c *communityController) AllRepliesOnPost(ctx *gin.Context) {
 		return
 	}
 
-	sortBy := ctx.Query(QueryParamSortBy)
-	if len(sortBy) == 0 {
-		sortBy = common.USER_BASED_FLOW
+	sortBy, _, exp := parseFeedSort(ctx)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
 	}
 
-	res, exp := c.communityService.AllRepliesOnPost(ctx, postId, userId, channelID, convertedLimit, convertedCurrentPage, sortBy)
+	cursor, exp := parseFeedCursor(ctx)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+
+	moderatorView, _ := strconv.ParseBool(strings.ToLower(ctx.Query(MODERATOR_VIEW)))
+
+	res, exp := c.communityService.AllRepliesOnPost(ctx, postId, userId, channelID, convertedLimit, convertedCurrentPage, sortBy, moderatorView, cursor)
 	if exp != nil {
 		log.Errorf("[AllRepliesOnPost] Error ocured while getting replies on a post")
 		SendApiResponseV1(ctx, nil, exp)
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	"github.com/Abhishekjha321/community_service/internal/media"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	AttachmentFilesPath  = "/attachments/files"
+	AttachmentUploadPath = "/attachments/upload"
+
+	attachmentFormField = "file"
+)
+
+// UploadAttachment accepts a multipart upload with the file in the "file" field.
+func (c *communityController) UploadAttachment(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "UploadAttachment")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[UploadAttachmentController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	fileHeader, err := ctx.FormFile(attachmentFormField)
+	if err != nil {
+		log.Errorf("[UploadAttachmentController] file isn't found in the form: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "A file is required in the \"file\" form field"))
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Errorf("[UploadAttachmentController] couldn't open uploaded file: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode))
+		return
+	}
+	defer file.Close()
+
+	res, exp := c.communityService.UploadAttachment(ctx.Request.Context(), userID, fileHeader.Filename, file, fileHeader.Size)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+func (c *communityController) CreateAttachmentUploadURL(ctx *gin.Context) {
+	var requestBody dto.RequestAttachmentUploadURL
+	log := logger.GetLogInstance(ctx, "CreateAttachmentUploadURL")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[CreateAttachmentUploadURLController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[CreateAttachmentUploadURLController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[CreateAttachmentUploadURLController] Error occured while binding json"))
+		return
+	}
+	if strings.TrimSpace(requestBody.FileName) == "" || requestBody.Size <= 0 {
+		log.Errorf("[CreateAttachmentUploadURLController] file name or size isn't found in request body")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "File name and size are required"))
+		return
+	}
+
+	res, exp := c.communityService.CreateAttachmentUploadURL(ctx.Request.Context(), &requestBody, userID)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+// ReceiveSignedUpload is the target of upload URLs signed by the local object
+// store. The signature in the query authorizes the request, so it needs no
+// user header.
+func (c *communityController) ReceiveSignedUpload(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "ReceiveSignedUpload")
+
+	expires, err := strconv.ParseInt(ctx.Query("expires"), 10, 64)
+	if err != nil || ctx.Query("key") == "" || ctx.Query("signature") == "" {
+		log.Errorf("[ReceiveSignedUploadController] upload url is missing its signature")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.QueryParamsIncorrectErrorCode))
+		return
+	}
+	upload := model.SignedUpload{
+		Key:         ctx.Query("key"),
+		ContentType: ctx.Query("content_type"),
+		Expires:     expires,
+		Signature:   ctx.Query("signature"),
+	}
+	if exp := c.communityService.ReceiveSignedUpload(ctx.Request.Context(), upload, ctx.Request.Body, ctx.Request.ContentLength); exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, nil, nil)
+}
+
+func (c *communityController) CompleteAttachmentUpload(ctx *gin.Context) {
+	var requestBody dto.RequestCompleteAttachmentUpload
+	log := logger.GetLogInstance(ctx, "CompleteAttachmentUpload")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[CompleteAttachmentUploadController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[CompleteAttachmentUploadController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[CompleteAttachmentUploadController] Error occured while binding json"))
+		return
+	}
+	if requestBody.AttachmentID == 0 {
+		log.Errorf("[CompleteAttachmentUploadController] attachment id isn't found in request body")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "Attachment id is required"))
+		return
+	}
+
+	res, exp := c.communityService.CompleteAttachmentUpload(ctx.Request.Context(), &requestBody, userID)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+func (c *communityController) GetAttachmentFile(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "GetAttachmentFile")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[GetAttachmentFileController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+	file, contentType, exp := c.communityService.GetAttachmentFile(ctx.Request.Context(), key, userID)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	defer file.Close()
+
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	// only images are shown inline, anything else is downloaded
+	if !media.IsImage(contentType) {
+		ctx.Header("Content-Disposition", "attachment")
+	}
+	if _, err := io.Copy(ctx.Writer, file); err != nil {
+		log.Errorf("[GetAttachmentFileController] couldn't send key: %s, err: %v", key, err)
+	}
+}
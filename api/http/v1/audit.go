@@ -0,0 +1,99 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ACTOR_ID    = "actor_id"
+	TARGET_TYPE = "target_type"
+	TARGET_ID   = "target_id"
+	REQUEST_ID  = "request_id"
+)
+
+// GetAuditLogs lists the moderation actions matching the query filters,
+// newest first.
+func (c *communityController) GetAuditLogs(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "GetAuditLogs")
+
+	filter, exp := parseAuditLogFilter(ctx)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	if limitParam := ctx.Query(LIMIT); limitParam != "" {
+		limit, err := strconv.Atoi(limitParam)
+		if err != nil {
+			log.Errorf("[GetAuditLogsController] couldn't convert limit: %s to integer in query params", limitParam)
+			SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+				exceptions.BadRequestErrorCode, "Limit is not correct"))
+			return
+		}
+		filter.Limit = limit
+	}
+	if cursorParam := ctx.Query(CURSOR); cursorParam != "" {
+		var cursor model.AuditLogCursor
+		if err := common.DecodeCursor(cursorParam, &cursor); err != nil {
+			log.Errorf("[GetAuditLogsController] invalid cursor: %s, err: %v", cursorParam, err)
+			SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.InvalidCursorErrorCode))
+			return
+		}
+		filter.Cursor = &cursor
+	}
+
+	res, exp := c.communityService.GetAuditLogs(ctx.Request.Context(), filter)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+// ExportAuditLogs downloads every moderation action matching the query
+// filters as a CSV file.
+func (c *communityController) ExportAuditLogs(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "ExportAuditLogs")
+
+	filter, exp := parseAuditLogFilter(ctx)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+
+	ctx.Header("Content-Type", "text/csv")
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="audit_logs_%d.csv"`, time.Now().Unix()))
+	ctx.Status(http.StatusOK)
+	if exp := c.communityService.ExportAuditLogs(ctx.Request.Context(), filter, ctx.Writer); exp != nil {
+		// the header is already sent, so a failure can only cut the file short
+		log.Errorf("[ExportAuditLogsController] export stopped early: %s", exp.ErrorMessage)
+	}
+}
+
+func parseAuditLogFilter(ctx *gin.Context) (model.AuditLogFilter, *exceptions.Exception) {
+	filter := model.AuditLogFilter{
+		ActorID:    ctx.Query(ACTOR_ID),
+		Action:     strings.ToUpper(ctx.Query(ACTION)),
+		TargetType: strings.ToUpper(ctx.Query(TARGET_TYPE)),
+		TargetID:   ctx.Query(TARGET_ID),
+		ChannelID:  ctx.Query(CHANNEL_ID),
+		RequestID:  ctx.Query(REQUEST_ID),
+	}
+	var exp *exceptions.Exception
+	if filter.From, exp = parseUnixQueryParam(ctx, FROM); exp != nil {
+		return filter, exp
+	}
+	if filter.To, exp = parseUnixQueryParam(ctx, TO); exp != nil {
+		return filter, exp
+	}
+	return filter, nil
+}
@@ -0,0 +1,201 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+const COLLECTION_ID = "collection_id"
+
+func (c *communityController) CreateBookmarkCollection(ctx *gin.Context) {
+	var requestBody dto.RequestBookmarkCollection
+	log := logger.GetLogInstance(ctx, "CreateBookmarkCollection")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[CreateBookmarkCollectionController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[CreateBookmarkCollectionController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[CreateBookmarkCollectionController] Error occured while binding json"))
+		return
+	}
+
+	res, exp := c.communityService.CreateBookmarkCollection(ctx.Request.Context(), &requestBody, userID)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+func (c *communityController) GetBookmarkCollections(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "GetBookmarkCollections")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[GetBookmarkCollectionsController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+
+	res, exp := c.communityService.GetBookmarkCollections(ctx.Request.Context(), userID)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+func (c *communityController) RenameBookmarkCollection(ctx *gin.Context) {
+	var requestBody dto.RequestBookmarkCollection
+	log := logger.GetLogInstance(ctx, "RenameBookmarkCollection")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[RenameBookmarkCollectionController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[RenameBookmarkCollectionController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[RenameBookmarkCollectionController] Error occured while binding json"))
+		return
+	}
+	if requestBody.CollectionID <= 0 {
+		log.Errorf("[RenameBookmarkCollectionController] collection id isn't found in request body")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "Collection id is required"))
+		return
+	}
+
+	res, exp := c.communityService.RenameBookmarkCollection(ctx.Request.Context(), &requestBody, userID)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+func (c *communityController) DeleteBookmarkCollection(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "DeleteBookmarkCollection")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[DeleteBookmarkCollectionController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	collectionID, err := strconv.ParseInt(ctx.Query(COLLECTION_ID), 10, 64)
+	if err != nil || collectionID <= 0 {
+		log.Errorf("[DeleteBookmarkCollectionController] collection id: %s isn't correct in query params", ctx.Query(COLLECTION_ID))
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.QueryParamsIncorrectErrorCode, "Collection id is not correct"))
+		return
+	}
+
+	if exp := c.communityService.DeleteBookmarkCollection(ctx.Request.Context(), collectionID, userID); exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, &SuccessResp{
+		Code:    "00000",
+		Message: "Success",
+	}, nil)
+}
+
+// SaveBookmark bookmarks a post and files it in a collection with a note.
+// Saving an already bookmarked post only moves it or changes its note.
+func (c *communityController) SaveBookmark(ctx *gin.Context) {
+	var requestBody dto.RequestSaveBookmark
+	log := logger.GetLogInstance(ctx, "SaveBookmark")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[SaveBookmarkController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[SaveBookmarkController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[SaveBookmarkController] Error occured while binding json"))
+		return
+	}
+	if requestBody.PostID <= 0 {
+		log.Errorf("[SaveBookmarkController] post id isn't found in request body")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode))
+		return
+	}
+
+	res, exp := c.communityService.SaveBookmark(ctx.Request.Context(), &requestBody, userID)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+// GetSavedItems lists the user's bookmarks of every channel. collection_id
+// narrows it to one collection, 0 being the bookmarks outside of any.
+func (c *communityController) GetSavedItems(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "GetSavedItems")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[GetSavedItemsController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+
+	var collectionID *int64
+	if collectionParam := ctx.Query(COLLECTION_ID); collectionParam != "" {
+		id, err := strconv.ParseInt(collectionParam, 10, 64)
+		if err != nil || id < 0 {
+			log.Errorf("[GetSavedItemsController] collection id: %s isn't correct in query params", collectionParam)
+			SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+				exceptions.QueryParamsIncorrectErrorCode, "Collection id is not correct"))
+			return
+		}
+		collectionID = &id
+	}
+	var limit int
+	if limitParam := ctx.Query(LIMIT); limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			log.Errorf("[GetSavedItemsController] couldn't convert limit: %s to integer in query params", limitParam)
+			SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+				exceptions.BadRequestErrorCode, "Limit is not correct"))
+			return
+		}
+	}
+
+	var cursor *model.SavedItemsCursor
+	if cursorParam := ctx.Query(CURSOR); cursorParam != "" {
+		var savedItemsCursor model.SavedItemsCursor
+		if err := common.DecodeCursor(cursorParam, &savedItemsCursor); err != nil {
+			log.Errorf("[GetSavedItemsController] invalid cursor: %s, err: %v", cursorParam, err)
+			SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.InvalidCursorErrorCode))
+			return
+		}
+		cursor = &savedItemsCursor
+	}
+
+	res, exp := c.communityService.GetSavedItems(ctx.Request.Context(), userID, collectionID, limit, cursor)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+func (c *communityController) GetChannelSettings(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "GetChannelSettings")
+	channelID := ctx.Query(CHANNEL_ID)
+	if channelID == "" {
+		log.Errorf("[GetChannelSettingsController] channel id not found in query params")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.QueryParamsIncorrectErrorCode, "Channel id not found in query params"))
+		return
+	}
+
+	userID := ctx.GetHeader(X_USER_ID)
+
+	res, exp := c.communityService.GetChannelSettings(ctx.Request.Context(), channelID, userID)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+func (c *communityController) UpdateChannelSettings(ctx *gin.Context) {
+	var requestBody dto.RequestChannelSettings
+	log := logger.GetLogInstance(ctx, "UpdateChannelSettings")
+
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[UpdateChannelSettingsController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[UpdateChannelSettingsController] Error occured while binding json"))
+		return
+	}
+	if requestBody.ChannelID == "" || !hasChannelSettings(&requestBody) {
+		log.Errorf("[UpdateChannelSettingsController] channel id or settings aren't found in request body")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "Channel id and at least one setting are required"))
+		return
+	}
+
+	res, exp := c.communityService.UpdateChannelSettings(ctx.Request.Context(), &requestBody)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+func hasChannelSettings(requestBody *dto.RequestChannelSettings) bool {
+	return requestBody.ScoringMode != "" || requestBody.ChannelMode != "" || requestBody.DefaultFlow != "" ||
+		requestBody.RepliesEnabled != nil || requestBody.ReactionsEnabled != nil || requestBody.MaxPostLength != nil ||
+		requestBody.SlowModeSeconds != nil || requestBody.ReportThreshold != nil
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+// GetChannel returns the visibility of a channel and what the user can do in
+// it.
+func (c *communityController) GetChannel(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "GetChannel")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[GetChannelController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	channelID := ctx.Query(CHANNEL_ID)
+	if channelID == "" {
+		log.Errorf("[GetChannelController] channel id not sent in query params")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.QueryParamsIncorrectErrorCode, "Channel id is missing"))
+		return
+	}
+
+	res, exp := c.communityService.GetChannel(ctx.Request.Context(), channelID, userID)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+func (c *communityController) JoinChannel(ctx *gin.Context) {
+	userID, requestBody, exp := bindChannelMembership(ctx, "JoinChannelController")
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+
+	res, exp := c.communityService.JoinChannel(ctx.Request.Context(), requestBody.ChannelID, userID)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+func (c *communityController) LeaveChannel(ctx *gin.Context) {
+	userID, requestBody, exp := bindChannelMembership(ctx, "LeaveChannelController")
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+
+	if exp := c.communityService.LeaveChannel(ctx.Request.Context(), requestBody.ChannelID, userID); exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, &SuccessResp{Code: "00000", Message: "Success"}, nil)
+}
+
+// InviteToChannel lets a moderator invite users to a channel.
+func (c *communityController) InviteToChannel(ctx *gin.Context) {
+	var requestBody dto.RequestChannelMembers
+	log := logger.GetLogInstance(ctx, "InviteToChannel")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[InviteToChannelController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[InviteToChannelController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[InviteToChannelController] Error occured while binding json"))
+		return
+	}
+	if requestBody.ChannelID == "" {
+		log.Errorf("[InviteToChannelController] channel id isn't found in request body")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "Channel id is required"))
+		return
+	}
+
+	if exp := c.communityService.InviteToChannel(ctx.Request.Context(), &requestBody, userID); exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, &SuccessResp{Code: "00000", Message: "Success"}, nil)
+}
+
+// UpdateChannel sets the visibility of a channel.
+func (c *communityController) UpdateChannel(ctx *gin.Context) {
+	var requestBody dto.RequestUpdateChannel
+	log := logger.GetLogInstance(ctx, "UpdateChannel")
+
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[UpdateChannelController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[UpdateChannelController] Error occured while binding json"))
+		return
+	}
+	if requestBody.ChannelID == "" {
+		log.Errorf("[UpdateChannelController] channel id isn't found in request body")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "Channel id is required"))
+		return
+	}
+
+	res, exp := c.communityService.UpdateChannel(ctx.Request.Context(), &requestBody)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+// AddChannelMembers adds users to a channel without inviting them first.
+func (c *communityController) AddChannelMembers(ctx *gin.Context) {
+	var requestBody dto.RequestChannelMembers
+	log := logger.GetLogInstance(ctx, "AddChannelMembers")
+
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[AddChannelMembersController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[AddChannelMembersController] Error occured while binding json"))
+		return
+	}
+	if requestBody.ChannelID == "" {
+		log.Errorf("[AddChannelMembersController] channel id isn't found in request body")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "Channel id is required"))
+		return
+	}
+
+	if exp := c.communityService.AddChannelMembers(ctx.Request.Context(), &requestBody); exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, &SuccessResp{Code: "00000", Message: "Success"}, nil)
+}
+
+func (c *communityController) RemoveChannelMember(ctx *gin.Context) {
+	var requestBody dto.RequestChannelMember
+	log := logger.GetLogInstance(ctx, "RemoveChannelMember")
+
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[RemoveChannelMemberController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[RemoveChannelMemberController] Error occured while binding json"))
+		return
+	}
+	if requestBody.ChannelID == "" || requestBody.UserID == "" {
+		log.Errorf("[RemoveChannelMemberController] channel id or user id isn't found in request body")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "Channel id and user id are required"))
+		return
+	}
+
+	if exp := c.communityService.RemoveChannelMember(ctx.Request.Context(), &requestBody, ctx.GetHeader(X_USER_ID)); exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, &SuccessResp{Code: "00000", Message: "Success"}, nil)
+}
+
+func bindChannelMembership(ctx *gin.Context, name string) (string, *dto.RequestChannelMembership, *exceptions.Exception) {
+	var requestBody dto.RequestChannelMembership
+	log := logger.GetLogInstance(ctx, name)
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[%s] x-user-id not found in header", name)
+		return "", nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode)
+	}
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[%s] Error occurred while binding request: %v", name, err)
+		return "", nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "["+name+"] Error occured while binding json")
+	}
+	if requestBody.ChannelID == "" {
+		log.Errorf("[%s] channel id isn't found in request body", name)
+		return "", nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "Channel id is required")
+	}
+	return userID, &requestBody, nil
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+const REASON = "reason"
+
+// RestorePost lets a moderator bring back a deleted post.
+func (c *communityController) RestorePost(ctx *gin.Context) {
+	var requestBody dto.RequestRestorePost
+	log := logger.GetLogInstance(ctx, "RestorePost")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[RestorePostController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[RestorePostController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[RestorePostController] Error occured while binding json"))
+		return
+	}
+	if requestBody.PostID == 0 {
+		log.Errorf("[RestorePostController] post id isn't found in request body")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode))
+		return
+	}
+
+	res, exp := c.communityService.RestorePost(ctx.Request.Context(), &requestBody, userID)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+func (c *communityController) EditPost(ctx *gin.Context) {
+	var requestBody dto.RequestEditPost
+	log := logger.GetLogInstance(ctx, "EditPost")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[EditPostController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[EditPostController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[EditPostController] Error occured while binding json"))
+		return
+	}
+	if requestBody.PostID == 0 || strings.TrimSpace(requestBody.Content) == "" {
+		log.Errorf("[EditPostController] post id or content isn't found in request body")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "Post id and content are required"))
+		return
+	}
+
+	res, exp := c.communityService.EditPost(ctx.Request.Context(), &requestBody, userID)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
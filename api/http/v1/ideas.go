@@ -0,0 +1,81 @@
+package api
+
+import (
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+func (c *communityController) UpdateIdeaStatus(ctx *gin.Context) {
+	var requestBody dto.RequestUpdateIdeaStatus
+	log := logger.GetLogInstance(ctx, "UpdateIdeaStatus")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[UpdateIdeaStatusController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[UpdateIdeaStatusController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[UpdateIdeaStatusController] Error occured while binding json"))
+		return
+	}
+	if requestBody.PostID == 0 || requestBody.Status == "" {
+		log.Errorf("[UpdateIdeaStatusController] post id or status isn't found in request body")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "Post id and status are required"))
+		return
+	}
+
+	res, exp := c.communityService.UpdateIdeaStatus(ctx.Request.Context(), &requestBody, userID)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+func (c *communityController) AddChannelModerator(ctx *gin.Context) {
+	requestBody, exp := bindChannelModerator(ctx)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	if exp := c.communityService.AddChannelModerator(ctx.Request.Context(), requestBody, ctx.GetHeader(X_USER_ID)); exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, &SuccessResp{Code: "00000", Message: "Success"}, nil)
+}
+
+func (c *communityController) RemoveChannelModerator(ctx *gin.Context) {
+	requestBody, exp := bindChannelModerator(ctx)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	if exp := c.communityService.RemoveChannelModerator(ctx.Request.Context(), requestBody, ctx.GetHeader(X_USER_ID)); exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, &SuccessResp{Code: "00000", Message: "Success"}, nil)
+}
+
+func bindChannelModerator(ctx *gin.Context) (*dto.RequestChannelModerator, *exceptions.Exception) {
+	var requestBody dto.RequestChannelModerator
+	log := logger.GetLogInstance(ctx, "bindChannelModerator")
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("Error occurred while binding request: %v", err)
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "Error occured while binding json")
+	}
+	if requestBody.ChannelID == "" || requestBody.UserID == "" {
+		log.Errorf("channel id or user id isn't found in request body")
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "Channel id and user id are required")
+	}
+	return &requestBody, nil
+}
@@ -10,4 +10,54 @@ type CommunityController interface {
 	ReportPost(ctx *gin.Context)
 	AllRepliesOnPost(ctx *gin.Context)
 	MarkNotificationsAsRead(ctx *gin.Context)
+	SearchPosts(ctx *gin.Context)
+	GetTrendingPosts(ctx *gin.Context)
+	GetTrendingChannels(ctx *gin.Context)
+	UpdateIdeaStatus(ctx *gin.Context)
+	GetNotifications(ctx *gin.Context)
+	ReadNotifications(ctx *gin.Context)
+	AddChannelModerator(ctx *gin.Context)
+	RemoveChannelModerator(ctx *gin.Context)
+	MergePosts(ctx *gin.Context)
+	RevertPostMerge(ctx *gin.Context)
+	RestorePost(ctx *gin.Context)
+	PinPost(ctx *gin.Context)
+	VotePost(ctx *gin.Context)
+	GetChannelSettings(ctx *gin.Context)
+	UpdateChannelSettings(ctx *gin.Context)
+	AcceptAnswer(ctx *gin.Context)
+	VotePoll(ctx *gin.Context)
+	UploadAttachment(ctx *gin.Context)
+	CreateAttachmentUploadURL(ctx *gin.Context)
+	ReceiveSignedUpload(ctx *gin.Context)
+	CompleteAttachmentUpload(ctx *gin.Context)
+	GetAttachmentFile(ctx *gin.Context)
+	EditPost(ctx *gin.Context)
+	SearchMentionUsers(ctx *gin.Context)
+	GetPopularTags(ctx *gin.Context)
+	GetThread(ctx *gin.Context)
+	CreateBookmarkCollection(ctx *gin.Context)
+	GetBookmarkCollections(ctx *gin.Context)
+	RenameBookmarkCollection(ctx *gin.Context)
+	DeleteBookmarkCollection(ctx *gin.Context)
+	SaveBookmark(ctx *gin.Context)
+	GetSavedItems(ctx *gin.Context)
+	AddUserRelation(ctx *gin.Context)
+	RemoveUserRelation(ctx *gin.Context)
+	GetUserRelations(ctx *gin.Context)
+	GetChannel(ctx *gin.Context)
+	JoinChannel(ctx *gin.Context)
+	LeaveChannel(ctx *gin.Context)
+	InviteToChannel(ctx *gin.Context)
+	UpdateChannel(ctx *gin.Context)
+	AddChannelMembers(ctx *gin.Context)
+	RemoveChannelMember(ctx *gin.Context)
+	GetChannelBlocklist(ctx *gin.Context)
+	UpdateChannelBlocklist(ctx *gin.Context)
+	GetPostFlags(ctx *gin.Context)
+	ReviewPostFlags(ctx *gin.Context)
+	GetUserStatus(ctx *gin.Context)
+	SetUserStatus(ctx *gin.Context)
+	GetAuditLogs(ctx *gin.Context)
+	ExportAuditLogs(ctx *gin.Context)
 }
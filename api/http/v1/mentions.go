@@ -0,0 +1,40 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/Abhishekjha321/community_service/exceptions"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+// SearchMentionUsers backs @mention autocomplete in a channel.
+func (c *communityController) SearchMentionUsers(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "SearchMentionUsers")
+
+	channelID := ctx.Query(CHANNEL_ID)
+	if channelID == "" {
+		log.Errorf("[SearchMentionUsersController] channel id not sent in query params")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.QueryParamsIncorrectErrorCode, "Channel id is missing"))
+		return
+	}
+	var limit int
+	if limitParam := ctx.Query(LIMIT); limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			log.Errorf("[SearchMentionUsersController] couldn't convert limit: %s to integer in query params", limitParam)
+			SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+				exceptions.BadRequestErrorCode, "Limit is not correct"))
+			return
+		}
+	}
+
+	res, exp := c.communityService.SearchMentionUsers(ctx.Request.Context(), channelID, ctx.Query(QUERY), ctx.GetHeader(X_USER_ID), limit)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
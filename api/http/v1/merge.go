@@ -0,0 +1,70 @@
+package api
+
+import (
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+func (c *communityController) MergePosts(ctx *gin.Context) {
+	var requestBody dto.RequestMergePosts
+	log := logger.GetLogInstance(ctx, "MergePosts")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[MergePostsController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[MergePostsController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[MergePostsController] Error occured while binding json"))
+		return
+	}
+	if requestBody.SourcePostID == 0 || requestBody.TargetPostID == 0 {
+		log.Errorf("[MergePostsController] source or target post id isn't found in request body")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "Source and target post ids are required"))
+		return
+	}
+
+	res, exp := c.communityService.MergePosts(ctx.Request.Context(), &requestBody, userID)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+func (c *communityController) RevertPostMerge(ctx *gin.Context) {
+	var requestBody dto.RequestRevertPostMerge
+	log := logger.GetLogInstance(ctx, "RevertPostMerge")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[RevertPostMergeController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[RevertPostMergeController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[RevertPostMergeController] Error occured while binding json"))
+		return
+	}
+	if requestBody.MergeID == 0 {
+		log.Errorf("[RevertPostMergeController] merge id isn't found in request body")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "Merge id is required"))
+		return
+	}
+
+	res, exp := c.communityService.RevertPostMerge(ctx.Request.Context(), &requestBody, userID)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+const FLAG_STATUS = "status"
+
+func (c *communityController) GetChannelBlocklist(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "GetChannelBlocklist")
+	channelID := ctx.Query(CHANNEL_ID)
+	if channelID == "" {
+		log.Errorf("[GetChannelBlocklistController] channel id not found in query params")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.QueryParamsIncorrectErrorCode, "Channel id not found in query params"))
+		return
+	}
+
+	res, exp := c.communityService.GetChannelBlocklist(ctx.Request.Context(), channelID)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+// UpdateChannelBlocklist replaces the words blocked in a channel.
+func (c *communityController) UpdateChannelBlocklist(ctx *gin.Context) {
+	var requestBody dto.RequestChannelBlocklist
+	log := logger.GetLogInstance(ctx, "UpdateChannelBlocklist")
+
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[UpdateChannelBlocklistController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[UpdateChannelBlocklistController] Error occured while binding json"))
+		return
+	}
+	if requestBody.ChannelID == "" {
+		log.Errorf("[UpdateChannelBlocklistController] channel id isn't found in request body")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "Channel id is required"))
+		return
+	}
+
+	res, exp := c.communityService.UpdateChannelBlocklist(ctx.Request.Context(), &requestBody)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+// GetPostFlags lists the posts the moderation pipeline flagged in a channel.
+func (c *communityController) GetPostFlags(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "GetPostFlags")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[GetPostFlagsController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	channelID := ctx.Query(CHANNEL_ID)
+	if channelID == "" {
+		log.Errorf("[GetPostFlagsController] channel id not found in query params")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.QueryParamsIncorrectErrorCode, "Channel id not found in query params"))
+		return
+	}
+
+	var limit int
+	if limitParam := ctx.Query(LIMIT); limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			log.Errorf("[GetPostFlagsController] couldn't convert limit: %s to integer in query params", limitParam)
+			SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+				exceptions.BadRequestErrorCode, "Limit is not correct"))
+			return
+		}
+	}
+
+	var cursor *model.PostFlagCursor
+	if cursorParam := ctx.Query(CURSOR); cursorParam != "" {
+		var flagCursor model.PostFlagCursor
+		if err := common.DecodeCursor(cursorParam, &flagCursor); err != nil {
+			log.Errorf("[GetPostFlagsController] invalid cursor: %s, err: %v", cursorParam, err)
+			SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.InvalidCursorErrorCode))
+			return
+		}
+		cursor = &flagCursor
+	}
+
+	res, exp := c.communityService.GetPostFlags(ctx.Request.Context(), channelID, ctx.Query(FLAG_STATUS), userID, limit, cursor)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+// ReviewPostFlags lets a moderator approve or remove a flagged post.
+func (c *communityController) ReviewPostFlags(ctx *gin.Context) {
+	var requestBody dto.RequestReviewPostFlags
+	log := logger.GetLogInstance(ctx, "ReviewPostFlags")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[ReviewPostFlagsController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[ReviewPostFlagsController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[ReviewPostFlagsController] Error occured while binding json"))
+		return
+	}
+	if requestBody.PostID == 0 {
+		log.Errorf("[ReviewPostFlagsController] post id isn't found in request body")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode))
+		return
+	}
+
+	if exp := c.communityService.ReviewPostFlags(ctx.Request.Context(), &requestBody, userID); exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, &SuccessResp{Code: "00000", Message: "Success"}, nil)
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+const UNREAD_ONLY = "unread_only"
+
+func (c *communityController) GetNotifications(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "GetNotifications")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[GetNotificationsController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+
+	var limit int
+	if limitParam := ctx.Query(LIMIT); limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			log.Errorf("[GetNotificationsController] couldn't convert limit: %s to integer in query params", limitParam)
+			SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+				exceptions.BadRequestErrorCode, "Limit is not correct"))
+			return
+		}
+	}
+	unreadOnly, _ := strconv.ParseBool(strings.ToLower(ctx.Query(UNREAD_ONLY)))
+
+	var cursor *model.NotificationCursor
+	if cursorParam := ctx.Query(CURSOR); cursorParam != "" {
+		var notificationCursor model.NotificationCursor
+		if err := common.DecodeCursor(cursorParam, &notificationCursor); err != nil {
+			log.Errorf("[GetNotificationsController] invalid cursor: %s, err: %v", cursorParam, err)
+			SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.InvalidCursorErrorCode))
+			return
+		}
+		cursor = &notificationCursor
+	}
+
+	res, exp := c.communityService.GetNotifications(ctx.Request.Context(), userID, limit, unreadOnly, cursor)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+func (c *communityController) ReadNotifications(ctx *gin.Context) {
+	var requestBody dto.RequestReadNotifications
+	log := logger.GetLogInstance(ctx, "ReadNotifications")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[ReadNotificationsController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[ReadNotificationsController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[ReadNotificationsController] Error occured while binding json"))
+		return
+	}
+
+	res, exp := c.communityService.ReadNotifications(ctx.Request.Context(), userID, requestBody.IDs)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+// PinPost lets a moderator pin a post to the top of its channel or unpin it.
+func (c *communityController) PinPost(ctx *gin.Context) {
+	var requestBody dto.RequestPinPost
+	log := logger.GetLogInstance(ctx, "PinPost")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[PinPostController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[PinPostController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[PinPostController] Error occured while binding json"))
+		return
+	}
+	if requestBody.PostID == 0 {
+		log.Errorf("[PinPostController] post id isn't found in request body")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode))
+		return
+	}
+
+	res, exp := c.communityService.PinPost(ctx.Request.Context(), &requestBody, userID)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/ratelimit"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	X_API_KEY = "x-api-key"
+
+	PublicRouteGroup  = "public"
+	PrivateRouteGroup = "private"
+
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+	RetryAfterHeader         = "Retry-After"
+)
+
+// RateLimitMiddleware limits the requests to a route group with a token bucket
+// per client kept in Redis, so the limit holds across instances. Clients on
+// the deny list are refused and clients on the allow list are never limited.
+// Requests go through when Redis can't be reached. An API key that isn't in
+// apiKeys is ignored, so made up keys can't be used to get a fresh bucket, and
+// requests sent with a user id also count against the IP's bucket, so made
+// up user ids can't either.
+func RateLimitMiddleware(limiter *ratelimit.Limiter, group string, rate ratelimit.Rate, apiKeys ratelimit.KeySet, allowList *ratelimit.ClientList, denyList *ratelimit.ClientList) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		log := logger.GetLogInstance(ctx, "RateLimitMiddleware")
+
+		client := ratelimit.Client{
+			UserID: ctx.GetHeader(X_USER_ID),
+			IP:     ctx.ClientIP(),
+		}
+		if apiKey := ctx.GetHeader(X_API_KEY); apiKeys.Contains(apiKey) {
+			client.APIKey = apiKey
+		}
+		if denyList.Contains(client) {
+			log.Errorf("[RateLimitMiddleware] refused denied client: %s", client.Key())
+			SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.RequestDeniedErrorCode))
+			ctx.Abort()
+			return
+		}
+		if limiter == nil || rate.Limit <= 0 || rate.Window <= 0 || allowList.Contains(client) {
+			ctx.Next()
+			return
+		}
+
+		var bucket *ratelimit.Bucket
+		for _, key := range client.Keys() {
+			taken, err := limiter.Take(ctx.Request.Context(), ratelimit.Key("http:"+group, key), rate)
+			if err != nil {
+				log.Errorf("[RateLimitMiddleware] couldn't count request of client: %s, err: %v", key, err)
+				ctx.Next()
+				return
+			}
+			if bucket == nil || !taken.Taken || taken.Remaining < bucket.Remaining {
+				bucket = taken
+			}
+			if !taken.Taken {
+				break
+			}
+		}
+		ctx.Header(RateLimitLimitHeader, strconv.Itoa(rate.Limit))
+		ctx.Header(RateLimitRemainingHeader, strconv.Itoa(bucket.Remaining))
+		ctx.Header(RateLimitResetHeader, strconv.FormatInt(retryAfterSeconds(bucket.Reset), 10))
+		if !bucket.Taken {
+			log.Infof("[RateLimitMiddleware] client: %s is rate limited on %s routes for %s", client.Key(), group, bucket.RetryAfter)
+			exp := exceptions.GetExceptionByErrorCode(exceptions.RateLimitedErrorCode)
+			exp.RetryAfter = bucket.RetryAfter
+			SendApiResponseV1(ctx, nil, exp)
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	TARGET_USER_ID = "target_user_id"
+	RELATION_TYPE  = "type"
+)
+
+// AddUserRelation blocks or mutes the target user for the caller.
+func (c *communityController) AddUserRelation(ctx *gin.Context) {
+	var requestBody dto.RequestUserRelation
+	log := logger.GetLogInstance(ctx, "AddUserRelation")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[AddUserRelationController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[AddUserRelationController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[AddUserRelationController] Error occured while binding json"))
+		return
+	}
+
+	if exp := c.communityService.AddUserRelation(ctx.Request.Context(), &requestBody, userID); exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, &SuccessResp{
+		Code:    "00000",
+		Message: "Success",
+	}, nil)
+}
+
+// RemoveUserRelation unblocks or unmutes the user given in the query params.
+func (c *communityController) RemoveUserRelation(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "RemoveUserRelation")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[RemoveUserRelationController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+	requestBody := dto.RequestUserRelation{
+		TargetUserID: ctx.Query(TARGET_USER_ID),
+		Type:         ctx.Query(RELATION_TYPE),
+	}
+
+	if exp := c.communityService.RemoveUserRelation(ctx.Request.Context(), &requestBody, userID); exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, &SuccessResp{
+		Code:    "00000",
+		Message: "Success",
+	}, nil)
+}
+
+// GetUserRelations lists who the caller blocked or muted, optionally only
+// one of the two.
+func (c *communityController) GetUserRelations(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "GetUserRelations")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	if userID == "" {
+		log.Errorf("[GetUserRelationsController] x-user-id not found in header")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode))
+		return
+	}
+
+	res, exp := c.communityService.GetUserRelations(ctx.Request.Context(), userID, ctx.Query(RELATION_TYPE))
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	X_REQUEST_ID = "x-request-id"
+
+	maxRequestIDLength = 128
+)
+
+// RequestIDMiddleware gives every request an id, the caller's x-request-id
+// header when it sends a usable one. The id is sent back in that header and
+// kept in the request context, where the logs and the audit log read it.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := strings.TrimSpace(ctx.GetHeader(X_REQUEST_ID))
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = uuid.NewString()
+		}
+		ctx.Set(common.REQUEST_ID, requestID)
+		ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), common.REQUEST_ID, requestID))
+		ctx.Header(X_REQUEST_ID, requestID)
+		ctx.Next()
+	}
+}
@@ -2,6 +2,8 @@ package api
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/Abhishekjha321/community_service/exceptions"
 	"github.com/gin-gonic/gin"
@@ -13,6 +15,9 @@ type ErrorResponse struct {
 
 	// message
 	Message string `json:"message,omitempty"`
+
+	// seconds to wait before retrying a rate limited request
+	RetryAfter int64 `json:"retry_after,omitempty"`
 }
 
 type SuccessResp struct {
@@ -23,9 +28,15 @@ type SuccessResp struct {
 
 func SendApiResponseV1(ctx *gin.Context, apiResp interface{}, apiErr *exceptions.Exception) {
 	if apiErr != nil {
+		var retryAfter int64
+		if apiErr.RetryAfter > 0 {
+			retryAfter = retryAfterSeconds(apiErr.RetryAfter)
+			ctx.Header(RetryAfterHeader, strconv.FormatInt(retryAfter, 10))
+		}
 		ctx.JSON(apiErr.HttpCode, &ErrorResponse{
-			Code:    apiErr.ErrorCode.String(),
-			Message: apiErr.ErrorMessage.String(),
+			Code:       apiErr.ErrorCode.String(),
+			Message:    apiErr.ErrorMessage.String(),
+			RetryAfter: retryAfter,
 		})
 		return
 	}
@@ -40,3 +51,13 @@ func SendApiResponseV1(ctx *gin.Context, apiResp interface{}, apiErr *exceptions
 		Message: "SUCCESS",
 	})
 }
+
+// retryAfterSeconds rounds a wait up to whole seconds, as Retry-After is
+// given in seconds.
+func retryAfterSeconds(wait time.Duration) int64 {
+	seconds := int64(wait / time.Second)
+	if wait%time.Second > 0 {
+		seconds++
+	}
+	return seconds
+}
@@ -10,9 +10,9 @@ const (
 	PrivateApiV1PathPrefix = "/private/community/v1"
 )
 
-func AddPublicRoutes(router *gin.Engine, communityController CommunityController) {
+func AddPublicRoutes(router *gin.Engine, communityController CommunityController, middlewares ...gin.HandlerFunc) {
 	// Public API routes
-	v1Public := router.Group(PublicApiV1PathPrefix)
+	v1Public := router.Group(PublicApiV1PathPrefix, middlewares...)
 	{
 		v1Public.GET("/health", func(ctx *gin.Context) {
 			ctx.JSON(http.StatusOK, gin.H{"status": "ok"})
@@ -21,18 +21,68 @@ func AddPublicRoutes(router *gin.Engine, communityController CommunityController
 		v1Public.POST("/like", communityController.LikePost)
 		v1Public.DELETE("/post", communityController.DeletePost)
 		v1Public.POST("/post", communityController.CreatePost)
+		v1Public.PUT("/post", communityController.EditPost)
 		v1Public.POST("/report", communityController.ReportPost)
 		v1Public.GET("/replies", communityController.AllRepliesOnPost)
+		v1Public.GET("/thread", communityController.GetThread)
 		v1Public.GET("/read_status", communityController.MarkNotificationsAsRead)
+		v1Public.GET("/search", communityController.SearchPosts)
+		v1Public.GET("/trending/posts", communityController.GetTrendingPosts)
+		v1Public.GET("/trending/channels", communityController.GetTrendingChannels)
+		v1Public.PUT("/ideas/status", communityController.UpdateIdeaStatus)
+		v1Public.GET("/notifications", communityController.GetNotifications)
+		v1Public.PUT("/notifications/read", communityController.ReadNotifications)
+		v1Public.POST("/post/merge", communityController.MergePosts)
+		v1Public.POST("/post/merge/revert", communityController.RevertPostMerge)
+		v1Public.POST("/post/restore", communityController.RestorePost)
+		v1Public.PUT("/post/pin", communityController.PinPost)
+		v1Public.POST("/vote", communityController.VotePost)
+		v1Public.GET("/channel/settings", communityController.GetChannelSettings)
+		v1Public.PUT("/answers/accept", communityController.AcceptAnswer)
+		v1Public.POST("/poll/vote", communityController.VotePoll)
+		v1Public.POST("/attachments", communityController.UploadAttachment)
+		v1Public.POST("/attachments/upload-url", communityController.CreateAttachmentUploadURL)
+		v1Public.PUT(AttachmentUploadPath, communityController.ReceiveSignedUpload)
+		v1Public.POST("/attachments/complete", communityController.CompleteAttachmentUpload)
+		v1Public.GET(AttachmentFilesPath+"/*key", communityController.GetAttachmentFile)
+		v1Public.GET("/mentions/users", communityController.SearchMentionUsers)
+		v1Public.GET("/tags/popular", communityController.GetPopularTags)
+		v1Public.GET("/bookmarks/saved", communityController.GetSavedItems)
+		v1Public.PUT("/bookmarks", communityController.SaveBookmark)
+		v1Public.GET("/bookmarks/collections", communityController.GetBookmarkCollections)
+		v1Public.POST("/bookmarks/collections", communityController.CreateBookmarkCollection)
+		v1Public.PUT("/bookmarks/collections", communityController.RenameBookmarkCollection)
+		v1Public.DELETE("/bookmarks/collections", communityController.DeleteBookmarkCollection)
+		v1Public.GET("/relations", communityController.GetUserRelations)
+		v1Public.POST("/relations", communityController.AddUserRelation)
+		v1Public.DELETE("/relations", communityController.RemoveUserRelation)
+		v1Public.GET("/channel", communityController.GetChannel)
+		v1Public.POST("/channel/join", communityController.JoinChannel)
+		v1Public.POST("/channel/leave", communityController.LeaveChannel)
+		v1Public.POST("/channel/invite", communityController.InviteToChannel)
+		v1Public.GET("/moderation/flags", communityController.GetPostFlags)
+		v1Public.POST("/moderation/flags/review", communityController.ReviewPostFlags)
 	}
 }
 
-func AddPrivateRoutes(router *gin.Engine, communityController CommunityController) {
+func AddPrivateRoutes(router *gin.Engine, communityController CommunityController, middlewares ...gin.HandlerFunc) {
 	// Private API routes
-	v1Private := router.Group(PrivateApiV1PathPrefix)
+	v1Private := router.Group(PrivateApiV1PathPrefix, middlewares...)
 	{
 		v1Private.GET("/health", func(ctx *gin.Context) {
 			ctx.JSON(http.StatusOK, gin.H{"status": "ok"})
 		})
+		v1Private.POST("/moderators", communityController.AddChannelModerator)
+		v1Private.DELETE("/moderators", communityController.RemoveChannelModerator)
+		v1Private.PUT("/channel/settings", communityController.UpdateChannelSettings)
+		v1Private.PUT("/channel", communityController.UpdateChannel)
+		v1Private.POST("/channel/members", communityController.AddChannelMembers)
+		v1Private.DELETE("/channel/members", communityController.RemoveChannelMember)
+		v1Private.GET("/channel/blocklist", communityController.GetChannelBlocklist)
+		v1Private.PUT("/channel/blocklist", communityController.UpdateChannelBlocklist)
+		v1Private.GET("/user/status", communityController.GetUserStatus)
+		v1Private.PUT("/user/status", communityController.SetUserStatus)
+		v1Private.GET("/audit/logs", communityController.GetAuditLogs)
+		v1Private.GET("/audit/logs/export", communityController.ExportAuditLogs)
 	}
 }
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	QUERY     = "q"
+	FORUM_ID  = "forum_id"
+	AUTHOR_ID = "author_id"
+	TYPE      = "type"
+	FROM      = "from"
+	TO        = "to"
+	CURSOR    = "cursor"
+)
+
+func (c *communityController) SearchPosts(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "SearchPosts")
+
+	filter := model.SearchFilter{
+		Query:     strings.TrimSpace(ctx.Query(QUERY)),
+		ChannelID: ctx.Query(CHANNEL_ID),
+		AuthorID:  ctx.Query(AUTHOR_ID),
+		Type:      strings.ToUpper(ctx.Query(TYPE)),
+		Viewer:    ctx.GetHeader(X_USER_ID),
+	}
+	if filter.Query == "" {
+		log.Errorf("[SearchPostsController] search query not sent in query params")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.QueryParamsIncorrectErrorCode, "Search query is missing"))
+		return
+	}
+	if filter.Type != "" && filter.Type != common.POST_TYPE_COMMENT && filter.Type != common.POST_TYPE_REPLY {
+		log.Errorf("[SearchPostsController] invalid type: %s in query params", filter.Type)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.QueryParamsIncorrectErrorCode, "Type should be COMMENT or REPLY"))
+		return
+	}
+
+	if forumID := ctx.Query(FORUM_ID); forumID != "" {
+		convertedForumID, err := strconv.ParseInt(forumID, 10, 64)
+		if err != nil {
+			log.Errorf("[SearchPostsController] couldn't convert forum_id: %s to integer in query params", forumID)
+			SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+				exceptions.QueryParamsIncorrectErrorCode, "Forum id is not correct"))
+			return
+		}
+		filter.ForumID = convertedForumID
+	}
+
+	var exp *exceptions.Exception
+	if filter.From, exp = parseUnixQueryParam(ctx, FROM); exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	if filter.To, exp = parseUnixQueryParam(ctx, TO); exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+
+	limit := ctx.Query(LIMIT)
+	if limit == "" {
+		limit = DefaultPageLimit
+	}
+	convertedLimit, err := strconv.Atoi(limit)
+	if err != nil {
+		log.Errorf("[SearchPostsController] couldn't convert limit: %s to integer in query params", limit)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "Limit is missing"))
+		return
+	}
+	filter.Limit = convertedLimit
+
+	if cursor := ctx.Query(CURSOR); cursor != "" {
+		var searchCursor model.SearchCursor
+		if err := common.DecodeCursor(cursor, &searchCursor); err != nil {
+			log.Errorf("[SearchPostsController] invalid cursor: %s, err: %v", cursor, err)
+			SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.InvalidCursorErrorCode))
+			return
+		}
+		filter.Cursor = &searchCursor
+	}
+
+	res, exp := c.communityService.SearchPosts(ctx, filter)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+// parseUnixQueryParam reads an optional unix timestamp (seconds) from the query string.
+func parseUnixQueryParam(ctx *gin.Context, key string) (time.Time, *exceptions.Exception) {
+	value := ctx.Query(key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		logger.GetLogInstance(ctx, "parseUnixQueryParam").Errorf("couldn't convert %s: %s to unix timestamp", key, value)
+		return time.Time{}, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.QueryParamsIncorrectErrorCode, key+" should be a unix timestamp")
+	}
+	return time.Unix(seconds, 0), nil
+}
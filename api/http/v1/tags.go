@@ -0,0 +1,51 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/Abhishekjha321/community_service/exceptions"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+// GetPopularTags lists the most used hashtags of a channel or a forum.
+func (c *communityController) GetPopularTags(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "GetPopularTags")
+
+	channelID := ctx.Query(CHANNEL_ID)
+	var forumID int64
+	if forumParam := ctx.Query(FORUM_ID); forumParam != "" {
+		var err error
+		forumID, err = strconv.ParseInt(forumParam, 10, 64)
+		if err != nil {
+			log.Errorf("[GetPopularTagsController] couldn't convert forum_id: %s to integer in query params", forumParam)
+			SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+				exceptions.QueryParamsIncorrectErrorCode, "Forum id is not correct"))
+			return
+		}
+	}
+	if channelID == "" && forumID == 0 {
+		log.Errorf("[GetPopularTagsController] channel id or forum id not sent in query params")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.QueryParamsIncorrectErrorCode, "Channel id or forum id is required"))
+		return
+	}
+	var limit int
+	if limitParam := ctx.Query(LIMIT); limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			log.Errorf("[GetPopularTagsController] couldn't convert limit: %s to integer in query params", limitParam)
+			SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+				exceptions.BadRequestErrorCode, "Limit is not correct"))
+			return
+		}
+	}
+
+	res, exp := c.communityService.GetPopularTags(ctx.Request.Context(), channelID, forumID, ctx.GetHeader(X_USER_ID), limit)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+const QueryParamDepth = "depth"
+
+// GetThread returns the reply tree of a post. limit applies to every branch
+// and depth to the number of reply levels; a branch's next_cursor is sent
+// back with the post_id of that branch to load more of it.
+func (c *communityController) GetThread(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "GetThread")
+
+	userID := ctx.GetHeader(X_USER_ID)
+	postID, err := strconv.ParseInt(ctx.Query(POST_ID), 10, 64)
+	if err != nil || postID <= 0 {
+		log.Errorf("[GetThreadController] post id: %s isn't correct in query params", ctx.Query(POST_ID))
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode))
+		return
+	}
+
+	var limit, depth int
+	if limitParam := ctx.Query(LIMIT); limitParam != "" {
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			log.Errorf("[GetThreadController] couldn't convert limit: %s to integer in query params", limitParam)
+			SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+				exceptions.BadRequestErrorCode, "Limit is not correct"))
+			return
+		}
+	}
+	if depthParam := ctx.Query(QueryParamDepth); depthParam != "" {
+		depth, err = strconv.Atoi(depthParam)
+		if err != nil {
+			log.Errorf("[GetThreadController] couldn't convert depth: %s to integer in query params", depthParam)
+			SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+				exceptions.BadRequestErrorCode, "Depth is not correct"))
+			return
+		}
+	}
+
+	var cursor *model.ThreadCursor
+	if cursorParam := ctx.Query(CURSOR); cursorParam != "" {
+		var threadCursor model.ThreadCursor
+		if err := common.DecodeCursor(cursorParam, &threadCursor); err != nil {
+			log.Errorf("[GetThreadController] invalid cursor: %s, err: %v", cursorParam, err)
+			SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCode(exceptions.InvalidCursorErrorCode))
+			return
+		}
+		cursor = &threadCursor
+	}
+
+	moderatorView, _ := strconv.ParseBool(strings.ToLower(ctx.Query(MODERATOR_VIEW)))
+
+	res, exp := c.communityService.GetThread(ctx.Request.Context(), postID, userID, depth, limit, moderatorView, cursor)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
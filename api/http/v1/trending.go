@@ -0,0 +1,75 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/Abhishekjha321/community_service/exceptions"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+func (c *communityController) GetTrendingPosts(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "GetTrendingPosts")
+
+	channelID := ctx.Query(CHANNEL_ID)
+	forumID := ctx.Query(FORUM_ID)
+	if channelID == "" && forumID == "" {
+		log.Errorf("[GetTrendingPostsController] neither channel id nor forum id sent in query params")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.QueryParamsIncorrectErrorCode, "Channel id or forum id is missing"))
+		return
+	}
+	var convertedForumID int64
+	if forumID != "" {
+		var err error
+		convertedForumID, err = strconv.ParseInt(forumID, 10, 64)
+		if err != nil {
+			log.Errorf("[GetTrendingPostsController] couldn't convert forum_id: %s to integer in query params", forumID)
+			SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+				exceptions.QueryParamsIncorrectErrorCode, "Forum id is not correct"))
+			return
+		}
+	}
+
+	limit, exp := parseTrendingLimit(ctx)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+
+	res, exp := c.communityService.GetTrendingPosts(ctx, channelID, convertedForumID, ctx.GetHeader(X_USER_ID), limit)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+func (c *communityController) GetTrendingChannels(ctx *gin.Context) {
+	limit, exp := parseTrendingLimit(ctx)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+
+	res, exp := c.communityService.GetTrendingChannels(ctx, ctx.GetHeader(X_USER_ID), limit)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+func parseTrendingLimit(ctx *gin.Context) (int, *exceptions.Exception) {
+	limit := ctx.Query(LIMIT)
+	if limit == "" {
+		limit = DefaultPageLimit
+	}
+	convertedLimit, err := strconv.Atoi(limit)
+	if err != nil {
+		logger.GetLogInstance(ctx, "parseTrendingLimit").Errorf("couldn't convert limit: %s to integer in query params", limit)
+		return 0, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "Limit is not correct")
+	}
+	return convertedLimit, nil
+}
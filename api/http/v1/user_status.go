@@ -0,0 +1,57 @@
+package api
+
+import (
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	USER_ID        = "user_id"
+	MODERATOR_VIEW = "moderator_view"
+)
+
+func (c *communityController) GetUserStatus(ctx *gin.Context) {
+	log := logger.GetLogInstance(ctx, "GetUserStatus")
+	userID := ctx.Query(USER_ID)
+	if userID == "" {
+		log.Errorf("[GetUserStatusController] user id not found in query params")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.QueryParamsIncorrectErrorCode, "User id not found in query params"))
+		return
+	}
+
+	res, exp := c.communityService.GetUserStatus(ctx.Request.Context(), userID)
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
+
+// SetUserStatus shadow bans a user or makes them active again.
+func (c *communityController) SetUserStatus(ctx *gin.Context) {
+	var requestBody dto.RequestUserStatus
+	log := logger.GetLogInstance(ctx, "SetUserStatus")
+
+	if err := ctx.BindJSON(&requestBody); err != nil {
+		log.Errorf("[SetUserStatusController] Error occurred while binding request: %v", err)
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "[SetUserStatusController] Error occured while binding json"))
+		return
+	}
+	if requestBody.UserID == "" {
+		log.Errorf("[SetUserStatusController] user id isn't found in request body")
+		SendApiResponseV1(ctx, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(
+			exceptions.BadRequestErrorCode, "User id is required"))
+		return
+	}
+
+	res, exp := c.communityService.SetUserStatus(ctx.Request.Context(), &requestBody, ctx.GetHeader(X_USER_ID))
+	if exp != nil {
+		SendApiResponseV1(ctx, nil, exp)
+		return
+	}
+	SendApiResponseV1(ctx, res, nil)
+}
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -10,12 +12,17 @@ import (
 
 	"github.com/Abhishekjha321/community_service/storage/cache" // write this code locally
 	api "github.com/Abhishekjha321/community_service/api/http/v1"
+	"github.com/Abhishekjha321/community_service/internal/events"
 	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
 	"github.com/Abhishekjha321/community_service/internal/logic/community/repo"
 	"github.com/Abhishekjha321/community_service/internal/logic/community/service"
+	"github.com/Abhishekjha321/community_service/internal/moderation"
+	"github.com/Abhishekjha321/community_service/internal/ratelimit"
+	"github.com/Abhishekjha321/community_service/internal/trending"
 	"github.com/Abhishekjha321/community_service/pkg/config"
 	"github.com/Abhishekjha321/community_service/pkg/store/db"
 	"github.com/Abhishekjha321/community_service/pkg/store/redis"
+	"github.com/Abhishekjha321/community_service/storage/objectstore"
 	"github.com/gin-gonic/gin"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
@@ -35,8 +42,15 @@ type Application struct {
 	cache      cache.CacheBase
 	services   services
 	controller controller
+	relay      *events.Relay
+	trending   *trending.Tracker
+	limiter    *ratelimit.Limiter
+	objects    objectstore.ObjectStore
 	router     *gin.Engine
 	http       *http.Server
+	// workers is cancelled by Stop to stop the background workers.
+	workers     context.Context
+	stopWorkers context.CancelFunc
 }
 
 func (a *Application) initStores() {
@@ -55,33 +69,116 @@ func (a *Application) initCache() {
 	}
 }
 
+func (a *Application) initOutboxRelay() {
+	outboxConfig := config.Config.Outbox
+	publisher, err := events.NewPublisher(outboxConfig.Publisher, a.cache, outboxConfig.StreamName, outboxConfig.StreamMaxLen)
+	if err != nil {
+		panic(fmt.Errorf("event publisher initialization failed: %w", err))
+	}
+	a.relay = events.NewRelay(a.db, publisher, outboxConfig.BatchSize, outboxConfig.RelayInterval, outboxConfig.MaxAttempts)
+}
+
+func (a *Application) initTrending() {
+	trendingConfig := config.Config.Trending
+	a.trending = trending.NewTracker(a.cache, trendingConfig.Window, trendingConfig.HalfLife, trendingConfig.RefreshInterval)
+}
+
+func (a *Application) initRateLimiter() {
+	a.limiter = ratelimit.NewLimiter(a.cache)
+	// the limiter loads its functions again on first use, so Redis being
+	// unavailable right now shouldn't keep the service from starting
+	if err := a.limiter.Load(context.Background()); err != nil {
+		logger.GetLogger().WithError(err).Error("failed to load rate limit functions")
+	}
+}
+
+func (a *Application) initObjectStore() {
+	storeConfig := config.Config.ObjectStore
+	// without explicit URLs the local driver's files are served by this service
+	if storeConfig.Driver == "" || storeConfig.Driver == objectstore.DriverLocal {
+		if storeConfig.PublicURL == "" {
+			storeConfig.PublicURL = api.PublicApiV1PathPrefix + api.AttachmentFilesPath
+		}
+		if storeConfig.UploadURL == "" {
+			storeConfig.UploadURL = api.PublicApiV1PathPrefix + api.AttachmentUploadPath
+		}
+	}
+	var err error
+	a.objects, err = objectstore.New(storeConfig.Driver, objectstore.Options{
+		LocalDir:        storeConfig.LocalDir,
+		PublicURL:       storeConfig.PublicURL,
+		UploadURL:       storeConfig.UploadURL,
+		SigningSecret:   storeConfig.SigningSecret,
+		UploadURLExpiry: storeConfig.UploadURLExpiry,
+		Endpoint:        storeConfig.Endpoint,
+		Region:          storeConfig.Region,
+		Bucket:          storeConfig.Bucket,
+		AccessKey:       storeConfig.AccessKey,
+		SecretKey:       storeConfig.SecretKey,
+	})
+	if err != nil {
+		panic(fmt.Errorf("object store initialization failed: %w", err))
+	}
+}
+
 func (a *Application) initServices() {
-	a.services.communityService = service.NewService(repo.NewRepo(a.db), a.cache)
+	communityRepo := repo.NewRepo(a.db)
+	postRate := ratelimit.Rate{Limit: config.Config.RateLimit.PostLimit, Window: config.Config.RateLimit.PostWindow}
+	moderationConfig := config.Config.Moderation
+	pipeline := moderation.NewPipeline(
+		moderation.NewLengthStage(moderationConfig.MaxLength),
+		moderation.NewBlocklistStage(communityRepo, moderationConfig.BlockedWords),
+		moderation.NewLinkSpamStage(moderationConfig.MaxLinks),
+		moderation.NewRepeatStage(communityRepo, moderationConfig.RepeatWindow),
+	)
+	a.services.communityService = service.NewService(communityRepo, a.cache, a.trending, a.objects, config.Config.ObjectStore.MaxUploadSize, config.Config.Threads.MaxDepth, a.limiter, postRate, pipeline, moderationConfig.RestoreWindow)
 }
 
 func (a *Application) initControllers() {
 	a.controller.communityController = api.NewCommunityController(a.services.communityService)
 }
 
+// routeRateLimit builds the rate limiting middleware of a route group.
+func (a *Application) routeRateLimit(group string) gin.HandlerFunc {
+	rateLimitConfig := config.Config.RateLimit
+	allowList, err := ratelimit.NewClientList(rateLimitConfig.AllowList)
+	if err != nil {
+		panic(fmt.Errorf("rate limit allow list is invalid: %w", err))
+	}
+	denyList, err := ratelimit.NewClientList(rateLimitConfig.DenyList)
+	if err != nil {
+		panic(fmt.Errorf("rate limit deny list is invalid: %w", err))
+	}
+	routeLimit := rateLimitConfig.Routes[group]
+	rate := ratelimit.Rate{Limit: routeLimit.Limit, Window: routeLimit.Window}
+	return api.RateLimitMiddleware(a.limiter, group, rate, ratelimit.NewKeySet(rateLimitConfig.APIKeys), allowList, denyList)
+}
+
 func (a *Application) setUpHandlers() *gin.Engine {
 	router := gin.Default()
+	// ClientIP only reads forwarding headers set by these proxies, so clients
+	// can't pick their own rate limit bucket
+	if err := router.SetTrustedProxies(config.Config.Server.TrustedProxies); err != nil {
+		panic(fmt.Errorf("trusted proxies are invalid: %w", err))
+	}
 
 	// Apply CORS middleware globally
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:5173"}, // Frontend URL
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Content-Type","x-user-id"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Content-Type","x-user-id", api.X_API_KEY, api.X_REQUEST_ID},
+		ExposeHeaders:    []string{"Content-Length", api.X_REQUEST_ID, api.RateLimitLimitHeader, api.RateLimitRemainingHeader, api.RateLimitResetHeader, api.RetryAfterHeader},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
 
 	// Set up OpenTelemetry middleware
 	router.Use(otelgin.Middleware(config.Config.Name))
+	router.Use(api.RequestIDMiddleware())
 
 	// Add public and private routes
-	api.AddPublicRoutes(router, a.controller.communityController)
-	api.AddPrivateRoutes(router, a.controller.communityController)
+	api.AddPublicRoutes(router, a.controller.communityController, a.routeRateLimit(api.PublicRouteGroup))
+	api.AddPrivateRoutes(router, a.controller.communityController, a.routeRateLimit(api.PrivateRouteGroup))
 
 	return router
 }
@@ -89,9 +186,14 @@ func (a *Application) setUpHandlers() *gin.Engine {
 func (a *Application) Init() {
 	a.initStores()
 	a.initCache()
+	a.initOutboxRelay()
+	a.initTrending()
+	a.initRateLimiter()
+	a.initObjectStore()
 	a.initServices()
 	a.initControllers()
 	a.router = a.setUpHandlers()
+	a.workers, a.stopWorkers = context.WithCancel(context.Background())
 	a.http = &http.Server{
 		Addr:         fmt.Sprintf(":%d", config.Config.Server.Port),
 		Handler:      a.router,
@@ -103,8 +205,17 @@ func (a *Application) Init() {
 
 func (a *Application) Start() {
 	defer logger.GetLogger().Errorf("stopped http server")
+	go a.relay.Start(a.workers)
+	go a.trending.Start(a.workers)
 	fmt.Printf("server is listening on port: %d \n", config.Config.Server.Port)
-	if err := a.http.ListenAndServe(); err != nil {
+	if err := a.http.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		logger.GetLogger().WithError(err).Fatal("failed to start http server")
 	}
 }
+
+// Stop stops the background workers and shuts the http server down, waiting
+// for in-flight requests until ctx is done.
+func (a *Application) Stop(ctx context.Context) error {
+	a.stopWorkers()
+	return a.http.Shutdown(ctx)
+}
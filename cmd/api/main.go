@@ -1,7 +1,12 @@
 	package main
 
 	import (
+		"context"
 		"log"
+		"os"
+		"os/signal"
+		"syscall"
+		"time"
 
 		"github.com/Abhishekjha321/community_service/cmd/api/app"
 		"github.com/Abhishekjha321/community_service/pkg/config"
@@ -15,5 +20,19 @@
 
 		app := &app.Application{}
 		app.Init()
+
+		stop, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+		stopped := make(chan struct{})
+		go func() {
+			defer close(stopped)
+			<-stop.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			if err := app.Stop(ctx); err != nil {
+				log.Printf("failed to shut down cleanly: %v", err)
+			}
+		}()
 		app.Start()
+		<-stopped
 	}
@@ -1,10 +1,24 @@
 package dto
 
+import "encoding/json"
+
 type RequestCreatePost struct {
-	ChannelID   string `json:"channel_id"`
-	Content     string `json:"content"`
-	CommentType string `json:"comment_type"`
-	ParentID    int64  `json:"parent_id"`
+	ChannelID     string             `json:"channel_id"`
+	Content       string             `json:"content"`
+	CommentType   string             `json:"comment_type"`
+	ParentID      int64              `json:"parent_id"`
+	Poll          *RequestCreatePoll `json:"poll,omitempty"`
+	AttachmentIDs []int64            `json:"attachment_ids,omitempty"`
+	QuotedPostID  int64              `json:"quoted_post_id,omitempty"`
+}
+
+// RequestCreatePoll carries the poll of a POLL post. ClosesAt is a unix
+// timestamp; zero leaves the poll open indefinitely.
+type RequestCreatePoll struct {
+	Options               []string `json:"options"`
+	MultiChoice           bool     `json:"multi_choice"`
+	ClosesAt              int64    `json:"closes_at"`
+	HideResultsUntilVoted bool     `json:"hide_results_until_voted"`
 }
 
 type ResponseCreatePost struct {
@@ -14,17 +28,21 @@ type ResponseCreatePost struct {
 }
 
 type ResponseCreatePostData struct {
-	UserName        string `json:"user_name"`
-	ProfileImageURL string `json:"profile_image_url"`
-	UserID          string `json:"user_id"`
-	UserPhone       string `json:"user_phone"`
-	PostID          int64  `json:"post_id"`
-	ChannelID       string `json:"channel_id"`
-	Content         string `json:"content"`
-	CommentType     string `json:"comment_type"`
-	ParentID        int64  `json:"parent_id"`
-	CreatedAt       string `json:"created_at"`
-	UpdatedAt       string `json:"updated_at"`
+	UserName        string                   `json:"user_name"`
+	ProfileImageURL string                   `json:"profile_image_url"`
+	UserID          string                   `json:"user_id"`
+	UserPhone       string                   `json:"user_phone"`
+	PostID          int64                    `json:"post_id"`
+	ChannelID       string                   `json:"channel_id"`
+	Content         string                   `json:"content"`
+	CommentType     string                   `json:"comment_type"`
+	ParentID        int64                    `json:"parent_id"`
+	CreatedAt       string                   `json:"created_at"`
+	UpdatedAt       string                   `json:"updated_at"`
+	Poll            *ResponsePollData        `json:"poll,omitempty"`
+	Attachments     []ResponseAttachmentData `json:"attachments,omitempty"`
+	Mentions        []ResponseMention        `json:"mentions,omitempty"`
+	QuotedPost      *ResponseQuotedPost      `json:"quoted_post,omitempty"`
 }
 
 type ResponseAllRepliesOnPost struct {
@@ -32,39 +50,51 @@ type ResponseAllRepliesOnPost struct {
 	Message    string                           `json:"message"`
 	Data       ResponseAllRepliesOnPostData    `json:"data"`
 	Pagination ResponseAllRepliesOnPostPagination `json:"pagination"`
+	NextCursor string                           `json:"next_cursor"`
 }
 
 type ResponseAllRepliesOnPostData struct {
-	PostID           string                              `json:"post_id"`
-	UserName         string                              `json:"user_name"`
-	ProfileImageURL  string                              `json:"profile_image_url"`
-	Content          string                              `json:"content"`
-	Type             string                              `json:"type"`
-	LikeCount        int64                               `json:"like_count"`
-	Status           string                              `json:"status"`
-	CreatedAt        string                              `json:"created_at"`
-	UpdatedAt        string                              `json:"updated_at"`
-	IsLiked          bool                                `json:"is_liked"`
-	UserID           string                              `json:"user_id"`
-	UserPhone        string                              `json:"user_phone"`
-	BookmarkCount    int64                               `json:"bookmark_count"`
-	IsBookmarked     bool                                `json:"is_bookmarked"`
-	Replies          []ResponseAllRepliesOnPostReplies   `json:"replies"`
+	PostID           string                            `json:"post_id"`
+	UserName         string                            `json:"user_name"`
+	ProfileImageURL  string                            `json:"profile_image_url"`
+	Content          string                            `json:"content"`
+	Type             string                            `json:"type"`
+	LikeCount        int64                             `json:"like_count"`
+	Status           string                            `json:"status"`
+	CreatedAt        string                            `json:"created_at"`
+	UpdatedAt        string                            `json:"updated_at"`
+	IsLiked          bool                              `json:"is_liked"`
+	UserID           string                            `json:"user_id"`
+	UserPhone        string                            `json:"user_phone"`
+	BookmarkCount    int64                             `json:"bookmark_count"`
+	IsBookmarked     bool                              `json:"is_bookmarked"`
+	AcceptedAnswerID int64                             `json:"accepted_answer_id,omitempty"`
+	Attachments      []ResponseAttachmentData          `json:"attachments,omitempty"`
+	Mentions         []ResponseMention                 `json:"mentions,omitempty"`
+	QuoteCount       int64                             `json:"quote_count"`
+	QuotedPost       *ResponseQuotedPost               `json:"quoted_post,omitempty"`
+	Replies          []ResponseAllRepliesOnPostReplies `json:"replies"`
 }
 
 type ResponseAllRepliesOnPostReplies struct {
-	PostID          string `json:"post_id"`
-	UserName        string `json:"user_name"`
-	ProfileImageURL string `json:"profile_image_url"`
-	Content         string `json:"content"`
-	Type            string `json:"type"`
-	LikeCount       int64  `json:"like_count"`
-	Status          string `json:"status"`
-	IsLiked         bool   `json:"is_liked"`
-	UserID          string `json:"user_id"`
-	UserPhone       string `json:"user_phone"`
-	CreatedAt       string `json:"created_at"`
-	UpdatedAt       string `json:"updated_at"`
+	PostID           string                   `json:"post_id"`
+	UserName         string                   `json:"user_name"`
+	ProfileImageURL  string                   `json:"profile_image_url"`
+	Content          string                   `json:"content"`
+	Type             string                   `json:"type"`
+	LikeCount        int64                    `json:"like_count"`
+	Status           string                   `json:"status"`
+	IsLiked          bool                     `json:"is_liked"`
+	UserID           string                   `json:"user_id"`
+	UserPhone        string                   `json:"user_phone"`
+	CreatedAt        string                   `json:"created_at"`
+	UpdatedAt        string                   `json:"updated_at"`
+	IsAcceptedAnswer bool                     `json:"is_accepted_answer,omitempty"`
+	RepliesCount     int64                    `json:"replies_count"`
+	Attachments      []ResponseAttachmentData `json:"attachments,omitempty"`
+	Mentions         []ResponseMention        `json:"mentions,omitempty"`
+	QuoteCount       int64                    `json:"quote_count"`
+	QuotedPost       *ResponseQuotedPost      `json:"quoted_post,omitempty"`
 }
 
 type ResponseAllRepliesOnPostPagination struct {
@@ -79,6 +109,7 @@ type ResponseGetPosts struct {
 	Message    string                     `json:"message"`
 	Data       []ResponseGetPostsPostData `json:"data"`
 	Pagination ResponseGetPostsPagination `json:"pagination"`
+	NextCursor string                     `json:"next_cursor"`
 }
 
 type ResponseGetPostsPagination struct {
@@ -89,38 +120,57 @@ type ResponseGetPostsPagination struct {
 }
 
 type ResponseGetPostsReply struct {
-	ID         int64  `json:"id"`
-	Content    string `json:"content"`
-	Type       string `json:"type"`
-	UserID     string `json:"user_id"`
-	LikeCount  int64  `json:"like_count"`
-	Status     string `json:"status"`
-	Avatar     string `json:"avatar"`
-	UserName   string `json:"user_name"`
-	UserPhone  string `json:"user_phone"`
-	CreatedAt  string `json:"created_at"`
-	UpdatedAt  string `json:"updated_at"`
-	IsLiked    bool   `json:"is_liked"`
+	ID               int64                    `json:"id"`
+	Content          string                   `json:"content"`
+	Type             string                   `json:"type"`
+	UserID           string                   `json:"user_id"`
+	LikeCount        int64                    `json:"like_count"`
+	Status           string                   `json:"status"`
+	Avatar           string                   `json:"avatar"`
+	UserName         string                   `json:"user_name"`
+	UserPhone        string                   `json:"user_phone"`
+	CreatedAt        string                   `json:"created_at"`
+	UpdatedAt        string                   `json:"updated_at"`
+	IsLiked          bool                     `json:"is_liked"`
+	IsAcceptedAnswer bool                     `json:"is_accepted_answer,omitempty"`
+	RepliesCount     int64                    `json:"replies_count"`
+	Attachments      []ResponseAttachmentData `json:"attachments,omitempty"`
+	Mentions         []ResponseMention        `json:"mentions,omitempty"`
+	QuoteCount       int64                    `json:"quote_count"`
+	QuotedPost       *ResponseQuotedPost      `json:"quoted_post,omitempty"`
 }
 
 type ResponseGetPostsPostData struct {
-	ID            int64                   `json:"id"`
-	Avatar        string                  `json:"avatar"`
-	UserName      string                  `json:"user_name"`
-	UserPhone     string                  `json:"user_phone"`
-	Content       string                  `json:"content"`
-	Type          string                  `json:"type"`
-	LikeCount     int64                   `json:"like_count"`
-	Status        string                  `json:"status"`
-	UserID        string                  `json:"user_id"`
-	CreatedAt     string                  `json:"created_at"`
-	UpdatedAt     string                  `json:"updated_at"`
-	IsLiked       bool                    `json:"is_liked"`
-	RepliesCount  int64                   `json:"replies_count"`
-	BookmarkCount int64                   `json:"bookmark_count"`
-	IsBookmarked  bool                    `json:"is_bookmarked"`
-	IsPinned      bool                    `json:"is_pinned"`
-	Replies       []ResponseGetPostsReply `json:"replies"`
+	ID               int64                    `json:"id"`
+	Avatar           string                   `json:"avatar"`
+	UserName         string                   `json:"user_name"`
+	UserPhone        string                   `json:"user_phone"`
+	Content          string                   `json:"content"`
+	Type             string                   `json:"type"`
+	LikeCount        int64                    `json:"like_count"`
+	Status           string                   `json:"status"`
+	UserID           string                   `json:"user_id"`
+	CreatedAt        string                   `json:"created_at"`
+	UpdatedAt        string                   `json:"updated_at"`
+	IsLiked          bool                     `json:"is_liked"`
+	RepliesCount     int64                    `json:"replies_count"`
+	BookmarkCount    int64                    `json:"bookmark_count"`
+	IsBookmarked     bool                     `json:"is_bookmarked"`
+	IsPinned         bool                     `json:"is_pinned"`
+	IdeaStatus       string                   `json:"idea_status,omitempty"`
+	OfficialResponse string                   `json:"official_response,omitempty"`
+	MergedIntoID     int64                    `json:"merged_into_id,omitempty"`
+	VoteScore        int64                    `json:"vote_score"`
+	UpvoteCount      int64                    `json:"upvote_count"`
+	DownvoteCount    int64                    `json:"downvote_count"`
+	MyVote           int                      `json:"my_vote"`
+	AcceptedAnswerID int64                    `json:"accepted_answer_id,omitempty"`
+	Poll             *ResponsePollData        `json:"poll,omitempty"`
+	Attachments      []ResponseAttachmentData `json:"attachments,omitempty"`
+	Mentions         []ResponseMention        `json:"mentions,omitempty"`
+	QuoteCount       int64                    `json:"quote_count"`
+	QuotedPost       *ResponseQuotedPost      `json:"quoted_post,omitempty"`
+	Replies          []ResponseGetPostsReply  `json:"replies"`
 }
 
 type ResponseMarkNotificationsAsRead struct {
@@ -132,3 +182,654 @@ type ResponseMarkNotificationsAsRead struct {
 type ResponseMarkNotificationsAsReadData struct {
 	IsUnread bool `json:"is_unread"`
 }
+
+type ResponseSearchPosts struct {
+	Code       string                    `json:"code"`
+	Message    string                    `json:"message"`
+	Data       []ResponseSearchPostsData `json:"data"`
+	NextCursor string                    `json:"next_cursor"`
+}
+
+type ResponseSearchPostsData struct {
+	ID        int64   `json:"id"`
+	ChannelID string  `json:"channel_id"`
+	ParentID  int64   `json:"parent_id"`
+	UserID    string  `json:"user_id"`
+	UserName  string  `json:"user_name"`
+	Avatar    string  `json:"avatar"`
+	Type      string  `json:"type"`
+	Status    string  `json:"status"`
+	LikeCount int64   `json:"like_count"`
+	Snippet   string  `json:"snippet"`
+	Rank      float64 `json:"rank"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+type ResponseTrendingPosts struct {
+	Code    string                     `json:"code"`
+	Message string                     `json:"message"`
+	Data    []ResponseTrendingPostData `json:"data"`
+}
+
+type ResponseTrendingPostData struct {
+	ID            int64   `json:"id"`
+	ChannelID     string  `json:"channel_id"`
+	UserID        string  `json:"user_id"`
+	UserName      string  `json:"user_name"`
+	Avatar        string  `json:"avatar"`
+	Content       string  `json:"content"`
+	LikeCount     int64   `json:"like_count"`
+	BookmarkCount int64   `json:"bookmark_count"`
+	ReplyCount    int64   `json:"reply_count"`
+	Score         float64 `json:"score"`
+	CreatedAt     string  `json:"created_at"`
+}
+
+type ResponseTrendingChannels struct {
+	Code    string                        `json:"code"`
+	Message string                        `json:"message"`
+	Data    []ResponseTrendingChannelData `json:"data"`
+}
+
+type ResponseTrendingChannelData struct {
+	ChannelID string  `json:"channel_id"`
+	Score     float64 `json:"score"`
+}
+
+type RequestUpdateIdeaStatus struct {
+	PostID           int64  `json:"post_id"`
+	Status           string `json:"status"`
+	OfficialResponse string `json:"official_response"`
+	Reason           string `json:"reason"`
+}
+
+type ResponseUpdateIdeaStatus struct {
+	Code    string                       `json:"code"`
+	Message string                       `json:"message"`
+	Data    ResponseUpdateIdeaStatusData `json:"data"`
+}
+
+type ResponseUpdateIdeaStatusData struct {
+	PostID             int64  `json:"post_id"`
+	Status             string `json:"status"`
+	OfficialResponse   string `json:"official_response"`
+	OfficialResponseBy string `json:"official_response_by"`
+	UpdatedAt          string `json:"updated_at"`
+}
+
+type ResponseGetNotifications struct {
+	Code        string                     `json:"code"`
+	Message     string                     `json:"message"`
+	Data        []ResponseNotificationData `json:"data"`
+	UnreadCount int64                      `json:"unread_count"`
+	NextCursor  string                     `json:"next_cursor"`
+}
+
+type ResponseNotificationData struct {
+	ID        int64  `json:"id"`
+	Type      string `json:"type"`
+	ChannelID string `json:"channel_id"`
+	PostID    int64  `json:"post_id"`
+	ActorID   string `json:"actor_id"`
+	Message   string `json:"message"`
+	IsRead    bool   `json:"is_read"`
+	CreatedAt string `json:"created_at"`
+}
+
+type RequestReadNotifications struct {
+	IDs []int64 `json:"ids"`
+}
+
+type ResponseReadNotifications struct {
+	Code    string                        `json:"code"`
+	Message string                        `json:"message"`
+	Data    ResponseReadNotificationsData `json:"data"`
+}
+
+type ResponseReadNotificationsData struct {
+	UpdatedCount int64 `json:"updated_count"`
+}
+
+type RequestChannelModerator struct {
+	ChannelID string `json:"channel_id"`
+	UserID    string `json:"user_id"`
+	Reason    string `json:"reason"`
+}
+
+type RequestMergePosts struct {
+	SourcePostID int64  `json:"source_post_id"`
+	TargetPostID int64  `json:"target_post_id"`
+	Reason       string `json:"reason"`
+}
+
+type RequestRevertPostMerge struct {
+	MergeID int64  `json:"merge_id"`
+	Reason  string `json:"reason"`
+}
+
+type ResponsePostMerge struct {
+	Code    string                `json:"code"`
+	Message string                `json:"message"`
+	Data    ResponsePostMergeData `json:"data"`
+}
+
+type ResponsePostMergeData struct {
+	MergeID         int64  `json:"merge_id"`
+	SourcePostID    int64  `json:"source_post_id"`
+	TargetPostID    int64  `json:"target_post_id"`
+	MergedBy        string `json:"merged_by"`
+	MovedLikesCount int    `json:"moved_likes_count"`
+	MovedReplyCount int    `json:"moved_reply_count"`
+	RevertedBy      string `json:"reverted_by,omitempty"`
+	CreatedAt       string `json:"created_at"`
+	RevertedAt      string `json:"reverted_at,omitempty"`
+}
+
+type RequestVotePost struct {
+	PostID    int64  `json:"post_id"`
+	ChannelID string `json:"channel_id"`
+	Vote      string `json:"vote"`
+}
+
+type ResponseVotePost struct {
+	Code    string               `json:"code"`
+	Message string               `json:"message"`
+	Data    ResponseVotePostData `json:"data"`
+}
+
+type ResponseVotePostData struct {
+	PostID        int64 `json:"post_id"`
+	VoteScore     int64 `json:"vote_score"`
+	UpvoteCount   int64 `json:"upvote_count"`
+	DownvoteCount int64 `json:"downvote_count"`
+	MyVote        int   `json:"my_vote"`
+}
+
+// RequestChannelSettings changes the settings that are sent and keeps the
+// others. A zero max post length, slow mode or report threshold turns the
+// limit off.
+type RequestChannelSettings struct {
+	ChannelID        string `json:"channel_id"`
+	ScoringMode      string `json:"scoring_mode"`
+	ChannelMode      string `json:"channel_mode"`
+	DefaultFlow      string `json:"default_flow"`
+	RepliesEnabled   *bool  `json:"replies_enabled"`
+	ReactionsEnabled *bool  `json:"reactions_enabled"`
+	MaxPostLength    *int   `json:"max_post_length"`
+	SlowModeSeconds  *int   `json:"slow_mode_seconds"`
+	ReportThreshold  *int   `json:"report_threshold"`
+}
+
+type ResponseChannelSettings struct {
+	Code    string                      `json:"code"`
+	Message string                      `json:"message"`
+	Data    ResponseChannelSettingsData `json:"data"`
+}
+
+type ResponseChannelSettingsData struct {
+	ChannelID        string `json:"channel_id"`
+	ScoringMode      string `json:"scoring_mode"`
+	ChannelMode      string `json:"channel_mode"`
+	DefaultFlow      string `json:"default_flow"`
+	RepliesEnabled   bool   `json:"replies_enabled"`
+	ReactionsEnabled bool   `json:"reactions_enabled"`
+	MaxPostLength    int    `json:"max_post_length"`
+	SlowModeSeconds  int    `json:"slow_mode_seconds"`
+	ReportThreshold  int    `json:"report_threshold"`
+}
+
+type RequestAcceptAnswer struct {
+	QuestionID int64 `json:"question_id"`
+	AnswerID   int64 `json:"answer_id"`
+}
+
+type ResponseAcceptAnswer struct {
+	Code    string                   `json:"code"`
+	Message string                   `json:"message"`
+	Data    ResponseAcceptAnswerData `json:"data"`
+}
+
+type ResponseAcceptAnswerData struct {
+	QuestionID       int64 `json:"question_id"`
+	AcceptedAnswerID int64 `json:"accepted_answer_id"`
+}
+
+// ResponsePollData is a poll with its tallies as seen by the caller. When
+// ResultsHidden is set the vote counts are left out until the caller votes.
+type ResponsePollData struct {
+	MultiChoice           bool                 `json:"multi_choice"`
+	HideResultsUntilVoted bool                 `json:"hide_results_until_voted"`
+	ClosesAt              string               `json:"closes_at,omitempty"`
+	IsClosed              bool                 `json:"is_closed"`
+	HasVoted              bool                 `json:"has_voted"`
+	ResultsHidden         bool                 `json:"results_hidden"`
+	VoterCount            int64                `json:"voter_count"`
+	Options               []ResponsePollOption `json:"options"`
+}
+
+type ResponsePollOption struct {
+	ID         int64  `json:"id"`
+	Text       string `json:"text"`
+	VoteCount  int64  `json:"vote_count"`
+	IsSelected bool   `json:"is_selected"`
+}
+
+type RequestVotePoll struct {
+	PostID    int64   `json:"post_id"`
+	OptionIDs []int64 `json:"option_ids"`
+}
+
+type ResponseVotePoll struct {
+	Code    string           `json:"code"`
+	Message string           `json:"message"`
+	Data    ResponsePollData `json:"data"`
+}
+
+// ResponseAttachmentData describes an uploaded file. Width, height and the
+// thumbnail are only set for images.
+type ResponseAttachmentData struct {
+	ID           int64  `json:"id"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	FileName     string `json:"file_name"`
+	ContentType  string `json:"content_type"`
+	Size         int64  `json:"size"`
+	Width        int    `json:"width,omitempty"`
+	Height       int    `json:"height,omitempty"`
+}
+
+type ResponseAttachment struct {
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Data    ResponseAttachmentData `json:"data"`
+}
+
+type RequestAttachmentUploadURL struct {
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+// ResponseAttachmentUploadURLData tells the client where to PUT the file.
+// Once uploaded, the attachment is completed with its AttachmentID.
+type ResponseAttachmentUploadURLData struct {
+	AttachmentID int64  `json:"attachment_id"`
+	UploadURL    string `json:"upload_url"`
+	Method       string `json:"method"`
+	ContentType  string `json:"content_type"`
+	ExpiresAt    string `json:"expires_at"`
+}
+
+type ResponseAttachmentUploadURL struct {
+	Code    string                          `json:"code"`
+	Message string                          `json:"message"`
+	Data    ResponseAttachmentUploadURLData `json:"data"`
+}
+
+type RequestCompleteAttachmentUpload struct {
+	AttachmentID int64 `json:"attachment_id"`
+}
+
+// ResponseMention locates a resolved @username in the post content. Start and
+// End are character offsets, End being exclusive.
+type ResponseMention struct {
+	UserID   string `json:"user_id"`
+	UserName string `json:"user_name"`
+	Start    int    `json:"start"`
+	End      int    `json:"end"`
+}
+
+// ResponseQuotedPost is the snapshot of a quoted post shown inside the quote.
+// When the quoted post was deleted or hidden, or is gone, Available is false
+// and only the id, the status and a placeholder excerpt are sent.
+type ResponseQuotedPost struct {
+	PostID    int64  `json:"post_id"`
+	ChannelID string `json:"channel_id,omitempty"`
+	UserID    string `json:"user_id,omitempty"`
+	UserName  string `json:"user_name,omitempty"`
+	Avatar    string `json:"avatar,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Excerpt   string `json:"excerpt"`
+	Status    string `json:"status"`
+	Available bool   `json:"available"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+type ResponseThread struct {
+	Code    string             `json:"code"`
+	Message string             `json:"message"`
+	Data    ResponseThreadNode `json:"data"`
+}
+
+// ResponseThreadNode is a post of a reply thread with a page of its direct
+// replies. NextCursor loads the following replies of this branch only; a
+// node whose replies weren't loaded because of the depth limit has
+// RepliesCount set and no Replies, and can be opened as a thread of its own.
+type ResponseThreadNode struct {
+	PostID           int64                    `json:"post_id"`
+	ParentID         int64                    `json:"parent_id"`
+	Depth            int                      `json:"depth"`
+	UserID           string                   `json:"user_id"`
+	UserName         string                   `json:"user_name"`
+	UserPhone        string                   `json:"user_phone"`
+	ProfileImageURL  string                   `json:"profile_image_url"`
+	Content          string                   `json:"content"`
+	Type             string                   `json:"type"`
+	Status           string                   `json:"status"`
+	LikeCount        int64                    `json:"like_count"`
+	IsLiked          bool                     `json:"is_liked"`
+	IsAcceptedAnswer bool                     `json:"is_accepted_answer,omitempty"`
+	CreatedAt        string                   `json:"created_at"`
+	UpdatedAt        string                   `json:"updated_at"`
+	Attachments      []ResponseAttachmentData `json:"attachments,omitempty"`
+	Mentions         []ResponseMention        `json:"mentions,omitempty"`
+	QuoteCount       int64                    `json:"quote_count"`
+	QuotedPost       *ResponseQuotedPost      `json:"quoted_post,omitempty"`
+	RepliesCount     int64                    `json:"replies_count"`
+	Replies          []ResponseThreadNode     `json:"replies"`
+	NextCursor       string                   `json:"next_cursor,omitempty"`
+}
+
+type RequestEditPost struct {
+	PostID  int64  `json:"post_id"`
+	Content string `json:"content"`
+}
+
+type ResponseEditPostData struct {
+	PostID    int64             `json:"post_id"`
+	Content   string            `json:"content"`
+	UpdatedAt string            `json:"updated_at"`
+	Mentions  []ResponseMention `json:"mentions,omitempty"`
+}
+
+type ResponseEditPost struct {
+	Code    string               `json:"code"`
+	Message string               `json:"message"`
+	Data    ResponseEditPostData `json:"data"`
+}
+
+type ResponseMentionUser struct {
+	UserID          string `json:"user_id"`
+	UserName        string `json:"user_name"`
+	FullName        string `json:"full_name"`
+	ProfileImageURL string `json:"profile_image_url"`
+}
+
+type ResponseMentionUsers struct {
+	Code    string                `json:"code"`
+	Message string                `json:"message"`
+	Data    []ResponseMentionUser `json:"data"`
+}
+
+type ResponseTagCount struct {
+	Tag       string `json:"tag"`
+	PostCount int64  `json:"post_count"`
+}
+
+type ResponsePopularTags struct {
+	Code    string             `json:"code"`
+	Message string             `json:"message"`
+	Data    []ResponseTagCount `json:"data"`
+}
+
+// RequestBookmarkCollection creates a collection, or renames the collection
+// with CollectionID.
+type RequestBookmarkCollection struct {
+	CollectionID int64  `json:"collection_id"`
+	Name         string `json:"name"`
+}
+
+type ResponseBookmarkCollectionData struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	ItemCount int64  `json:"item_count"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type ResponseBookmarkCollection struct {
+	Code    string                         `json:"code"`
+	Message string                         `json:"message"`
+	Data    ResponseBookmarkCollectionData `json:"data"`
+}
+
+type ResponseBookmarkCollections struct {
+	Code    string                           `json:"code"`
+	Message string                           `json:"message"`
+	Data    []ResponseBookmarkCollectionData `json:"data"`
+}
+
+// RequestSaveBookmark bookmarks a post if needed and files it in a
+// collection, 0 meaning none, with a private note.
+type RequestSaveBookmark struct {
+	PostID       int64  `json:"post_id"`
+	CollectionID int64  `json:"collection_id"`
+	Note         string `json:"note"`
+}
+
+type ResponseSavedBookmarkData struct {
+	PostID       int64  `json:"post_id"`
+	CollectionID int64  `json:"collection_id"`
+	Note         string `json:"note"`
+	IsBookmarked bool   `json:"is_bookmarked"`
+}
+
+type ResponseSaveBookmark struct {
+	Code    string                    `json:"code"`
+	Message string                    `json:"message"`
+	Data    ResponseSavedBookmarkData `json:"data"`
+}
+
+// ResponseSavedItem is a bookmarked post with the user's collection and note.
+// Notes are private and only ever returned to their author.
+type ResponseSavedItem struct {
+	ChannelID    string                   `json:"channel_id"`
+	CollectionID int64                    `json:"collection_id"`
+	Note         string                   `json:"note"`
+	SavedAt      string                   `json:"saved_at"`
+	Post         ResponseGetPostsPostData `json:"post"`
+}
+
+type ResponseSavedItems struct {
+	Code       string              `json:"code"`
+	Message    string              `json:"message"`
+	Data       []ResponseSavedItem `json:"data"`
+	NextCursor string              `json:"next_cursor"`
+}
+
+// RequestUserRelation blocks or mutes a user, or takes the block or the mute
+// back. Type is either BLOCK or MUTE.
+type RequestUserRelation struct {
+	TargetUserID string `json:"target_user_id"`
+	Type         string `json:"type"`
+}
+
+type ResponseUserRelation struct {
+	UserID          string `json:"user_id"`
+	UserName        string `json:"user_name"`
+	FullName        string `json:"full_name"`
+	ProfileImageURL string `json:"profile_image_url"`
+	Type            string `json:"type"`
+	CreatedAt       string `json:"created_at"`
+}
+
+type ResponseUserRelations struct {
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Data    []ResponseUserRelation `json:"data"`
+}
+
+// RequestChannelMembership joins or leaves a channel.
+type RequestChannelMembership struct {
+	ChannelID string `json:"channel_id"`
+}
+
+// RequestChannelMembers invites users to a channel, or adds them as members
+// through the private API.
+type RequestChannelMembers struct {
+	ChannelID string   `json:"channel_id"`
+	UserIDs   []string `json:"user_ids"`
+}
+
+// RequestChannelMember removes a member from a channel.
+type RequestChannelMember struct {
+	ChannelID string `json:"channel_id"`
+	UserID    string `json:"user_id"`
+	Reason    string `json:"reason"`
+}
+
+// RequestUpdateChannel registers a channel or changes its details. Only the
+// fields that are sent are changed. Visibility is one of public, members or
+// announcement.
+type RequestUpdateChannel struct {
+	ChannelID   string  `json:"channel_id"`
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	OwnerID     *string `json:"owner_id"`
+	Visibility  string  `json:"visibility"`
+	Archived    *bool   `json:"archived"`
+}
+
+// ResponseChannelData is a channel as seen by the requesting user.
+// MembershipStatus is empty when the user is neither a member nor invited.
+type ResponseChannelData struct {
+	ChannelID        string `json:"channel_id"`
+	Title            string `json:"title"`
+	Description      string `json:"description"`
+	OwnerID          string `json:"owner_id"`
+	Visibility       string `json:"visibility"`
+	Archived         bool   `json:"archived"`
+	CreatedAt        string `json:"created_at"`
+	MembershipStatus string `json:"membership_status"`
+	IsModerator      bool   `json:"is_moderator"`
+	CanRead          bool   `json:"can_read"`
+	CanPost          bool   `json:"can_post"`
+}
+
+type ResponseChannel struct {
+	Code    string              `json:"code"`
+	Message string              `json:"message"`
+	Data    ResponseChannelData `json:"data"`
+}
+
+type RequestChannelBlocklist struct {
+	ChannelID string   `json:"channel_id"`
+	Words     []string `json:"words"`
+}
+
+type ResponseChannelBlocklistData struct {
+	ChannelID string   `json:"channel_id"`
+	Words     []string `json:"words"`
+}
+
+type ResponseChannelBlocklist struct {
+	Code    string                       `json:"code"`
+	Message string                       `json:"message"`
+	Data    ResponseChannelBlocklistData `json:"data"`
+}
+
+type ResponsePostFlagData struct {
+	ID         int64  `json:"id"`
+	PostID     int64  `json:"post_id"`
+	ChannelID  string `json:"channel_id"`
+	UserID     string `json:"user_id"`
+	Stage      string `json:"stage"`
+	Reason     string `json:"reason"`
+	Status     string `json:"status"`
+	ReviewedBy string `json:"reviewed_by"`
+	ReviewedAt string `json:"reviewed_at"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type ResponsePostFlags struct {
+	Code       string                 `json:"code"`
+	Message    string                 `json:"message"`
+	Data       []ResponsePostFlagData `json:"data"`
+	NextCursor string                 `json:"next_cursor"`
+}
+
+// RequestReviewPostFlags approves a flagged post or removes it. Action is
+// approve or remove.
+type RequestReviewPostFlags struct {
+	PostID int64  `json:"post_id"`
+	Action string `json:"action"`
+	Reason string `json:"reason"`
+}
+
+type RequestUserStatus struct {
+	UserID string `json:"user_id"`
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+type ResponseUserStatusData struct {
+	UserID string `json:"user_id"`
+	Status string `json:"status"`
+}
+
+type ResponseUserStatus struct {
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Data    ResponseUserStatusData `json:"data"`
+}
+
+type ResponseAuditLogData struct {
+	ID         int64           `json:"id"`
+	ActorID    string          `json:"actor_id"`
+	ActorRole  string          `json:"actor_role"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetID   string          `json:"target_id"`
+	ChannelID  string          `json:"channel_id"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	Reason     string          `json:"reason"`
+	RequestID  string          `json:"request_id"`
+	CreatedAt  string          `json:"created_at"`
+}
+
+type ResponseAuditLogs struct {
+	Code       string                 `json:"code"`
+	Message    string                 `json:"message"`
+	Data       []ResponseAuditLogData `json:"data"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+}
+
+type RequestPinPost struct {
+	PostID int64  `json:"post_id"`
+	Pinned bool   `json:"pinned"`
+	Reason string `json:"reason"`
+}
+
+type ResponsePinPostData struct {
+	PostID   int64 `json:"post_id"`
+	IsPinned bool  `json:"is_pinned"`
+}
+
+type ResponsePinPost struct {
+	Code    string              `json:"code"`
+	Message string              `json:"message"`
+	Data    ResponsePinPostData `json:"data"`
+}
+
+type RequestRestorePost struct {
+	PostID int64  `json:"post_id"`
+	Reason string `json:"reason"`
+}
+
+type ResponseRestorePostData struct {
+	PostID        int64  `json:"post_id"`
+	Content       string `json:"content"`
+	Status        string `json:"status"`
+	DeletedBy     string `json:"deleted_by"`
+	DeletedByRole string `json:"deleted_by_role"`
+	DeletedAt     string `json:"deleted_at"`
+	RestoredBy    string `json:"restored_by"`
+}
+
+type ResponseRestorePost struct {
+	Code    string                  `json:"code"`
+	Message string                  `json:"message"`
+	Data    ResponseRestorePostData `json:"data"`
+}
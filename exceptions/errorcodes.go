@@ -20,6 +20,22 @@ const (
 	QueryParamsIncorrectErrorCode ErrorCode = "MPQPI"
 	AccessDeniedErrorCode         ErrorCode = "MPADE"
 	WrongChannelIdErrorCode       ErrorCode = "MPWCI"
+	InvalidCursorErrorCode        ErrorCode = "MPICE"
+	MergedPostErrorCode           ErrorCode = "MPMPE"
+	VotingDisabledErrorCode       ErrorCode = "MPVDE"
+	NotQAChannelErrorCode         ErrorCode = "MPNQE"
+	PollClosedErrorCode           ErrorCode = "MPPCE"
+	PollAlreadyVotedErrorCode     ErrorCode = "MPPAV"
+	AttachmentTooLargeErrorCode   ErrorCode = "MPATL"
+	InvalidAttachmentErrorCode    ErrorCode = "MPIAE"
+	AttachmentNotFoundErrorCode   ErrorCode = "MPANF"
+	QuotedPostErrorCode           ErrorCode = "MPQPU"
+	ReplyDepthErrorCode           ErrorCode = "MPRDE"
+	BlockedUserErrorCode          ErrorCode = "MPBUE"
+	ChannelAccessErrorCode        ErrorCode = "MPCAE"
+	RateLimitedErrorCode          ErrorCode = "MPRLE"
+	RequestDeniedErrorCode        ErrorCode = "MPDCE"
+	ModerationErrorCode           ErrorCode = "MPMDE"
 )
 
 const (
@@ -37,6 +53,22 @@ const (
 	accessDeniedErrorMessage      ErrorMessage = "User not authorized to perform action"
 	deletedPostErrorMessage       ErrorMessage = "Cannot perform action on a deleted post"
 	wrongChannelIdErrorMessage    ErrorMessage = "Given channel-id is wrong"
+	invalidCursorErrorMessage     ErrorMessage = "Cursor is invalid"
+	mergedPostErrorMessage        ErrorMessage = "Cannot perform action on a merged post"
+	votingDisabledErrorMessage    ErrorMessage = "Voting is not enabled for this channel"
+	notQAChannelErrorMessage      ErrorMessage = "Answers can only be accepted in Q&A channels"
+	pollClosedErrorMessage        ErrorMessage = "This poll is closed"
+	pollAlreadyVotedErrorMessage  ErrorMessage = "You have already voted on this poll"
+	attachmentTooLargeMessage     ErrorMessage = "Attachment is larger than the allowed size"
+	invalidAttachmentMessage      ErrorMessage = "Attachment doesn't exist or can't be used"
+	attachmentNotFoundMessage     ErrorMessage = "Attachment not found"
+	quotedPostErrorMessage        ErrorMessage = "Quoted post doesn't exist or can't be quoted"
+	replyDepthErrorMessage        ErrorMessage = "Replies can't be nested any deeper"
+	blockedUserErrorMessage       ErrorMessage = "This user has blocked you"
+	channelAccessErrorMessage     ErrorMessage = "User can't access this channel"
+	rateLimitedErrorMessage       ErrorMessage = "Too many requests, please try again later"
+	requestDeniedErrorMessage     ErrorMessage = "Requests from this client aren't allowed"
+	moderationErrorMessage        ErrorMessage = "Post was rejected by moderation"
 )
 
 var (
@@ -55,6 +87,22 @@ var (
 		AccessDeniedErrorCode:         accessDeniedErrorMessage,
 		DeletedPostErrorCode:          deletedPostErrorMessage,
 		WrongChannelIdErrorCode:       wrongChannelIdErrorMessage,
+		InvalidCursorErrorCode:        invalidCursorErrorMessage,
+		MergedPostErrorCode:           mergedPostErrorMessage,
+		VotingDisabledErrorCode:       votingDisabledErrorMessage,
+		NotQAChannelErrorCode:         notQAChannelErrorMessage,
+		PollClosedErrorCode:           pollClosedErrorMessage,
+		PollAlreadyVotedErrorCode:     pollAlreadyVotedErrorMessage,
+		AttachmentTooLargeErrorCode:   attachmentTooLargeMessage,
+		InvalidAttachmentErrorCode:    invalidAttachmentMessage,
+		AttachmentNotFoundErrorCode:   attachmentNotFoundMessage,
+		QuotedPostErrorCode:           quotedPostErrorMessage,
+		ReplyDepthErrorCode:           replyDepthErrorMessage,
+		BlockedUserErrorCode:          blockedUserErrorMessage,
+		ChannelAccessErrorCode:        channelAccessErrorMessage,
+		RateLimitedErrorCode:          rateLimitedErrorMessage,
+		RequestDeniedErrorCode:        requestDeniedErrorMessage,
+		ModerationErrorCode:           moderationErrorMessage,
 	}
 )
 
@@ -74,6 +122,22 @@ var (
 		AccessDeniedErrorCode:         http.StatusUnauthorized,
 		DeletedPostErrorCode:          http.StatusBadRequest,
 		WrongChannelIdErrorCode:       http.StatusBadRequest,
+		InvalidCursorErrorCode:        http.StatusBadRequest,
+		MergedPostErrorCode:           http.StatusBadRequest,
+		VotingDisabledErrorCode:       http.StatusBadRequest,
+		NotQAChannelErrorCode:         http.StatusBadRequest,
+		PollClosedErrorCode:           http.StatusBadRequest,
+		PollAlreadyVotedErrorCode:     http.StatusConflict,
+		AttachmentTooLargeErrorCode:   http.StatusRequestEntityTooLarge,
+		InvalidAttachmentErrorCode:    http.StatusBadRequest,
+		AttachmentNotFoundErrorCode:   http.StatusNotFound,
+		QuotedPostErrorCode:           http.StatusBadRequest,
+		ReplyDepthErrorCode:           http.StatusBadRequest,
+		BlockedUserErrorCode:          http.StatusForbidden,
+		ChannelAccessErrorCode:        http.StatusForbidden,
+		RateLimitedErrorCode:          http.StatusTooManyRequests,
+		RequestDeniedErrorCode:        http.StatusForbidden,
+		ModerationErrorCode:           http.StatusUnprocessableEntity,
 	}
 )
 
@@ -1,11 +1,17 @@
 package exceptions
 
-import "net/http"
+import (
+	"net/http"
+	"time"
+)
 
 type Exception struct {
 	ErrorCode    ErrorCode
 	ErrorMessage ErrorMessage
 	HttpCode     int
+	// RetryAfter is how long a rate limited caller should wait before trying
+	// again.
+	RetryAfter time.Duration
 }
 
 func GetExceptionByErrorCode(code ErrorCode) *Exception {
@@ -31,6 +37,14 @@ func GetExceptionByErrorCodeWithCustomMessage(code ErrorCode, message string) *E
 	return e
 }
 
+// GetRateLimitedException refuses a request that can be retried after the
+// given wait.
+func GetRateLimitedException(message string, retryAfter time.Duration) *Exception {
+	e := GetExceptionByErrorCodeWithCustomMessage(RateLimitedErrorCode, message)
+	e.RetryAfter = retryAfter
+	return e
+}
+
 func (e *Exception) Error() string {
 	return string(e.ErrorMessage)
 }
@@ -86,9 +86,10 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
+require github.com/gin-contrib/cors v1.7.3
+
 require (
 	github.com/felixge/httpsnoop v1.0.4 // indirect
-	github.com/gin-contrib/cors v1.7.3 // indirect
 	github.com/gorilla/handlers v1.5.2 // indirect
 	google.golang.org/protobuf v1.36.1 // indirect
 )
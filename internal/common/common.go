@@ -8,9 +8,99 @@ const (
 	Like_Action      = "like"
 	Bookmark_Action  = "bookmark"
 	POST_REPLY       = "reply"
-	IDEAS_CHANNEL_ID = "community-ideas"
+
+	POST_STATUS_PUBLISHED = "PUBLISHED"
+	POST_STATUS_DELETED   = "DELETED"
+	POST_STATUS_HIDDEN    = "HIDDEN"
+	POST_STATUS_MERGED    = "MERGED"
+
+	POST_TYPE_COMMENT = "COMMENT"
+	POST_TYPE_REPLY   = "REPLY"
+	POST_TYPE_POLL    = "POLL"
+
+	SORT_NEWEST        = "newest"
+	SORT_TOP           = "top"
+	SORT_HOT           = "hot"
+	SORT_CONTROVERSIAL = "controversial"
+	SORT_SCORE         = "score"
+
+	SCORING_MODE_LIKES = "likes"
+	SCORING_MODE_VOTES = "votes"
+
+	VOTE_UP   = "up"
+	VOTE_DOWN = "down"
+	VOTE_NONE = "none"
+
+	CHANNEL_MODE_DISCUSSION = "discussion"
+	CHANNEL_MODE_QA         = "qa"
+	CHANNEL_MODE_IDEAS      = "ideas"
+
+	CHANNEL_VISIBILITY_PUBLIC       = "public"
+	CHANNEL_VISIBILITY_MEMBERS      = "members"
+	CHANNEL_VISIBILITY_ANNOUNCEMENT = "announcement"
+
+	MEMBERSHIP_STATUS_INVITED = "INVITED"
+	MEMBERSHIP_STATUS_ACTIVE  = "ACTIVE"
+
+	ATTACHMENT_STATUS_PENDING = "PENDING"
+	ATTACHMENT_STATUS_READY   = "READY"
+
+	RELATION_BLOCK = "BLOCK"
+	RELATION_MUTE  = "MUTE"
+
+	USER_STATUS_ACTIVE        = "ACTIVE"
+	USER_STATUS_SHADOW_BANNED = "SHADOW_BANNED"
+
+	FLAG_STATUS_OPEN     = "OPEN"
+	FLAG_STATUS_APPROVED = "APPROVED"
+	FLAG_STATUS_REMOVED  = "REMOVED"
+
+	AUDIT_ACTION_POST_DELETE       = "POST_DELETE"
+	AUDIT_ACTION_POST_RESTORE      = "POST_RESTORE"
+	AUDIT_ACTION_POST_HIDE         = "POST_HIDE"
+	AUDIT_ACTION_POST_APPROVE      = "POST_APPROVE"
+	AUDIT_ACTION_POST_PIN          = "POST_PIN"
+	AUDIT_ACTION_POST_UNPIN        = "POST_UNPIN"
+	AUDIT_ACTION_POST_MERGE        = "POST_MERGE"
+	AUDIT_ACTION_POST_MERGE_REVERT = "POST_MERGE_REVERT"
+	AUDIT_ACTION_USER_STATUS       = "USER_STATUS_CHANGE"
+	AUDIT_ACTION_MODERATOR_ADD     = "MODERATOR_ADD"
+	AUDIT_ACTION_MODERATOR_REMOVE  = "MODERATOR_REMOVE"
+	AUDIT_ACTION_MEMBER_REMOVE     = "MEMBER_REMOVE"
+	AUDIT_ACTION_IDEA_STATUS       = "IDEA_STATUS_CHANGE"
+
+	AUDIT_TARGET_POST = "POST"
+	AUDIT_TARGET_USER = "USER"
+
+	AUDIT_ROLE_AUTHOR    = "AUTHOR"
+	AUDIT_ROLE_MODERATOR = "MODERATOR"
+	AUDIT_ROLE_ADMIN     = "ADMIN"
+	AUDIT_ROLE_SYSTEM    = "SYSTEM"
+
+	IDEA_STATUS_OPEN         = "OPEN"
+	IDEA_STATUS_UNDER_REVIEW = "UNDER_REVIEW"
+	IDEA_STATUS_PLANNED      = "PLANNED"
+	IDEA_STATUS_IN_PROGRESS  = "IN_PROGRESS"
+	IDEA_STATUS_SHIPPED      = "SHIPPED"
+	IDEA_STATUS_DECLINED     = "DECLINED"
+
+	NOTIFICATION_IDEA_STATUS_CHANGED = "IDEA_STATUS_CHANGED"
+	NOTIFICATION_ANSWER_ACCEPTED     = "ANSWER_ACCEPTED"
+	NOTIFICATION_MENTIONED           = "MENTIONED"
+	NOTIFICATION_QUOTED              = "QUOTED"
+	NOTIFICATION_CHANNEL_INVITE      = "CHANNEL_INVITE"
 )
 
+// IsValidIdeaStatus reports whether status is one of the ideas board statuses.
+func IsValidIdeaStatus(status string) bool {
+	switch status {
+	case IDEA_STATUS_OPEN, IDEA_STATUS_UNDER_REVIEW, IDEA_STATUS_PLANNED,
+		IDEA_STATUS_IN_PROGRESS, IDEA_STATUS_SHIPPED, IDEA_STATUS_DECLINED:
+		return true
+	}
+	return false
+}
+
 type UserInfo struct {
 	UserID          string
 	ProfileImageUrl string
@@ -38,6 +128,28 @@ type Post struct {
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
 	DeletedAt     time.Time `json:"deleted_at"`
+
+	ReplyCount       int64   `json:"reply_count"`
+	ReportCount      int64   `json:"report_count"`
+	HotScore         float64 `json:"hot_score"`
+	ControversyScore float64 `json:"controversy_score"`
+
+	IdeaStatus       string `json:"idea_status"`
+	OfficialResponse string `json:"official_response"`
+	MergedIntoID     int64  `json:"merged_into_id"`
+
+	VoteScore     int64 `json:"vote_score"`
+	UpvoteCount   int64 `json:"upvote_count"`
+	DownvoteCount int64 `json:"downvote_count"`
+
+	AcceptedAnswerID int64 `json:"accepted_answer_id"`
+	IsAcceptedAnswer bool  `json:"is_accepted_answer"`
+
+	QuotedPostID int64 `json:"quoted_post_id"`
+	QuoteCount   int64 `json:"quote_count"`
+
+	RootID int64 `json:"root_id"`
+	Depth  int   `json:"depth"`
 }
 
 type CommentWithReplies struct {
@@ -86,18 +198,22 @@ type Pagination struct {
 }
 
 type AllRepliesPost struct {
-	PostID          string    `json:"post_id"`
-	UserName        string    `json:"user_name"`
-	ProfileImageUrl string    `json:"profile_image_url"`
-	Content         string    `json:"content"`
-	Type            string    `json:"type"`
-	LikeCount       int       `json:"like_count"`
-	Status          string    `json:"status"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
-	UserId          string    `json:"user_id"`
-	IsLiked         bool      `json:"is_liked"`
-	UserPhone       string    `json:"user_phone"`
-	BookmarkCount   int64     `json:"bookmarkCount"`
-	IsBookmarked    bool      `json:"isBookmarked"`
+	PostID           string    `json:"post_id"`
+	ChannelID        string    `json:"channel_id"`
+	UserName         string    `json:"user_name"`
+	ProfileImageUrl  string    `json:"profile_image_url"`
+	Content          string    `json:"content"`
+	Type             string    `json:"type"`
+	LikeCount        int       `json:"like_count"`
+	Status           string    `json:"status"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	UserId           string    `json:"user_id"`
+	IsLiked          bool      `json:"is_liked"`
+	UserPhone        string    `json:"user_phone"`
+	BookmarkCount    int64     `json:"bookmarkCount"`
+	IsBookmarked     bool      `json:"isBookmarked"`
+	AcceptedAnswerID int64     `json:"accepted_answer_id"`
+	QuotedPostID     int64     `json:"quoted_post_id"`
+	QuoteCount       int64     `json:"quote_count"`
 }
@@ -0,0 +1,29 @@
+package common
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// EncodeCursor turns the sort keys of the last returned row into an opaque
+// token that clients send back to fetch the next page.
+func EncodeCursor(keys interface{}) (string, error) {
+	data, err := json.Marshal(keys)
+	if err != nil {
+		return "", fmt.Errorf("encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor reverses EncodeCursor into keys.
+func DecodeCursor(cursor string, keys interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return fmt.Errorf("decode cursor: %w", err)
+	}
+	if err := json.Unmarshal(data, keys); err != nil {
+		return fmt.Errorf("decode cursor: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package common
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type testCursor struct {
+	ID        int64     `json:"i"`
+	CreatedAt time.Time `json:"c"`
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	want := testCursor{ID: 42, CreatedAt: time.Date(2024, 3, 1, 10, 30, 0, 123000000, time.UTC)}
+	cursor, err := EncodeCursor(want)
+	if err != nil {
+		t.Fatalf("EncodeCursor() error = %v", err)
+	}
+	if strings.ContainsAny(cursor, "+/=") {
+		t.Errorf("EncodeCursor() = %q, want a URL safe token without padding", cursor)
+	}
+	var got testCursor
+	if err := DecodeCursor(cursor, &got); err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	if got.ID != want.ID || !got.CreatedAt.Equal(want.CreatedAt) {
+		t.Errorf("DecodeCursor() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "%%%"},
+		{name: "not json", cursor: "bm90IGpzb24"},
+		{name: "wrong shape", cursor: "WzEsMl0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got testCursor
+			if err := DecodeCursor(tt.cursor, &got); err == nil {
+				t.Errorf("DecodeCursor(%q) = %+v, want an error", tt.cursor, got)
+			}
+		})
+	}
+}
+
+func TestEncodeCursorUnsupportedValue(t *testing.T) {
+	if _, err := EncodeCursor(map[string]interface{}{"f": func() {}}); err == nil {
+		t.Error("EncodeCursor() of a func value succeeded, want an error")
+	}
+}
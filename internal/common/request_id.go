@@ -0,0 +1,14 @@
+package common
+
+import "context"
+
+// REQUEST_ID is the key the id of the request being served is kept under in
+// its context, where the logger also looks for it.
+const REQUEST_ID = "x-request-id"
+
+// RequestID returns the id of the request ctx belongs to, or an empty string
+// outside of a request.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(REQUEST_ID).(string)
+	return requestID
+}
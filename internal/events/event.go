@@ -0,0 +1,134 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"github.com/google/uuid"
+)
+
+const (
+	AggregatePost   = "post"
+	AggregateReport = "report"
+)
+
+// Domain event types written to the outbox.
+const (
+	PostCreated      = "post.created"
+	ReplyCreated     = "reply.created"
+	PostDeleted      = "post.deleted"
+	PostLiked        = "post.liked"
+	PostUnliked      = "post.unliked"
+	PostBookmarked   = "post.bookmarked"
+	PostUnbookmarked = "post.unbookmarked"
+	PostReported     = "post.reported"
+	IdeaStatusSet    = "idea.status_changed"
+	PostMerged       = "post.merged"
+	PostUnmerged     = "post.unmerged"
+	PostVoted        = "post.voted"
+	AnswerAccepted   = "answer.accepted"
+	PollVoted        = "poll.voted"
+	PostEdited       = "post.edited"
+	PostRestored     = "post.restored"
+	PostPinned       = "post.pinned"
+	PostUnpinned     = "post.unpinned"
+)
+
+// Event is the message handed to an EventPublisher. ID is generated when the
+// outbox row is written and never changes, so consumers can use it to drop
+// duplicates caused by redelivery.
+type Event struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	Payload       json.RawMessage `json:"payload"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+}
+
+type PostPayload struct {
+	PostID    int64  `json:"post_id"`
+	ChannelID string `json:"channel_id"`
+	UserID    string `json:"user_id"`
+	ParentID  int64  `json:"parent_id"`
+	Type      string `json:"type"`
+	Status    string `json:"status"`
+}
+
+type ActionPayload struct {
+	PostID int64  `json:"post_id"`
+	UserID string `json:"user_id"`
+	Action string `json:"action"`
+	Value  bool   `json:"value"`
+}
+
+type ReportPayload struct {
+	ReportID       int64  `json:"report_id"`
+	PostID         string `json:"post_id"`
+	ReportedBy     string `json:"reported_by"`
+	MasterReportID int64  `json:"master_report_id"`
+}
+
+type IdeaStatusPayload struct {
+	PostID           int64  `json:"post_id"`
+	PreviousStatus   string `json:"previous_status"`
+	Status           string `json:"status"`
+	OfficialResponse string `json:"official_response"`
+	ModeratorID      string `json:"moderator_id"`
+}
+
+type MergePayload struct {
+	MergeID      int64  `json:"merge_id"`
+	SourcePostID int64  `json:"source_post_id"`
+	TargetPostID int64  `json:"target_post_id"`
+	ModeratorID  string `json:"moderator_id"`
+}
+
+type VotePayload struct {
+	PostID        int64  `json:"post_id"`
+	UserID        string `json:"user_id"`
+	Value         int    `json:"value"`
+	PreviousValue int    `json:"previous_value"`
+}
+
+type AcceptedAnswerPayload struct {
+	QuestionID       int64  `json:"question_id"`
+	AnswerID         int64  `json:"answer_id"`
+	PreviousAnswerID int64  `json:"previous_answer_id"`
+	AcceptedBy       string `json:"accepted_by"`
+}
+
+type PollVotePayload struct {
+	PostID    int64   `json:"post_id"`
+	UserID    string  `json:"user_id"`
+	OptionIDs []int64 `json:"option_ids"`
+}
+
+// NewOutboxEvent builds the outbox row for an event. The caller is expected to
+// insert it in the same transaction as the state change it describes.
+func NewOutboxEvent(eventType string, aggregateType string, aggregateID string, payload interface{}) (*dbModel.OutboxEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s payload: %w", eventType, err)
+	}
+	return &dbModel.OutboxEvent{
+		EventID:       uuid.NewString(),
+		EventType:     eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Payload:       string(data),
+	}, nil
+}
+
+func FromOutbox(row dbModel.OutboxEvent) Event {
+	return Event{
+		ID:            row.EventID,
+		Type:          row.EventType,
+		AggregateType: row.AggregateType,
+		AggregateID:   row.AggregateID,
+		Payload:       json.RawMessage(row.Payload),
+		OccurredAt:    row.CreatedAt,
+	}
+}
@@ -0,0 +1,114 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/storage/cache"
+)
+
+const (
+	PublisherRedis = "redis"
+
+	DefaultStreamName = "community_events"
+)
+
+// EventPublisher delivers outbox events to downstream consumers. Publish may be
+// called more than once for the same event, so implementations must carry the
+// event ID through to consumers.
+type EventPublisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+type redisStreamPublisher struct {
+	client cache.CacheBase
+	stream string
+	maxLen int64
+}
+
+// NewRedisStreamPublisher appends events to a Redis stream. A maxLen of zero
+// leaves the stream untrimmed.
+func NewRedisStreamPublisher(client cache.CacheBase, stream string, maxLen int64) EventPublisher {
+	if stream == "" {
+		stream = DefaultStreamName
+	}
+	return &redisStreamPublisher{
+		client: client,
+		stream: stream,
+		maxLen: maxLen,
+	}
+}
+
+func (p *redisStreamPublisher) Publish(ctx context.Context, event Event) error {
+	_, err := p.client.XAdd(ctx, p.stream, p.maxLen, map[string]interface{}{
+		"event_id":       event.ID,
+		"type":           event.Type,
+		"aggregate_type": event.AggregateType,
+		"aggregate_id":   event.AggregateID,
+		"payload":        string(event.Payload),
+		"occurred_at":    event.OccurredAt.Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return fmt.Errorf("publish event %s to stream %s: %w", event.ID, p.stream, err)
+	}
+	return nil
+}
+
+// InMemoryPublisher keeps published events in process and hands them to
+// subscribers synchronously. Events already seen are dropped, which mirrors
+// what a consumer of the Redis stream is expected to do with the event ID.
+// It keeps every event and only sees those of its own process, so it is meant
+// for tests and can't be picked through config.
+type InMemoryPublisher struct {
+	mu          sync.Mutex
+	seen        map[string]struct{}
+	events      []Event
+	subscribers []func(Event)
+}
+
+func NewInMemoryPublisher() *InMemoryPublisher {
+	return &InMemoryPublisher{
+		seen: make(map[string]struct{}),
+	}
+}
+
+func (p *InMemoryPublisher) Publish(ctx context.Context, event Event) error {
+	p.mu.Lock()
+	if _, ok := p.seen[event.ID]; ok {
+		p.mu.Unlock()
+		return nil
+	}
+	p.seen[event.ID] = struct{}{}
+	p.events = append(p.events, event)
+	subscribers := append([]func(Event){}, p.subscribers...)
+	p.mu.Unlock()
+
+	for _, subscriber := range subscribers {
+		subscriber(event)
+	}
+	return nil
+}
+
+func (p *InMemoryPublisher) Subscribe(handler func(Event)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.subscribers = append(p.subscribers, handler)
+}
+
+func (p *InMemoryPublisher) Events() []Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Event{}, p.events...)
+}
+
+// NewPublisher picks the publisher implementation named in config.
+func NewPublisher(kind string, client cache.CacheBase, stream string, maxLen int64) (EventPublisher, error) {
+	switch strings.ToLower(kind) {
+	case "", PublisherRedis:
+		return NewRedisStreamPublisher(client, stream, maxLen), nil
+	}
+	return nil, fmt.Errorf("unknown event publisher: %s", kind)
+}
@@ -0,0 +1,171 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/Abhishekjha321/community_service/pkg/store/db"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	outboxTable = "outbox_events"
+
+	DefaultRelayBatchSize   = 100
+	DefaultRelayInterval    = 2 * time.Second
+	DefaultRelayMaxAttempts = 10
+	// DefaultRelayClaimTTL is how long a claimed batch is kept from other
+	// relays. Publishing a batch is cut short when it runs out.
+	DefaultRelayClaimTTL = 30 * time.Second
+)
+
+// Relay moves committed outbox rows to an EventPublisher. A row is marked as
+// published only after Publish returns, so a crash in between leads to the
+// event being sent again rather than lost. A row that fails maxAttempts times
+// is parked with failed_at set and no longer holds back the rows after it.
+type Relay struct {
+	db          *db.Store
+	publisher   EventPublisher
+	batchSize   int
+	interval    time.Duration
+	maxAttempts int
+	claimTTL    time.Duration
+}
+
+func NewRelay(db *db.Store, publisher EventPublisher, batchSize int, interval time.Duration, maxAttempts int) *Relay {
+	if batchSize <= 0 {
+		batchSize = DefaultRelayBatchSize
+	}
+	if interval <= 0 {
+		interval = DefaultRelayInterval
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultRelayMaxAttempts
+	}
+	return &Relay{
+		db:          db,
+		publisher:   publisher,
+		batchSize:   batchSize,
+		interval:    interval,
+		maxAttempts: maxAttempts,
+		claimTTL:    DefaultRelayClaimTTL,
+	}
+}
+
+// Start polls the outbox until ctx is cancelled.
+func (r *Relay) Start(ctx context.Context) {
+	log := logger.GetLogInstance(ctx, "OutboxRelay")
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		for ctx.Err() == nil {
+			published, err := r.RelayOnce(ctx)
+			if err != nil {
+				log.Errorf("[OutboxRelay] failed to relay outbox events: %v", err)
+				break
+			}
+			if published < r.batchSize {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayOnce publishes a single batch of pending events in insertion order and
+// returns how many were published. The batch is claimed in a short
+// transaction so that no row locks are held while publishing; a claim left
+// behind by a relay that died runs out after claimTTL.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	rows, err := r.claim(ctx)
+	if err != nil || len(rows) == 0 {
+		return 0, err
+	}
+	publishCtx, cancel := context.WithTimeout(ctx, r.claimTTL)
+	defer cancel()
+
+	published := 0
+	for i, row := range rows {
+		if err := r.publisher.Publish(publishCtx, FromOutbox(row)); err != nil {
+			// Stop at the first failure so later events are not delivered
+			// ahead of the one that failed.
+			if err := r.markFailed(ctx, row, err); err != nil {
+				return published, err
+			}
+			return published, r.release(ctx, rows[i+1:])
+		}
+		now := time.Now()
+		res := r.db.MasterDB.WithContext(ctx).Table(outboxTable).Where("id = ?", row.ID).Updates(map[string]interface{}{
+			"published_at":  now,
+			"claimed_until": nil,
+			"attempts":      gorm.Expr("attempts + ?", 1),
+			"updated_at":    now,
+		})
+		if res.Error != nil {
+			return published, res.Error
+		}
+		published++
+	}
+	return published, nil
+}
+
+// claim takes the next batch of pending rows that no other relay holds.
+func (r *Relay) claim(ctx context.Context) ([]dbModel.OutboxEvent, error) {
+	var rows []dbModel.OutboxEvent
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		res := tx.Table(outboxTable).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL AND failed_at IS NULL").
+			Where("claimed_until IS NULL OR claimed_until < ?", now).
+			Order("id").
+			Limit(r.batchSize).
+			Find(&rows)
+		if res.Error != nil || len(rows) == 0 {
+			return res.Error
+		}
+		ids := make([]int64, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		return tx.Table(outboxTable).Where("id IN ?", ids).Update("claimed_until", now.Add(r.claimTTL)).Error
+	})
+	return rows, err
+}
+
+// markFailed records a failed publish and parks the row once it has run out
+// of attempts.
+func (r *Relay) markFailed(ctx context.Context, row dbModel.OutboxEvent, publishErr error) error {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"attempts":      gorm.Expr("attempts + ?", 1),
+		"last_error":    publishErr.Error(),
+		"claimed_until": nil,
+		"updated_at":    now,
+	}
+	if row.Attempts+1 >= r.maxAttempts {
+		logger.GetLogInstance(ctx, "OutboxRelay").Errorf("[OutboxRelay] parking event id: %s after %d attempts, last error: %v",
+			row.EventID, row.Attempts+1, publishErr)
+		updates["failed_at"] = now
+	}
+	return r.db.MasterDB.WithContext(ctx).Table(outboxTable).Where("id = ?", row.ID).Updates(updates).Error
+}
+
+// release hands back claimed rows that weren't tried.
+func (r *Relay) release(ctx context.Context, rows []dbModel.OutboxEvent) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	return r.db.MasterDB.WithContext(ctx).Table(outboxTable).Where("id IN ?", ids).Update("claimed_until", nil).Error
+}
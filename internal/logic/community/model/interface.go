@@ -2,6 +2,8 @@ package model
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/Abhishekjha321/community_service/dto"
 	"github.com/Abhishekjha321/community_service/exceptions"
@@ -10,41 +12,168 @@ import (
 )
 
 type Service interface {
-	GetPosts(ctx context.Context, channelID string, userID string, limit int, currentPage int, sortBy string, bookMarksOnly bool) (*dto.ResponseGetPosts, *exceptions.Exception)
+	GetPosts(ctx context.Context, channelID string, userID string, limit int, currentPage int, sortBy string, feedSort FeedSort, bookMarksOnly bool, cursor *FeedCursor) (*dto.ResponseGetPosts, *exceptions.Exception)
 	LikePost(ctx context.Context, postID string, action string, userID string, channelID string) *exceptions.Exception
-	DeletePost(ctx context.Context, postID string, userID string) *exceptions.Exception
+	DeletePost(ctx context.Context, postID string, userID string, reason string) *exceptions.Exception
+	RestorePost(ctx context.Context, requestBody *dto.RequestRestorePost, userID string) (*dto.ResponseRestorePost, *exceptions.Exception)
+	PinPost(ctx context.Context, requestBody *dto.RequestPinPost, userID string) (*dto.ResponsePinPost, *exceptions.Exception)
 	CreatePost(ctx context.Context, requestBody *dto.RequestCreatePost, userId string) (*dto.ResponseCreatePost, *exceptions.Exception)
 	ReportPost(ctx context.Context, requestBody *RequestReportPost, userId string) *exceptions.Exception
-	AllRepliesOnPost(ctx context.Context, postId string, userId string, channelID string, limit int, currentPage int, sortBy string) (*dto.ResponseAllRepliesOnPost, *exceptions.Exception)
-	GetPostsCount(ctx context.Context, channelID string) (int64, *exceptions.Exception)
-	GetRepliesCount(ctx context.Context, postID string) (int64, *exceptions.Exception)
+	AllRepliesOnPost(ctx context.Context, postId string, userId string, channelID string, limit int, currentPage int, sortBy string, moderatorView bool, cursor *FeedCursor) (*dto.ResponseAllRepliesOnPost, *exceptions.Exception)
+	GetPostsCount(ctx context.Context, channelID string, feedSort FeedSort) (int64, *exceptions.Exception)
+	GetRepliesCount(ctx context.Context, postID string, viewer Viewer) (int64, *exceptions.Exception)
 	MarkAsRead(ctx context.Context, userID string, channelID string) (*dto.ResponseMarkNotificationsAsRead, *exceptions.Exception)
 	HasUserReadPost(ctx context.Context, userID string, channelID string) (bool, error)
-	GetUserPostsCount(ctx context.Context, channelID string, userID string, sortBy string) (int, *exceptions.Exception)
-	GetUserEventPosts(ctx context.Context, channelID string, limit int, currentPage int, userID string, offset int, sortBy string) ([]common.Post, *exceptions.Exception)
+	GetUserPostsCount(ctx context.Context, channelID string, userID string, sortBy string, feedSort FeedSort) (int, *exceptions.Exception)
+	GetUserEventPosts(ctx context.Context, channelID string, limit int, currentPage int, userID string, offset int, sortBy string, feedSort FeedSort) ([]common.Post, *exceptions.Exception)
+	SearchPosts(ctx context.Context, filter SearchFilter) (*dto.ResponseSearchPosts, *exceptions.Exception)
+	GetTrendingPosts(ctx context.Context, channelID string, forumID int64, userID string, limit int) (*dto.ResponseTrendingPosts, *exceptions.Exception)
+	GetTrendingChannels(ctx context.Context, userID string, limit int) (*dto.ResponseTrendingChannels, *exceptions.Exception)
+	UpdateIdeaStatus(ctx context.Context, requestBody *dto.RequestUpdateIdeaStatus, userID string) (*dto.ResponseUpdateIdeaStatus, *exceptions.Exception)
+	GetNotifications(ctx context.Context, userID string, limit int, unreadOnly bool, cursor *NotificationCursor) (*dto.ResponseGetNotifications, *exceptions.Exception)
+	ReadNotifications(ctx context.Context, userID string, ids []int64) (*dto.ResponseReadNotifications, *exceptions.Exception)
+	AddChannelModerator(ctx context.Context, requestBody *dto.RequestChannelModerator, actorID string) *exceptions.Exception
+	RemoveChannelModerator(ctx context.Context, requestBody *dto.RequestChannelModerator, actorID string) *exceptions.Exception
+	MergePosts(ctx context.Context, requestBody *dto.RequestMergePosts, userID string) (*dto.ResponsePostMerge, *exceptions.Exception)
+	RevertPostMerge(ctx context.Context, requestBody *dto.RequestRevertPostMerge, userID string) (*dto.ResponsePostMerge, *exceptions.Exception)
+	VotePost(ctx context.Context, requestBody *dto.RequestVotePost, userID string) (*dto.ResponseVotePost, *exceptions.Exception)
+	GetChannelSettings(ctx context.Context, channelID string, userID string) (*dto.ResponseChannelSettings, *exceptions.Exception)
+	UpdateChannelSettings(ctx context.Context, requestBody *dto.RequestChannelSettings) (*dto.ResponseChannelSettings, *exceptions.Exception)
+	AcceptAnswer(ctx context.Context, requestBody *dto.RequestAcceptAnswer, userID string) (*dto.ResponseAcceptAnswer, *exceptions.Exception)
+	VotePoll(ctx context.Context, requestBody *dto.RequestVotePoll, userID string) (*dto.ResponseVotePoll, *exceptions.Exception)
+	UploadAttachment(ctx context.Context, userID string, fileName string, body io.Reader, size int64) (*dto.ResponseAttachment, *exceptions.Exception)
+	CreateAttachmentUploadURL(ctx context.Context, requestBody *dto.RequestAttachmentUploadURL, userID string) (*dto.ResponseAttachmentUploadURL, *exceptions.Exception)
+	ReceiveSignedUpload(ctx context.Context, upload SignedUpload, body io.Reader, size int64) *exceptions.Exception
+	CompleteAttachmentUpload(ctx context.Context, requestBody *dto.RequestCompleteAttachmentUpload, userID string) (*dto.ResponseAttachment, *exceptions.Exception)
+	GetAttachmentFile(ctx context.Context, key string, userID string) (io.ReadCloser, string, *exceptions.Exception)
+	EditPost(ctx context.Context, requestBody *dto.RequestEditPost, userID string) (*dto.ResponseEditPost, *exceptions.Exception)
+	SearchMentionUsers(ctx context.Context, channelID string, prefix string, userID string, limit int) (*dto.ResponseMentionUsers, *exceptions.Exception)
+	GetPopularTags(ctx context.Context, channelID string, forumID int64, userID string, limit int) (*dto.ResponsePopularTags, *exceptions.Exception)
+	GetThread(ctx context.Context, postID int64, userID string, depth int, limit int, moderatorView bool, cursor *ThreadCursor) (*dto.ResponseThread, *exceptions.Exception)
+	CreateBookmarkCollection(ctx context.Context, requestBody *dto.RequestBookmarkCollection, userID string) (*dto.ResponseBookmarkCollection, *exceptions.Exception)
+	GetBookmarkCollections(ctx context.Context, userID string) (*dto.ResponseBookmarkCollections, *exceptions.Exception)
+	RenameBookmarkCollection(ctx context.Context, requestBody *dto.RequestBookmarkCollection, userID string) (*dto.ResponseBookmarkCollection, *exceptions.Exception)
+	DeleteBookmarkCollection(ctx context.Context, collectionID int64, userID string) *exceptions.Exception
+	SaveBookmark(ctx context.Context, requestBody *dto.RequestSaveBookmark, userID string) (*dto.ResponseSaveBookmark, *exceptions.Exception)
+	GetSavedItems(ctx context.Context, userID string, collectionID *int64, limit int, cursor *SavedItemsCursor) (*dto.ResponseSavedItems, *exceptions.Exception)
+	AddUserRelation(ctx context.Context, requestBody *dto.RequestUserRelation, userID string) *exceptions.Exception
+	RemoveUserRelation(ctx context.Context, requestBody *dto.RequestUserRelation, userID string) *exceptions.Exception
+	GetUserRelations(ctx context.Context, userID string, relationType string) (*dto.ResponseUserRelations, *exceptions.Exception)
+	GetChannel(ctx context.Context, channelID string, userID string) (*dto.ResponseChannel, *exceptions.Exception)
+	JoinChannel(ctx context.Context, channelID string, userID string) (*dto.ResponseChannel, *exceptions.Exception)
+	LeaveChannel(ctx context.Context, channelID string, userID string) *exceptions.Exception
+	InviteToChannel(ctx context.Context, requestBody *dto.RequestChannelMembers, userID string) *exceptions.Exception
+	UpdateChannel(ctx context.Context, requestBody *dto.RequestUpdateChannel) (*dto.ResponseChannel, *exceptions.Exception)
+	AddChannelMembers(ctx context.Context, requestBody *dto.RequestChannelMembers) *exceptions.Exception
+	RemoveChannelMember(ctx context.Context, requestBody *dto.RequestChannelMember, actorID string) *exceptions.Exception
+	GetChannelBlocklist(ctx context.Context, channelID string) (*dto.ResponseChannelBlocklist, *exceptions.Exception)
+	UpdateChannelBlocklist(ctx context.Context, requestBody *dto.RequestChannelBlocklist) (*dto.ResponseChannelBlocklist, *exceptions.Exception)
+	GetPostFlags(ctx context.Context, channelID string, status string, userID string, limit int, cursor *PostFlagCursor) (*dto.ResponsePostFlags, *exceptions.Exception)
+	ReviewPostFlags(ctx context.Context, requestBody *dto.RequestReviewPostFlags, userID string) *exceptions.Exception
+	GetUserStatus(ctx context.Context, userID string) (*dto.ResponseUserStatus, *exceptions.Exception)
+	SetUserStatus(ctx context.Context, requestBody *dto.RequestUserStatus, actorID string) (*dto.ResponseUserStatus, *exceptions.Exception)
+	GetAuditLogs(ctx context.Context, filter AuditLogFilter) (*dto.ResponseAuditLogs, *exceptions.Exception)
+	ExportAuditLogs(ctx context.Context, filter AuditLogFilter, w io.Writer) *exceptions.Exception
 }
 
 type Repo interface {
 	GetUserDetailsForPostID(ctx context.Context, postIDs []int) ([]PostUserDetails, error)
-	GetEventPosts(ctx context.Context, channelID string, limit int, currentPage int, userId string, offset int, sortBy string) ([]common.Post, error)
+	GetEventPosts(ctx context.Context, channelID string, limit int, currentPage int, userId string, offset int, sortBy string, feedSort FeedSort) ([]common.Post, error)
 	GetBookMarkedPosts(ctx context.Context, channelID string, limit int, currentPage int, userId string, offset int, sortBy string) ([]common.Post, error)
 	ActionSpecificLikePost(ctx context.Context, postID string, action string, userID string) (string, *exceptions.Exception)
 	CheckPostIDValidity(ctx context.Context, postID string, channelId string) (common.Post, error)
-	DeleteSpecificPost(ctx context.Context, postID string, userID string) (string, error)
+	DeleteSpecificPost(ctx context.Context, postID string, actor AuditActor) (string, error)
 	InsertPostData(ctx context.Context, postData dbModel.Post) (*dbModel.Post, error)
 	GetUserDetailsByUserId(ctx context.Context, userId string) (*dbModel.UserDetails, error)
 	ReportPostData(ctx context.Context, report dbModel.Reports) (*dbModel.Reports, error)
 	PopulateUserInfoTable(ctx context.Context, userInfo common.UserInfo) error
-	GetAllRepliesOnPost(ctx context.Context, postId string, channelID string, limit int, currentPage int, sortBy string) ([]ReplyPost, error)
-	GetEventPostsCount(ctx context.Context, channelID string) (int64, error)
-	GetCommentSpecificReplyCount(ctx context.Context, postID string) (int64, error)
+	GetAllRepliesOnPost(ctx context.Context, postId string, channelID string, limit int, currentPage int, sortBy string, viewer Viewer) ([]ReplyPost, error)
+	GetEventPostsCount(ctx context.Context, channelID string, feedSort FeedSort) (int64, error)
+	GetCommentSpecificReplyCount(ctx context.Context, postID string, viewer Viewer) (int64, error)
 	GetPostByPostId(ctx context.Context, postId string) (*common.AllRepliesPost, error)
 	FetchUserPostSpecificActionValue(ctx context.Context, postID int64, userID string) (bool, bool, error)
 	GetUserIDByPostID(ctx context.Context, ParentId int64) (string, error)
-	GetUserSpecificPostsCount(ctx context.Context, channelId string, userId string) (int, error)
-	GetUserSpecificEventPosts(ctx context.Context, channelID string, limit int, currentPage int, userId string, offset int) ([]common.Post, error)
-	GetRelevantReplies(ctx context.Context, commentIds []int64, sortBy string, bookMarksOnly bool) ([]common.Post, error)
+	GetUserSpecificPostsCount(ctx context.Context, channelId string, userId string, feedSort FeedSort) (int, error)
+	GetUserSpecificEventPosts(ctx context.Context, channelID string, limit int, currentPage int, userId string, offset int, feedSort FeedSort) ([]common.Post, error)
+	GetRelevantReplies(ctx context.Context, commentIds []int64, sortBy string, bookMarksOnly bool, viewer Viewer) ([]common.Post, error)
 	UpdateRequiredActionInPostsTable(ctx context.Context, postID string, updateExpr string, userID string, action string) *exceptions.Exception
+	SearchPosts(ctx context.Context, filter SearchFilter) ([]SearchResult, error)
+	GetEventPostsAfterCursor(ctx context.Context, channelID string, userId string, limit int, sortBy string, feedSort FeedSort, phase string, cursor *FeedCursor) ([]common.Post, error)
+	GetBookMarkedPostsAfterCursor(ctx context.Context, channelID string, userId string, limit int, sortBy string, cursor *FeedCursor) ([]common.Post, error)
+	GetAllRepliesOnPostAfterCursor(ctx context.Context, postId string, limit int, sortBy string, viewer Viewer, cursor *FeedCursor) ([]ReplyPost, error)
+	GetForumIDsByChannelID(ctx context.Context, channelID string) ([]int64, error)
+	GetTrendingPostsByIDs(ctx context.Context, postIDs []int64) ([]TrendingPost, error)
+	IsChannelModerator(ctx context.Context, channelID string, userID string) (bool, error)
+	AddChannelModerator(ctx context.Context, channelID string, userID string, actor AuditActor) error
+	RemoveChannelModerator(ctx context.Context, channelID string, userID string, actor AuditActor) error
+	UpdateIdeaStatus(ctx context.Context, postID int64, status string, officialResponse string, actor AuditActor) (*dbModel.Post, error)
+	GetNotifications(ctx context.Context, userID string, limit int, beforeID int64, unreadOnly bool) ([]dbModel.Notification, error)
+	GetUnreadNotificationsCount(ctx context.Context, userID string) (int64, error)
+	MarkNotificationsRead(ctx context.Context, userID string, ids []int64) (int64, error)
+	MergePosts(ctx context.Context, sourceID int64, targetID int64, actor AuditActor) (*dbModel.PostMerge, *exceptions.Exception)
+	RevertPostMerge(ctx context.Context, mergeID int64, actor AuditActor) (*dbModel.PostMerge, *exceptions.Exception)
+	GetPostMerge(ctx context.Context, mergeID int64) (*dbModel.PostMerge, error)
+	GetPostDeletion(ctx context.Context, postID int64) (*dbModel.PostDeletion, error)
+	GetPostDeletionRoles(ctx context.Context, postIDs []int64) (map[int64]string, error)
+	RestorePost(ctx context.Context, deletionID int64, tags []string, actor AuditActor) (*dbModel.Post, *exceptions.Exception)
+	SetPostPinned(ctx context.Context, postID int64, pinned bool, actor AuditActor) (*dbModel.Post, error)
+	GetChannelSettings(ctx context.Context, channelID string) (*dbModel.ChannelSetting, error)
+	UpsertChannelSettings(ctx context.Context, setting *dbModel.ChannelSetting) error
+	VotePost(ctx context.Context, postID int64, userID string, value int) (*dbModel.Post, error)
+	GetUserVotes(ctx context.Context, userID string, postIDs []int64) (map[int64]int, error)
+	SetAcceptedAnswer(ctx context.Context, questionID int64, answerID int64, actorID string) (*dbModel.Post, error)
+	InsertPollPost(ctx context.Context, postData dbModel.Post, poll dbModel.Poll, options []string) (*dbModel.Post, error)
+	GetPolls(ctx context.Context, postIDs []int64) ([]dbModel.Poll, error)
+	GetPollOptions(ctx context.Context, postIDs []int64) ([]dbModel.PollOption, error)
+	GetUserPollVotes(ctx context.Context, userID string, postIDs []int64) ([]dbModel.PollVote, error)
+	VotePoll(ctx context.Context, postID int64, userID string, optionIDs []int64) *exceptions.Exception
+	InsertAttachment(ctx context.Context, attachment *dbModel.Attachment) error
+	GetAttachmentsByIDs(ctx context.Context, ids []int64) ([]dbModel.Attachment, error)
+	GetAttachmentByKey(ctx context.Context, key string) (*dbModel.Attachment, error)
+	GetPostAttachments(ctx context.Context, postIDs []int64) ([]dbModel.Attachment, error)
+	MarkAttachmentReady(ctx context.Context, attachment *dbModel.Attachment) error
+	LinkAttachments(ctx context.Context, postID int64, userID string, ids []int64) error
+	GetUsersByUserNames(ctx context.Context, userNames []string) ([]dbModel.UserDetails, error)
+	SavePostMentions(ctx context.Context, post dbModel.Post, mentions []dbModel.PostMention) error
+	GetPostMentions(ctx context.Context, postIDs []int64) ([]dbModel.PostMention, error)
+	SearchChannelUsers(ctx context.Context, channelID string, prefix string, limit int) ([]dbModel.UserDetails, error)
+	UpdatePostContent(ctx context.Context, postID int64, content string, mentions []dbModel.PostMention, tags []string) (*dbModel.Post, error)
+	SavePostTags(ctx context.Context, post dbModel.Post, tags []string) error
+	GetPopularTags(ctx context.Context, channelIDs []string, limit int) ([]TagCount, error)
+	GetChannelIDsByForumID(ctx context.Context, forumID int64) ([]string, error)
+	GetQuotedPosts(ctx context.Context, postIDs []int64, viewer Viewer) ([]QuotedPost, error)
+	GetThreadPost(ctx context.Context, postID int64) (*ReplyPost, error)
+	GetThreadReplies(ctx context.Context, parentIDs []int64, perParent int, viewer Viewer, cursor *ThreadCursor) ([]ReplyPost, error)
+	GetUserLikedPostIDs(ctx context.Context, userID string, postIDs []int64) (map[int64]bool, error)
+	CreateBookmarkCollection(ctx context.Context, collection *dbModel.BookmarkCollection) error
+	GetBookmarkCollection(ctx context.Context, userID string, collectionID int64) (*dbModel.BookmarkCollection, error)
+	GetBookmarkCollectionByName(ctx context.Context, userID string, name string) (*dbModel.BookmarkCollection, error)
+	GetBookmarkCollections(ctx context.Context, userID string) ([]BookmarkCollectionCount, error)
+	RenameBookmarkCollection(ctx context.Context, userID string, collectionID int64, name string) (*dbModel.BookmarkCollection, error)
+	DeleteBookmarkCollection(ctx context.Context, userID string, collectionID int64) error
+	SaveBookmark(ctx context.Context, detail dbModel.BookmarkDetail) error
+	GetSavedItems(ctx context.Context, userID string, collectionID *int64, limit int, cursor *SavedItemsCursor) ([]SavedItem, error)
+	AddUserRelation(ctx context.Context, relation dbModel.UserRelation) error
+	RemoveUserRelation(ctx context.Context, userID string, targetUserID string, relationType string) error
+	GetUserRelations(ctx context.Context, userID string, relationType string) ([]UserRelationDetails, error)
+	GetBlockingUsers(ctx context.Context, userIDs []string, targetUserID string) (map[string]bool, error)
+	GetChannel(ctx context.Context, channelID string) (*dbModel.Channel, error)
+	UpsertChannel(ctx context.Context, channel *dbModel.Channel) error
+	GetChannelMember(ctx context.Context, channelID string, userID string) (*dbModel.ChannelMember, error)
+	ActivateChannelMembers(ctx context.Context, channelID string, userIDs []string, invitedBy string) error
+	InviteChannelMembers(ctx context.Context, channelID string, userIDs []string, invitedBy string) ([]string, error)
+	RemoveChannelMember(ctx context.Context, channelID string, userID string, actor *AuditActor) error
+	GetRestrictedChannels(ctx context.Context, userID string, channelIDs []string) (map[string]bool, error)
+	HideReportedPost(ctx context.Context, postID int64, threshold int, actor AuditActor) (bool, error)
+	GetChannelBlockedWords(ctx context.Context, channelID string) ([]string, error)
+	SetChannelBlockedWords(ctx context.Context, channelID string, words []string) error
+	HasRecentPost(ctx context.Context, userID string, content string, since time.Time, excludePostID int64) (bool, error)
+	SavePostFlags(ctx context.Context, flags []dbModel.PostFlag) error
+	GetPostFlags(ctx context.Context, channelID string, status string, limit int, beforeID int64) ([]dbModel.PostFlag, error)
+	ReviewPostFlags(ctx context.Context, postID int64, status string, actor AuditActor) (int64, error)
+	GetUserStatus(ctx context.Context, userID string) (*dbModel.UserStatus, error)
+	SetUserStatus(ctx context.Context, userID string, status string, actor AuditActor) error
+	GetAuditLogs(ctx context.Context, filter AuditLogFilter) ([]dbModel.AuditLog, error)
 }
 
 type Consumer interface {
@@ -160,19 +160,27 @@ type PostUserDetails struct {
 }
 
 type ReplyPost struct {
-	ID              int64
-	UserID          string
-	UserPhone       string
-	FirstName       string
-	MiddleName      string
-	LastName        string
-	ProfileImageUrl string
-	Content         string
-	Type            string
-	Status          string
-	LikeCount       int64
-	CreatedAt       string
-	UpdatedAt       string
+	ID               int64
+	ChannelID        string
+	UserID           string
+	UserPhone        string
+	FirstName        string
+	MiddleName       string
+	LastName         string
+	ProfileImageUrl  string
+	Content          string
+	Type             string
+	Status           string
+	LikeCount        int64
+	IsPinned         bool
+	IsAcceptedAnswer bool
+	QuotedPostID     int64
+	QuoteCount       int64
+	ParentID         int64
+	Depth            int
+	ReplyCount       int64
+	CreatedAt        string
+	UpdatedAt        string
 }
 
 type ReportResponse struct {
@@ -185,3 +193,229 @@ type ReportResponse struct {
 	ChildTopic     string `json:"child_topic"`
 	ChildSubTitle  string `json:"child_subtitle"`
 }
+
+type SearchFilter struct {
+	Query     string
+	ChannelID string
+	ForumID   int64
+	AuthorID  string
+	Type      string
+	From      time.Time
+	To        time.Time
+	Limit     int
+	Cursor    *SearchCursor
+	Viewer    string
+}
+
+type SearchCursor struct {
+	Rank float64 `json:"r"`
+	ID   int64   `json:"i"`
+}
+
+type SearchResult struct {
+	ID              int64
+	ChannelID       string
+	ParentID        int64
+	UserID          string
+	FirstName       string
+	MiddleName      string
+	LastName        string
+	ProfileImageUrl string
+	Type            string
+	Status          string
+	LikeCount       int64
+	Rank            float64
+	Snippet         string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type TrendingPost struct {
+	ID              int64
+	ChannelID       string
+	UserID          string
+	FirstName       string
+	MiddleName      string
+	LastName        string
+	ProfileImageUrl string
+	Content         string
+	LikeCount       int64
+	BookmarkCount   int64
+	ReplyCount      int64
+	CreatedAt       time.Time
+}
+
+const (
+	FeedPhaseOwn    = "own"
+	FeedPhaseOthers = "others"
+)
+
+// FeedCursor carries the sort keys of the last row returned by a feed or
+// replies page. Phase is only set in the user based flow, where the viewer's
+// own posts are listed before everyone else's.
+type FeedCursor struct {
+	Order     string    `json:"o,omitempty"`
+	Phase     string    `json:"ph,omitempty"`
+	Pinned    bool      `json:"p"`
+	Deleted   bool      `json:"d"`
+	LikeCount int64     `json:"l"`
+	Score     float64   `json:"s,omitempty"`
+	VoteScore int64     `json:"v,omitempty"`
+	Accepted  bool      `json:"a,omitempty"`
+	CreatedAt time.Time `json:"c"`
+	UpdatedAt time.Time `json:"u"`
+	ID        int64     `json:"i"`
+}
+
+const (
+	MovedLikeExisting    = "existing"
+	MovedLikeReactivated = "reactivated"
+	MovedLikeCreated     = "created"
+)
+
+// MovedLike records how a like on a merged duplicate was carried over to the
+// canonical post: the user already liked it, an earlier unlike was turned
+// back into a like, or a new like was created.
+type MovedLike struct {
+	UserID string `json:"user_id"`
+	Target string `json:"target"`
+}
+
+// ThreadCursor carries the branch of a thread being paged and the last reply
+// returned on it. Replies of a branch are listed oldest first.
+type ThreadCursor struct {
+	ParentID  int64     `json:"p"`
+	CreatedAt time.Time `json:"c"`
+	ID        int64     `json:"i"`
+}
+
+// NotificationCursor carries the id of the last notification of a page.
+type NotificationCursor struct {
+	ID int64 `json:"i"`
+}
+
+// PostFlagCursor carries the id of the last flag of a page.
+type PostFlagCursor struct {
+	ID int64 `json:"i"`
+}
+
+// AuditActor is who takes a moderation action, in which role and why, as
+// recorded in the audit log along with the request it came in.
+type AuditActor struct {
+	UserID    string
+	Role      string
+	Reason    string
+	RequestID string
+}
+
+// AuditLogFilter narrows the audit log down. Empty fields match everything.
+type AuditLogFilter struct {
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	ChannelID  string
+	RequestID  string
+	From       time.Time
+	To         time.Time
+	Limit      int
+	Cursor     *AuditLogCursor
+}
+
+// AuditLogCursor carries the id of the last audit log entry of a page.
+type AuditLogCursor struct {
+	ID int64 `json:"i"`
+}
+
+// FeedSort selects the ordering of a channel feed. An empty Order keeps the
+// flow's default ordering. Since limits the feed to posts created after it
+// and is set for the top order's time window; IdeaStatus limits the ideas
+// board to ideas in that status. Answered, when set, keeps only questions
+// with (true) or without (false) an accepted answer. Tag keeps only posts
+// using that hashtag. Viewer, when set, leaves out the authors hidden from
+// the viewer.
+type FeedSort struct {
+	Order      string
+	Since      time.Time
+	IdeaStatus string
+	Answered   *bool
+	Tag        string
+	Viewer     Viewer
+}
+
+// Viewer is who posts are read for. Posts of authors the viewer blocked or
+// muted are left out, and so are posts of shadow banned users other than the
+// viewer unless a moderator reads with ModeratorView.
+type Viewer struct {
+	UserID        string
+	ModeratorView bool
+}
+
+// SignedUpload is the query of an upload URL signed by the local object
+// store, which the service checks before accepting the body.
+type SignedUpload struct {
+	Key         string
+	ContentType string
+	Expires     int64
+	Signature   string
+}
+
+// TagCount is how many live posts use a tag and when it was last used.
+type TagCount struct {
+	Tag        string
+	PostCount  int64
+	LastUsedAt time.Time
+}
+
+// QuotedPost is the quoted side of a quote with its author, whatever its
+// status; hiding deleted or hidden content is up to the caller.
+type QuotedPost struct {
+	ID              int64
+	ChannelID       string
+	UserID          string
+	FirstName       string
+	MiddleName      string
+	LastName        string
+	ProfileImageUrl string
+	Content         string
+	Type            string
+	Status          string
+	CreatedAt       time.Time
+}
+
+// BookmarkCollectionCount is a bookmark collection with the number of live
+// bookmarks filed in it.
+type BookmarkCollectionCount struct {
+	ID        int64
+	Name      string
+	ItemCount int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SavedItem is a bookmarked post with where the user filed it. SavedAt is
+// when the bookmark was last set.
+type SavedItem struct {
+	common.Post
+	CollectionID int64
+	Note         string
+	SavedAt      time.Time
+}
+
+// SavedItemsCursor carries the last bookmark of a saved items page.
+type SavedItemsCursor struct {
+	SavedAt time.Time `json:"s"`
+	ID      int64     `json:"i"`
+}
+
+// UserRelationDetails is a block or a mute along with the user it targets.
+type UserRelationDetails struct {
+	TargetUserID    string
+	Type            string
+	CreatedAt       time.Time
+	UserName        string
+	FirstName       string
+	MiddleName      string
+	LastName        string
+	ProfileImageUrl string
+}
@@ -0,0 +1,76 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/events"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SetAcceptedAnswer marks answerID as the accepted answer of questionID,
+// replacing any previously accepted one. An answerID of 0 clears it. The
+// author of a newly accepted answer is notified unless they accepted it
+// themselves.
+func (r *repo) SetAcceptedAnswer(ctx context.Context, questionID int64, answerID int64, actorID string) (*dbModel.Post, error) {
+	log := logger.GetLogInstance(ctx, "SetAcceptedAnswer")
+	var question dbModel.Post
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Table(postsTable).Where("id = ?", questionID).First(&question).Error; err != nil {
+			return err
+		}
+		previousAnswerID := question.AcceptedAnswerID
+		if previousAnswerID == answerID {
+			return nil
+		}
+
+		if previousAnswerID != 0 {
+			if err := tx.Table(postsTable).Where("id = ?", previousAnswerID).Update("is_accepted_answer", false).Error; err != nil {
+				return err
+			}
+		}
+		if answerID != 0 {
+			if err := tx.Table(postsTable).Where("id = ? AND parent_id = ?", answerID, questionID).Update("is_accepted_answer", true).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Table(postsTable).Where("id = ?", questionID).Update("accepted_answer_id", answerID).Error; err != nil {
+			return err
+		}
+		question.AcceptedAnswerID = answerID
+
+		if answerID != 0 {
+			var answer dbModel.Post
+			if err := tx.Table(postsTable).Select("id, user_id").Where("id = ?", answerID).First(&answer).Error; err != nil {
+				return err
+			}
+			if answer.UserID != actorID {
+				if err := insertNotifications(tx, []dbModel.Notification{{
+					UserID:    answer.UserID,
+					Type:      common.NOTIFICATION_ANSWER_ACCEPTED,
+					ChannelID: question.ChannelID,
+					PostID:    answerID,
+					ActorID:   actorID,
+					Message:   "Your answer was accepted",
+				}}); err != nil {
+					return err
+				}
+			}
+		}
+
+		return addOutboxEvent(tx, events.AnswerAccepted, events.AggregatePost, questionID, events.AcceptedAnswerPayload{
+			QuestionID:       questionID,
+			AnswerID:         answerID,
+			PreviousAnswerID: previousAnswerID,
+			AcceptedBy:       actorID,
+		})
+	})
+	if err != nil {
+		log.Errorf("[SetAcceptedAnswer] unable to set accepted answer of question id: %d with error: %v", questionID, err)
+		return nil, err
+	}
+	return &question, nil
+}
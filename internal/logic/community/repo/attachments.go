@@ -0,0 +1,113 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+)
+
+const attachmentsTable = "attachments"
+
+func (r *repo) InsertAttachment(ctx context.Context, attachment *dbModel.Attachment) error {
+	log := logger.GetLogInstance(ctx, "InsertAttachment")
+	if err := r.db.MasterDB.WithContext(ctx).Table(attachmentsTable).Create(attachment).Error; err != nil {
+		log.Errorf("[InsertAttachment] unable to create attachment for user id: %s with error: %v", attachment.UserID, err)
+		return err
+	}
+	return nil
+}
+
+func (r *repo) GetAttachmentsByIDs(ctx context.Context, ids []int64) ([]dbModel.Attachment, error) {
+	log := logger.GetLogInstance(ctx, "GetAttachmentsByIDs")
+	var attachments []dbModel.Attachment
+	if len(ids) == 0 {
+		return attachments, nil
+	}
+	db := r.db.MasterDB.WithContext(ctx).Table(attachmentsTable).Where("id IN ?", ids).Find(&attachments)
+	if db.Error != nil {
+		log.Errorf("[GetAttachmentsByIDs] unable to fetch attachments with error: %v", db.Error)
+		return nil, db.Error
+	}
+	return attachments, nil
+}
+
+// GetAttachmentByKey returns the attachment stored under key, either as its
+// file or as its thumbnail.
+func (r *repo) GetAttachmentByKey(ctx context.Context, key string) (*dbModel.Attachment, error) {
+	log := logger.GetLogInstance(ctx, "GetAttachmentByKey")
+	var attachment dbModel.Attachment
+	db := r.db.MasterDB.WithContext(ctx).Table(attachmentsTable).
+		Where("storage_key = ? OR thumbnail_key = ?", key, key).
+		First(&attachment)
+	if db.Error != nil {
+		if !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			log.Errorf("[GetAttachmentByKey] unable to fetch attachment of key: %s with error: %v", key, db.Error)
+		}
+		return nil, db.Error
+	}
+	return &attachment, nil
+}
+
+// GetPostAttachments returns the attachments linked to the given posts in
+// upload order.
+func (r *repo) GetPostAttachments(ctx context.Context, postIDs []int64) ([]dbModel.Attachment, error) {
+	log := logger.GetLogInstance(ctx, "GetPostAttachments")
+	var attachments []dbModel.Attachment
+	if len(postIDs) == 0 {
+		return attachments, nil
+	}
+	db := r.db.MasterDB.WithContext(ctx).Table(attachmentsTable).
+		Where("post_id IN ? AND status = ?", postIDs, common.ATTACHMENT_STATUS_READY).
+		Order("post_id, id").
+		Find(&attachments)
+	if db.Error != nil {
+		log.Errorf("[GetPostAttachments] unable to fetch attachments with error: %v", db.Error)
+		return nil, db.Error
+	}
+	return attachments, nil
+}
+
+// MarkAttachmentReady stores what was learned from the uploaded content. Only
+// a PENDING attachment can be completed, so a retried completion is a no-op.
+func (r *repo) MarkAttachmentReady(ctx context.Context, attachment *dbModel.Attachment) error {
+	log := logger.GetLogInstance(ctx, "MarkAttachmentReady")
+	db := r.db.MasterDB.WithContext(ctx).Table(attachmentsTable).
+		Where("id = ? AND status = ?", attachment.ID, common.ATTACHMENT_STATUS_PENDING).
+		Updates(map[string]interface{}{
+			"thumbnail_key": attachment.ThumbnailKey,
+			"content_type":  attachment.ContentType,
+			"size":          attachment.Size,
+			"width":         attachment.Width,
+			"height":        attachment.Height,
+			"status":        common.ATTACHMENT_STATUS_READY,
+			"updated_at":    time.Now(),
+		})
+	if db.Error != nil {
+		log.Errorf("[MarkAttachmentReady] unable to complete attachment id: %d with error: %v", attachment.ID, db.Error)
+		return db.Error
+	}
+	return nil
+}
+
+// LinkAttachments attaches the user's ready, unlinked attachments to a post.
+// It fails if any of them has been used or changed in the meantime.
+func (r *repo) LinkAttachments(ctx context.Context, postID int64, userID string, ids []int64) error {
+	log := logger.GetLogInstance(ctx, "LinkAttachments")
+	db := r.db.MasterDB.WithContext(ctx).Table(attachmentsTable).
+		Where("id IN ? AND user_id = ? AND post_id = 0 AND status = ?", ids, userID, common.ATTACHMENT_STATUS_READY).
+		Updates(map[string]interface{}{"post_id": postID, "updated_at": time.Now()})
+	if db.Error != nil {
+		log.Errorf("[LinkAttachments] unable to link attachments to post id: %d with error: %v", postID, db.Error)
+		return db.Error
+	}
+	if db.RowsAffected != int64(len(ids)) {
+		return fmt.Errorf("linked %d of %d attachments to post id: %d", db.RowsAffected, len(ids), postID)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+)
+
+const auditLogsTable = "audit_logs"
+
+// auditPost is the state of a post kept in the audit log.
+type auditPost struct {
+	UserID       string `json:"user_id"`
+	ChannelID    string `json:"channel_id"`
+	ParentID     int64  `json:"parent_id,omitempty"`
+	Content      string `json:"content"`
+	Status       string `json:"status"`
+	IsPinned     bool   `json:"is_pinned"`
+	MergedIntoID int64  `json:"merged_into_id,omitempty"`
+}
+
+func newAuditPost(post dbModel.Post) auditPost {
+	return auditPost{
+		UserID:       post.UserID,
+		ChannelID:    post.ChannelID,
+		ParentID:     post.ParentID,
+		Content:      post.Content,
+		Status:       post.Status,
+		IsPinned:     post.IsPinned,
+		MergedIntoID: post.MergedIntoID,
+	}
+}
+
+// readAuditPost reads the current state of a post inside tx.
+func readAuditPost(tx *gorm.DB, postID interface{}) (auditPost, error) {
+	var post dbModel.Post
+	if err := tx.Table(postsTable).Where("id = ?", postID).First(&post).Error; err != nil {
+		return auditPost{}, err
+	}
+	return newAuditPost(post), nil
+}
+
+// addAuditLog appends entry to the audit log on the given transaction so it
+// is only kept when the action it records is committed. The actor fields of
+// entry are filled in from actor and before and after are stored as JSON.
+func addAuditLog(tx *gorm.DB, actor model.AuditActor, entry dbModel.AuditLog, before interface{}, after interface{}) error {
+	beforeData, err := json.Marshal(before)
+	if err != nil {
+		return err
+	}
+	afterData, err := json.Marshal(after)
+	if err != nil {
+		return err
+	}
+	entry.ActorID = actor.UserID
+	entry.ActorRole = actor.Role
+	entry.Reason = actor.Reason
+	entry.RequestID = actor.RequestID
+	entry.BeforeData = string(beforeData)
+	entry.AfterData = string(afterData)
+	entry.CreatedAt = time.Now()
+	return tx.Table(auditLogsTable).Create(&entry).Error
+}
+
+// GetAuditLogs lists the audit log entries matching filter, newest first.
+func (r *repo) GetAuditLogs(ctx context.Context, filter model.AuditLogFilter) ([]dbModel.AuditLog, error) {
+	log := logger.GetLogInstance(ctx, "GetAuditLogs")
+	var logs []dbModel.AuditLog
+	query := r.db.MasterDB.WithContext(ctx).Table(auditLogsTable)
+	for column, value := range map[string]string{
+		"actor_id":    filter.ActorID,
+		"action":      filter.Action,
+		"target_type": filter.TargetType,
+		"target_id":   filter.TargetID,
+		"channel_id":  filter.ChannelID,
+		"request_id":  filter.RequestID,
+	} {
+		if value != "" {
+			query = query.Where(column+" = ?", value)
+		}
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at <= ?", filter.To)
+	}
+	if filter.Cursor != nil {
+		query = query.Where("id < ?", filter.Cursor.ID)
+	}
+	db := query.Order("id desc").Limit(filter.Limit).Find(&logs)
+	if db.Error != nil {
+		log.Errorf("[GetAuditLogs] unable to fetch audit logs with error: %v", db.Error)
+		return nil, db.Error
+	}
+	return logs, nil
+}
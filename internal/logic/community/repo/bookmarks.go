@@ -0,0 +1,240 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/internal/events"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	bookmarkCollectionsTable = "bookmark_collections"
+	bookmarkDetailsTable     = "bookmark_details"
+)
+
+func (r *repo) CreateBookmarkCollection(ctx context.Context, collection *dbModel.BookmarkCollection) error {
+	log := logger.GetLogInstance(ctx, "CreateBookmarkCollection")
+	if err := r.db.MasterDB.WithContext(ctx).Table(bookmarkCollectionsTable).Create(collection).Error; err != nil {
+		log.Errorf("[CreateBookmarkCollection] unable to create collection for user id: %s with error: %v", collection.UserID, err)
+		return err
+	}
+	return nil
+}
+
+// GetBookmarkCollection returns a collection of the user, or
+// gorm.ErrRecordNotFound when the user has no such collection.
+func (r *repo) GetBookmarkCollection(ctx context.Context, userID string, collectionID int64) (*dbModel.BookmarkCollection, error) {
+	var collection dbModel.BookmarkCollection
+	err := r.db.MasterDB.WithContext(ctx).Table(bookmarkCollectionsTable).
+		Where("id = ? AND user_id = ?", collectionID, userID).First(&collection).Error
+	if err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// GetBookmarkCollectionByName looks a collection of the user up by name,
+// ignoring case. It returns nil when there is none.
+func (r *repo) GetBookmarkCollectionByName(ctx context.Context, userID string, name string) (*dbModel.BookmarkCollection, error) {
+	log := logger.GetLogInstance(ctx, "GetBookmarkCollectionByName")
+	var collections []dbModel.BookmarkCollection
+	db := r.db.MasterDB.WithContext(ctx).Table(bookmarkCollectionsTable).
+		Where("user_id = ? AND LOWER(name) = LOWER(?)", userID, name).Limit(1).Find(&collections)
+	if db.Error != nil {
+		log.Errorf("[GetBookmarkCollectionByName] unable to fetch collection of user id: %s with error: %v", userID, db.Error)
+		return nil, db.Error
+	}
+	if len(collections) == 0 {
+		return nil, nil
+	}
+	return &collections[0], nil
+}
+
+// GetBookmarkCollections lists the user's collections by name, each with the
+// number of posts still bookmarked in it.
+func (r *repo) GetBookmarkCollections(ctx context.Context, userID string) ([]model.BookmarkCollectionCount, error) {
+	log := logger.GetLogInstance(ctx, "GetBookmarkCollections")
+	var collections []model.BookmarkCollectionCount
+	db := r.db.MasterDB.WithContext(ctx).Raw(`
+		SELECT
+			c.id,
+			c.name,
+			c.created_at,
+			c.updated_at,
+			COUNT(ua.id) AS item_count
+		FROM bookmark_collections c
+		LEFT JOIN bookmark_details bd ON bd.collection_id = c.id
+		LEFT JOIN user_actions ua ON ua.post_id = bd.post_id AND ua.user_id = bd.user_id AND ua.action = ? AND ua.value = true
+		WHERE c.user_id = ?
+		GROUP BY c.id
+		ORDER BY LOWER(c.name), c.id
+	`, bookmark, userID).Scan(&collections)
+	if db.Error != nil {
+		log.Errorf("[GetBookmarkCollections] unable to fetch collections of user id: %s with error: %v", userID, db.Error)
+		return nil, db.Error
+	}
+	return collections, nil
+}
+
+func (r *repo) RenameBookmarkCollection(ctx context.Context, userID string, collectionID int64, name string) (*dbModel.BookmarkCollection, error) {
+	log := logger.GetLogInstance(ctx, "RenameBookmarkCollection")
+	res := r.db.MasterDB.WithContext(ctx).Table(bookmarkCollectionsTable).
+		Where("id = ? AND user_id = ?", collectionID, userID).
+		Updates(map[string]interface{}{"name": name, "updated_at": time.Now()})
+	if res.Error != nil {
+		log.Errorf("[RenameBookmarkCollection] unable to rename collection id: %d with error: %v", collectionID, res.Error)
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.GetBookmarkCollection(ctx, userID, collectionID)
+}
+
+// DeleteBookmarkCollection removes a collection. Its bookmarks are kept,
+// notes included, and are left outside of any collection.
+func (r *repo) DeleteBookmarkCollection(ctx context.Context, userID string, collectionID int64) error {
+	log := logger.GetLogInstance(ctx, "DeleteBookmarkCollection")
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Table(bookmarkCollectionsTable).Where("id = ? AND user_id = ?", collectionID, userID).Delete(&dbModel.BookmarkCollection{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Table(bookmarkDetailsTable).Where("user_id = ? AND collection_id = ?", userID, collectionID).
+			Updates(map[string]interface{}{"collection_id": 0, "updated_at": time.Now()}).Error
+	})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Errorf("[DeleteBookmarkCollection] unable to delete collection id: %d with error: %v", collectionID, err)
+	}
+	return err
+}
+
+// SaveBookmark bookmarks a post if it isn't already and files it with the
+// collection and note of detail, replacing earlier ones.
+func (r *repo) SaveBookmark(ctx context.Context, detail dbModel.BookmarkDetail) error {
+	log := logger.GetLogInstance(ctx, "SaveBookmark")
+	postID := strconv.FormatInt(detail.PostID, 10)
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var userActions []dbModel.UserActions
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Table(userActionsTable).
+			Where("post_id = ? AND user_id = ? AND action = ?", detail.PostID, detail.UserID, bookmark).
+			Find(&userActions).Error; err != nil {
+			return err
+		}
+		if len(userActions) == 0 || !*userActions[0].Value {
+			if exp := updateRequiredActionInPostsTable(ctx, tx, postID, "bookmark_count + ?", detail.UserID, bookmark); exp != nil {
+				return exp
+			}
+			active := true
+			var res *gorm.DB
+			if len(userActions) > 0 {
+				res = tx.Table(userActionsTable).Where("id = ?", userActions[0].ID).Updates(&dbModel.UserActions{Value: &active})
+			} else {
+				res = tx.Table(userActionsTable).Create(&dbModel.UserActions{
+					UserID: detail.UserID,
+					PostID: detail.PostID,
+					Action: bookmark,
+					Value:  &active,
+				})
+			}
+			if res.Error != nil {
+				return res.Error
+			}
+			err := addOutboxEvent(tx, events.PostBookmarked, events.AggregatePost, detail.PostID, events.ActionPayload{
+				PostID: detail.PostID,
+				UserID: detail.UserID,
+				Action: bookmark,
+				Value:  true,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Table(bookmarkDetailsTable).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "post_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"collection_id", "note", "updated_at"}),
+		}).Create(&detail).Error
+	})
+	if err != nil {
+		log.Errorf("[SaveBookmark] unable to save bookmark of post id: %d for user id: %s with error: %v", detail.PostID, detail.UserID, err)
+		return err
+	}
+	return nil
+}
+
+// removeBookmarkDetail forgets the collection and note of a bookmark once it
+// is taken off.
+func removeBookmarkDetail(tx *gorm.DB, postID string, userID string) error {
+	return tx.Table(bookmarkDetailsTable).Where("post_id = ? AND user_id = ?", postID, userID).Delete(&dbModel.BookmarkDetail{}).Error
+}
+
+// GetSavedItems lists the posts a user bookmarked across all channels they can
+// still read, most recently saved first. A nil collectionID lists every bookmark and 0 those
+// outside of any collection.
+func (r *repo) GetSavedItems(ctx context.Context, userID string, collectionID *int64, limit int, cursor *model.SavedItemsCursor) ([]model.SavedItem, error) {
+	log := logger.GetLogInstance(ctx, "GetSavedItems")
+	var items []model.SavedItem
+
+	keys := []sortKey{{expr: "ua.updated_at", desc: true}, {expr: "ua.post_id", desc: true}}
+	whereClause := "ua.action = ? AND ua.user_id = ? AND ua.value = true AND " + readableChannelsCondition("p.channel_id")
+	params := []interface{}{bookmark, userID, userID, userID}
+	if collectionID != nil {
+		whereClause += " AND COALESCE(bd.collection_id, 0) = ?"
+		params = append(params, *collectionID)
+	}
+	if cursor != nil {
+		keys[0].value, keys[1].value = cursor.SavedAt, cursor.ID
+		condition, conditionParams := keysetCondition(keys)
+		whereClause += " AND " + condition
+		params = append(params, conditionParams...)
+	}
+	params = append(params, limit)
+
+	db := r.db.MasterDB.WithContext(ctx).Raw(`
+	SELECT
+		p.id,
+		p.channel_id,
+		p.user_id,
+		p.content,
+		p.type,
+		p.is_pinned,
+		p.parent_id,
+		p.like_count,
+		p.bookmark_count,
+		p.vote_score,
+		p.upvote_count,
+		p.downvote_count,
+		p.accepted_answer_id,
+		p.is_accepted_answer,
+		p.quoted_post_id,
+		p.quote_count,
+		p.status,
+		p.created_at,
+		p.updated_at,
+		p.deleted_at,
+		COALESCE(bd.collection_id, 0) AS collection_id,
+		COALESCE(bd.note, '') AS note,
+		ua.updated_at AS saved_at
+		FROM user_actions ua
+		JOIN posts p ON p.id = ua.post_id
+		LEFT JOIN bookmark_details bd ON bd.post_id = ua.post_id AND bd.user_id = ua.user_id
+		WHERE `+whereClause+`
+		ORDER BY `+keysetOrder(keys)+`
+		LIMIT ?
+	`, params...).Scan(&items)
+	if db.Error != nil {
+		log.Errorf("[GetSavedItems] unable to fetch saved items of user id: %s with error: %v", userID, db.Error)
+		return nil, db.Error
+	}
+	return items, nil
+}
@@ -0,0 +1,54 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const channelSettingsTable = "channel_settings"
+
+// channelSettingsColumns are the columns an upsert of the settings writes.
+var channelSettingsColumns = []string{"scoring_mode", "channel_mode", "default_flow", "replies_disabled",
+	"reactions_disabled", "max_post_length", "slow_mode_seconds", "report_threshold", "updated_at"}
+
+// GetChannelSettings returns the settings of a channel. Channels that were
+// never configured get the defaults rather than an error.
+func (r *repo) GetChannelSettings(ctx context.Context, channelID string) (*dbModel.ChannelSetting, error) {
+	log := logger.GetLogInstance(ctx, "GetChannelSettings")
+	var setting dbModel.ChannelSetting
+	err := r.db.MasterDB.WithContext(ctx).Table(channelSettingsTable).Where("channel_id = ?", channelID).First(&setting).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &dbModel.ChannelSetting{
+			ChannelID:   channelID,
+			ScoringMode: common.SCORING_MODE_LIKES,
+			ChannelMode: common.CHANNEL_MODE_DISCUSSION,
+			DefaultFlow: common.USER_BASED_FLOW,
+		}, nil
+	}
+	if err != nil {
+		log.Errorf("[GetChannelSettings] unable to fetch settings for channel id: %s with error: %v", channelID, err)
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (r *repo) UpsertChannelSettings(ctx context.Context, setting *dbModel.ChannelSetting) error {
+	log := logger.GetLogInstance(ctx, "UpsertChannelSettings")
+	setting.UpdatedAt = time.Now()
+	db := r.db.MasterDB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "channel_id"}},
+		DoUpdates: clause.AssignmentColumns(channelSettingsColumns),
+	}).Create(setting)
+	if db.Error != nil {
+		log.Errorf("[UpsertChannelSettings] unable to save settings for channel id: %s with error: %v", setting.ChannelID, db.Error)
+		return db.Error
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	channelsTable       = "channels"
+	channelMembersTable = "channel_members"
+	// restrictedChannelsQuery selects the members-only channels a user is
+	// neither an active member nor a moderator of. It takes the user id twice.
+	restrictedChannelsQuery = `SELECT c.channel_id FROM ` + channelsTable + ` c
+		WHERE c.visibility = '` + common.CHANNEL_VISIBILITY_MEMBERS + `'
+		AND NOT EXISTS (SELECT 1 FROM ` + channelMembersTable + ` m WHERE m.channel_id = c.channel_id AND m.user_id = ? AND m.status = '` + common.MEMBERSHIP_STATUS_ACTIVE + `')
+		AND NOT EXISTS (SELECT 1 FROM ` + channelModeratorsTable + ` cm WHERE cm.channel_id = c.channel_id AND cm.user_id = ?)`
+)
+
+// readableChannelsCondition keeps the rows whose channel, read from column,
+// the user passed as its two parameters can read.
+func readableChannelsCondition(column string) string {
+	return column + " NOT IN (" + restrictedChannelsQuery + ")"
+}
+
+// GetChannel returns the registered channel, or nil when the channel was
+// never registered.
+func (r *repo) GetChannel(ctx context.Context, channelID string) (*dbModel.Channel, error) {
+	log := logger.GetLogInstance(ctx, "GetChannel")
+	var channels []dbModel.Channel
+	db := r.db.MasterDB.WithContext(ctx).Table(channelsTable).Where("channel_id = ?", channelID).Limit(1).Find(&channels)
+	if db.Error != nil {
+		log.Errorf("[GetChannel] unable to fetch channel id: %s with error: %v", channelID, db.Error)
+		return nil, db.Error
+	}
+	if len(channels) == 0 {
+		return nil, nil
+	}
+	return &channels[0], nil
+}
+
+// UpsertChannel registers a channel or changes the details of a registered
+// one.
+func (r *repo) UpsertChannel(ctx context.Context, channel *dbModel.Channel) error {
+	log := logger.GetLogInstance(ctx, "UpsertChannel")
+	db := r.db.MasterDB.WithContext(ctx).Table(channelsTable).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "channel_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "description", "owner_id", "visibility", "archived", "updated_at"}),
+	}).Create(channel)
+	if db.Error != nil {
+		log.Errorf("[UpsertChannel] unable to save channel id: %s with error: %v", channel.ChannelID, db.Error)
+		return db.Error
+	}
+	return nil
+}
+
+// GetChannelMember returns the user's membership of the channel, or nil
+// when the user is neither a member nor invited.
+func (r *repo) GetChannelMember(ctx context.Context, channelID string, userID string) (*dbModel.ChannelMember, error) {
+	log := logger.GetLogInstance(ctx, "GetChannelMember")
+	var members []dbModel.ChannelMember
+	db := r.db.MasterDB.WithContext(ctx).Table(channelMembersTable).
+		Where("channel_id = ? AND user_id = ?", channelID, userID).Limit(1).Find(&members)
+	if db.Error != nil {
+		log.Errorf("[GetChannelMember] unable to fetch membership of user id: %s in channel id: %s with error: %v", userID, channelID, db.Error)
+		return nil, db.Error
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+	return &members[0], nil
+}
+
+// ActivateChannelMembers makes the users active members of the channel,
+// whether they were invited before or not.
+func (r *repo) ActivateChannelMembers(ctx context.Context, channelID string, userIDs []string, invitedBy string) error {
+	log := logger.GetLogInstance(ctx, "ActivateChannelMembers")
+	if len(userIDs) == 0 {
+		return nil
+	}
+	members := make([]dbModel.ChannelMember, 0, len(userIDs))
+	for _, userID := range userIDs {
+		members = append(members, dbModel.ChannelMember{
+			ChannelID: channelID,
+			UserID:    userID,
+			Status:    common.MEMBERSHIP_STATUS_ACTIVE,
+			InvitedBy: invitedBy,
+		})
+	}
+	db := r.db.MasterDB.WithContext(ctx).Table(channelMembersTable).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "channel_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "updated_at"}),
+	}).Create(&members)
+	if db.Error != nil {
+		log.Errorf("[ActivateChannelMembers] unable to add members to channel id: %s with error: %v", channelID, db.Error)
+		return db.Error
+	}
+	return nil
+}
+
+// InviteChannelMembers invites the users to the channel and notifies them.
+// Users who are already members or invited are left as they are. It returns
+// the users newly invited.
+func (r *repo) InviteChannelMembers(ctx context.Context, channelID string, userIDs []string, invitedBy string) ([]string, error) {
+	log := logger.GetLogInstance(ctx, "InviteChannelMembers")
+	var invited []string
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var notifications []dbModel.Notification
+		for _, userID := range userIDs {
+			res := tx.Table(channelMembersTable).Clauses(clause.OnConflict{DoNothing: true}).Create(&dbModel.ChannelMember{
+				ChannelID: channelID,
+				UserID:    userID,
+				Status:    common.MEMBERSHIP_STATUS_INVITED,
+				InvitedBy: invitedBy,
+			})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				continue
+			}
+			invited = append(invited, userID)
+			notifications = append(notifications, dbModel.Notification{
+				UserID:    userID,
+				Type:      common.NOTIFICATION_CHANNEL_INVITE,
+				ChannelID: channelID,
+				ActorID:   invitedBy,
+				Message:   "You were invited to a channel",
+			})
+		}
+		return insertNotifications(tx, notifications)
+	})
+	if err != nil {
+		log.Errorf("[InviteChannelMembers] unable to invite users to channel id: %s with error: %v", channelID, err)
+		return nil, err
+	}
+	return invited, nil
+}
+
+// auditChannelMember is the membership of a user kept in the audit log.
+type auditChannelMember struct {
+	Status string `json:"status,omitempty"`
+}
+
+// RemoveChannelMember ends a membership or invitation. Removals by someone
+// other than the member are audited; actor is nil when members leave.
+func (r *repo) RemoveChannelMember(ctx context.Context, channelID string, userID string, actor *model.AuditActor) error {
+	log := logger.GetLogInstance(ctx, "RemoveChannelMember")
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var members []dbModel.ChannelMember
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Table(channelMembersTable).
+			Where("channel_id = ? AND user_id = ?", channelID, userID).Find(&members).Error; err != nil {
+			return err
+		}
+		if len(members) == 0 {
+			return nil
+		}
+		if err := tx.Table(channelMembersTable).Where("id = ?", members[0].ID).Delete(&dbModel.ChannelMember{}).Error; err != nil {
+			return err
+		}
+		if actor == nil {
+			return nil
+		}
+		return addAuditLog(tx, *actor, dbModel.AuditLog{
+			Action:     common.AUDIT_ACTION_MEMBER_REMOVE,
+			TargetType: common.AUDIT_TARGET_USER,
+			TargetID:   userID,
+			ChannelID:  channelID,
+		}, auditChannelMember{Status: members[0].Status}, auditChannelMember{})
+	})
+	if err != nil {
+		log.Errorf("[RemoveChannelMember] unable to remove user id: %s from channel id: %s with error: %v", userID, channelID, err)
+		return err
+	}
+	return nil
+}
+
+// GetRestrictedChannels returns which of channelIDs the user can't read.
+func (r *repo) GetRestrictedChannels(ctx context.Context, userID string, channelIDs []string) (map[string]bool, error) {
+	log := logger.GetLogInstance(ctx, "GetRestrictedChannels")
+	restricted := make(map[string]bool)
+	if len(channelIDs) == 0 {
+		return restricted, nil
+	}
+	var restrictedIDs []string
+	db := r.db.MasterDB.WithContext(ctx).Raw(restrictedChannelsQuery+" AND c.channel_id IN ?", userID, userID, channelIDs).Scan(&restrictedIDs)
+	if db.Error != nil {
+		log.Errorf("[GetRestrictedChannels] unable to check channels of user id: %s with error: %v", userID, db.Error)
+		return restricted, fmt.Errorf("getRestrictedChannels query failed: %w", db.Error)
+	}
+	for _, id := range restrictedIDs {
+		restricted[id] = true
+	}
+	return restricted, nil
+}
@@ -0,0 +1,294 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"gorm.io/gorm"
+)
+
+const (
+	pinnedSortKey   = "CASE WHEN %sis_pinned = true THEN 0 ELSE 1 END"
+	deletedSortKey  = "CASE WHEN %sstatus = 'DELETED' THEN 1 ELSE 0 END"
+	acceptedSortKey = "CASE WHEN %sis_accepted_answer = true THEN 0 ELSE 1 END"
+)
+
+// sortKey is one column of a keyset ordering together with the value taken
+// from the cursor.
+type sortKey struct {
+	expr  string
+	desc  bool
+	value interface{}
+}
+
+// keysetOrder renders the ORDER BY list for keys.
+func keysetOrder(keys []sortKey) string {
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		direction := "ASC"
+		if key.desc {
+			direction = "DESC"
+		}
+		parts = append(parts, key.expr+" "+direction)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// keysetCondition renders the predicate selecting rows that sort strictly
+// after the cursor, e.g. (a > ? OR (a = ? AND b < ?)) for a ASC, b DESC.
+func keysetCondition(keys []sortKey) (string, []interface{}) {
+	var (
+		clauses []string
+		params  []interface{}
+	)
+	for i, key := range keys {
+		var parts []string
+		for _, prev := range keys[:i] {
+			parts = append(parts, prev.expr+" = ?")
+			params = append(params, prev.value)
+		}
+		operator := " > ?"
+		if key.desc {
+			operator = " < ?"
+		}
+		parts = append(parts, key.expr+operator)
+		params = append(params, key.value)
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")", params
+}
+
+func boolSortValue(value bool, whenTrue int) int {
+	if value {
+		return whenTrue
+	}
+	return 1 - whenTrue
+}
+
+// feedSortKeys returns the ordering of a feed for the given flow and sort
+// order. Pinned posts lead in the ideas based flow and deleted posts trail in
+// every order; id is the final tie breaker so every row has a unique position.
+func feedSortKeys(prefix string, sortBy string, order string, phase string, cursor *model.FeedCursor) []sortKey {
+	if cursor == nil {
+		cursor = &model.FeedCursor{}
+	}
+	var keys []sortKey
+	if sortBy == common.IDEAS_BASED_FLOW {
+		keys = append(keys, sortKey{expr: fmt.Sprintf(pinnedSortKey, prefix), value: boolSortValue(cursor.Pinned, 0)})
+	}
+	keys = append(keys, sortKey{expr: fmt.Sprintf(deletedSortKey, prefix), value: boolSortValue(cursor.Deleted, 1)})
+	switch order {
+	case common.SORT_NEWEST:
+		keys = append(keys, sortKey{expr: prefix + "created_at", desc: true, value: cursor.CreatedAt})
+	case common.SORT_TOP:
+		keys = append(keys,
+			sortKey{expr: prefix + "like_count", desc: true, value: cursor.LikeCount},
+			sortKey{expr: prefix + "created_at", desc: true, value: cursor.CreatedAt},
+		)
+	case common.SORT_HOT:
+		keys = append(keys, sortKey{expr: prefix + "hot_score", desc: true, value: cursor.Score})
+	case common.SORT_CONTROVERSIAL:
+		keys = append(keys, sortKey{expr: prefix + "controversy_score", desc: true, value: cursor.Score})
+	case common.SORT_SCORE:
+		keys = append(keys,
+			sortKey{expr: prefix + "vote_score", desc: true, value: cursor.VoteScore},
+			sortKey{expr: prefix + "created_at", desc: true, value: cursor.CreatedAt},
+		)
+	default:
+		if sortBy != common.IDEAS_BASED_FLOW && phase == model.FeedPhaseOthers {
+			keys = append(keys, sortKey{expr: prefix + "like_count", desc: true, value: cursor.LikeCount})
+		}
+		keys = append(keys, sortKey{expr: prefix + "updated_at", desc: true, value: cursor.UpdatedAt})
+	}
+	keys = append(keys, sortKey{expr: prefix + "id", desc: true, value: cursor.ID})
+	return keys
+}
+
+// replySortKeys returns the ordering of the replies to a post: the accepted
+// answer of a question comes first, the rest follow the feed ordering.
+func replySortKeys(prefix string, sortBy string, cursor *model.FeedCursor) []sortKey {
+	accepted := sortKey{expr: fmt.Sprintf(acceptedSortKey, prefix)}
+	if cursor != nil {
+		accepted.value = boolSortValue(cursor.Accepted, 0)
+	}
+	return append([]sortKey{accepted}, feedSortKeys(prefix, sortBy, "", "", cursor)...)
+}
+
+// feedFilter renders the conditions of feedSort that narrow a feed down, to be
+// appended to the WHERE clause of a posts query.
+func feedFilter(feedSort model.FeedSort) (string, []interface{}) {
+	var (
+		filter string
+		params []interface{}
+	)
+	if !feedSort.Since.IsZero() {
+		filter += " AND created_at >= ?"
+		params = append(params, feedSort.Since)
+	}
+	if feedSort.IdeaStatus != "" {
+		filter += " AND idea_status = ?"
+		params = append(params, feedSort.IdeaStatus)
+	}
+	if feedSort.Answered != nil {
+		if *feedSort.Answered {
+			filter += " AND accepted_answer_id <> 0"
+		} else {
+			filter += " AND accepted_answer_id = 0"
+		}
+	}
+	if feedSort.Tag != "" {
+		filter += " AND id IN (SELECT post_id FROM " + postTagsTable + " WHERE tag = ?)"
+		params = append(params, feedSort.Tag)
+	}
+	if feedSort.Viewer.UserID != "" {
+		filter += " AND " + hiddenAuthorsCondition("user_id")
+		params = append(params, hiddenAuthorsParams(feedSort.Viewer)...)
+	} else {
+		filter += " AND " + shadowBannedAuthorsCondition("user_id")
+		params = append(params, shadowBannedAuthorsParams(feedSort.Viewer)...)
+	}
+	return filter, params
+}
+
+// GetEventPostsAfterCursor returns the next comments of a channel after cursor.
+// In the user based flow phase selects between the viewer's own posts and
+// everyone else's; in the ideas based flow it is ignored.
+func (r *repo) GetEventPostsAfterCursor(ctx context.Context, channelID string, userId string, limit int, sortBy string, feedSort model.FeedSort, phase string, cursor *model.FeedCursor) ([]common.Post, error) {
+	log := logger.GetLogInstance(ctx, "GetEventPostsAfterCursor")
+	var posts []common.Post
+
+	whereClause := "channel_id = ? AND " + feedTypesCondition
+	params := []interface{}{channelID}
+	if sortBy != common.IDEAS_BASED_FLOW {
+		if phase == model.FeedPhaseOwn {
+			whereClause += " AND user_id = ?"
+		} else {
+			whereClause += " AND user_id != ?"
+		}
+		params = append(params, userId)
+	}
+	filter, filterParams := feedFilter(feedSort)
+	whereClause += filter
+	params = append(params, filterParams...)
+
+	keys := feedSortKeys("", sortBy, feedSort.Order, phase, cursor)
+	if cursor != nil {
+		condition, conditionParams := keysetCondition(keys)
+		whereClause += " AND " + condition
+		params = append(params, conditionParams...)
+	}
+	params = append(params, limit)
+
+	result := r.db.MasterDB.WithContext(ctx).Raw(`
+	SELECT
+		id,
+		channel_id,
+		user_id,
+		content,
+		type,
+		is_pinned,
+		parent_id,
+		like_count,
+		bookmark_count,
+		reply_count,
+		report_count,
+		hot_score,
+		controversy_score,
+		idea_status,
+		official_response,
+		merged_into_id,
+		vote_score,
+		upvote_count,
+		downvote_count,
+		accepted_answer_id,
+		quoted_post_id,
+		quote_count,
+		status,
+		created_at,
+		updated_at,
+		deleted_at
+		FROM posts
+		WHERE `+whereClause+`
+		ORDER BY `+keysetOrder(keys)+`
+		LIMIT ?
+	`, params...).Find(&posts)
+	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		log.Errorf("[GetEventPostsAfterCursor] unable to fetch posts for channel id: %s with error: %v", channelID, result.Error)
+		return nil, result.Error
+	}
+	return posts, nil
+}
+
+// GetBookMarkedPostsAfterCursor pages through a user's bookmarks in the order
+// they were bookmarked.
+func (r *repo) GetBookMarkedPostsAfterCursor(ctx context.Context, channelID string, userId string, limit int, sortBy string, cursor *model.FeedCursor) ([]common.Post, error) {
+	log := logger.GetLogInstance(ctx, "GetBookMarkedPostsAfterCursor")
+	var posts []common.Post
+
+	keys := []sortKey{{expr: "ua.updated_at", desc: true}, {expr: "ua.post_id", desc: true}}
+	whereClause := "ua.action = 'bookmark' AND ua.user_id = ? AND p.channel_id = ? AND ua.value = true AND " + hiddenAuthorsCondition("p.user_id")
+	params := append([]interface{}{userId, channelID}, hiddenAuthorsParams(model.Viewer{UserID: userId})...)
+	if cursor != nil {
+		keys[0].value, keys[1].value = cursor.UpdatedAt, cursor.ID
+		condition, conditionParams := keysetCondition(keys)
+		whereClause += " AND " + condition
+		params = append(params, conditionParams...)
+	}
+	params = append(params, limit)
+
+	result := r.db.MasterDB.WithContext(ctx).Raw(`
+	SELECT
+		ua.post_id AS id,
+		p.channel_id,
+		ua.user_id,
+		p.content,
+		p.type,
+		p.is_pinned,
+		p.parent_id,
+		p.like_count,
+		p.bookmark_count,
+		p.status,
+		ua.created_at,
+		ua.updated_at,
+		p.deleted_at
+		FROM posts p
+		JOIN user_actions ua ON p.id = ua.post_id
+		WHERE `+whereClause+`
+		ORDER BY `+keysetOrder(keys)+`
+		LIMIT ?
+	`, params...).Find(&posts)
+	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		log.Errorf("[GetBookMarkedPostsAfterCursor] unable to fetch bookmarks for channel id: %s with error: %v", channelID, result.Error)
+		return nil, result.Error
+	}
+	return posts, nil
+}
+
+// GetAllRepliesOnPostAfterCursor is the keyset counterpart of GetAllRepliesOnPost.
+func (r *repo) GetAllRepliesOnPostAfterCursor(ctx context.Context, postId string, limit int, sortBy string, viewer model.Viewer, cursor *model.FeedCursor) ([]model.ReplyPost, error) {
+	log := logger.GetLogInstance(ctx, "GetAllRepliesOnPostAfterCursor-repo")
+	var results []model.ReplyPost
+
+	keys := replySortKeys("p.", sortBy, cursor)
+	query := r.db.MasterDB.WithContext(ctx).
+		Table("posts as p").
+		Select("p.id as id, p.content as content, p.type as type, p.like_count as like_count, p.status as status, p.is_pinned as is_pinned, p.is_accepted_answer as is_accepted_answer, p.quoted_post_id as quoted_post_id, p.quote_count as quote_count, p.parent_id as parent_id, p.depth as depth, p.reply_count as reply_count, p.created_at as created_at, p.updated_at as updated_at, u.first_name as first_name, u.middle_name as middle_name, u.last_name as last_name, u.profile_image_url as profile_image_url, p.user_id as user_id, u.user_phone").
+		Joins("left join user_details u on p.user_id = u.user_id").
+		Where("p.parent_id = ?", postId).
+		Where(hiddenAuthorsCondition("p.user_id"), hiddenAuthorsParams(viewer)...)
+	if cursor != nil {
+		condition, conditionParams := keysetCondition(keys)
+		query = query.Where(condition, conditionParams...)
+	}
+	db := query.Order(keysetOrder(keys)).Limit(limit).Scan(&results)
+	if db.Error != nil {
+		log.Errorf("error while executing GetAllRepliesOnPostAfterCursor query: %+v", db.Error.Error())
+		return nil, fmt.Errorf("GetAllRepliesOnPostAfterCursor query failed: %w", db.Error)
+	}
+	return results, nil
+}
@@ -0,0 +1,160 @@
+package repo
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+)
+
+func TestKeysetCondition(t *testing.T) {
+	tests := []struct {
+		name       string
+		keys       []sortKey
+		wantQuery  string
+		wantParams []interface{}
+	}{
+		{
+			name:       "single ascending key",
+			keys:       []sortKey{{expr: "a", value: 1}},
+			wantQuery:  "((a > ?))",
+			wantParams: []interface{}{1},
+		},
+		{
+			name:       "single descending key",
+			keys:       []sortKey{{expr: "a", desc: true, value: 1}},
+			wantQuery:  "((a < ?))",
+			wantParams: []interface{}{1},
+		},
+		{
+			name:       "mixed directions",
+			keys:       []sortKey{{expr: "a", value: 1}, {expr: "b", desc: true, value: 2}},
+			wantQuery:  "((a > ?) OR (a = ? AND b < ?))",
+			wantParams: []interface{}{1, 1, 2},
+		},
+		{
+			name: "three keys",
+			keys: []sortKey{
+				{expr: "a", desc: true, value: 1},
+				{expr: "b", desc: true, value: 2},
+				{expr: "id", desc: true, value: 3},
+			},
+			wantQuery:  "((a < ?) OR (a = ? AND b < ?) OR (a = ? AND b = ? AND id < ?))",
+			wantParams: []interface{}{1, 1, 2, 1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, params := keysetCondition(tt.keys)
+			if query != tt.wantQuery {
+				t.Errorf("keysetCondition() query = %q, want %q", query, tt.wantQuery)
+			}
+			if !reflect.DeepEqual(params, tt.wantParams) {
+				t.Errorf("keysetCondition() params = %v, want %v", params, tt.wantParams)
+			}
+		})
+	}
+}
+
+func TestFeedSortKeysEndWithID(t *testing.T) {
+	cursor := &model.FeedCursor{ID: 7, CreatedAt: time.Unix(100, 0)}
+	for _, order := range []string{"", common.SORT_NEWEST, common.SORT_TOP, common.SORT_HOT, common.SORT_CONTROVERSIAL, common.SORT_SCORE} {
+		keys := feedSortKeys("p.", "", order, model.FeedPhaseOthers, cursor)
+		last := keys[len(keys)-1]
+		if last.expr != "p.id" || !last.desc || last.value != int64(7) {
+			t.Errorf("feedSortKeys(order %q) last key = %+v, want p.id DESC with the cursor id", order, last)
+		}
+	}
+}
+
+func TestKeysetOrder(t *testing.T) {
+	got := keysetOrder([]sortKey{{expr: "a"}, {expr: "b", desc: true}})
+	if want := "a ASC, b DESC"; got != want {
+		t.Errorf("keysetOrder() = %q, want %q", got, want)
+	}
+}
+
+func TestReplySortKeysPutAcceptedAnswerFirst(t *testing.T) {
+	acceptedExpr := "CASE WHEN p.is_accepted_answer = true THEN 0 ELSE 1 END"
+	tests := []struct {
+		name      string
+		cursor    *model.FeedCursor
+		wantValue interface{}
+	}{
+		{name: "without cursor"},
+		{name: "after the accepted answer", cursor: &model.FeedCursor{ID: 7, Accepted: true}, wantValue: 0},
+		{name: "after another reply", cursor: &model.FeedCursor{ID: 7}, wantValue: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := replySortKeys("p.", "", tt.cursor)
+			first := keys[0]
+			if first.expr != acceptedExpr || first.desc || first.value != tt.wantValue {
+				t.Errorf("replySortKeys() first key = %+v, want %s ASC with value %v", first, acceptedExpr, tt.wantValue)
+			}
+			if order := keysetOrder(keys); !strings.HasPrefix(order, acceptedExpr+" ASC, ") {
+				t.Errorf("replySortKeys() order = %q, want the accepted answer first", order)
+			}
+			if last := keys[len(keys)-1]; last.expr != "p.id" || !last.desc {
+				t.Errorf("replySortKeys() last key = %+v, want p.id DESC", last)
+			}
+		})
+	}
+}
+
+func TestFeedFilterAnswered(t *testing.T) {
+	answered, unanswered := true, false
+	tests := []struct {
+		name       string
+		answered   *bool
+		want       string
+		wantAbsent []string
+	}{
+		{name: "answered", answered: &answered, want: " AND accepted_answer_id <> 0"},
+		{name: "unanswered", answered: &unanswered, want: " AND accepted_answer_id = 0"},
+		{name: "any", wantAbsent: []string{"accepted_answer_id"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, _ := feedFilter(model.FeedSort{Answered: tt.answered})
+			if !strings.Contains(filter, tt.want) {
+				t.Errorf("feedFilter() = %q, want it to contain %q", filter, tt.want)
+			}
+			for _, absent := range tt.wantAbsent {
+				if strings.Contains(filter, absent) {
+					t.Errorf("feedFilter() = %q, want it without %q", filter, absent)
+				}
+			}
+		})
+	}
+}
+
+func TestFeedFilterHidesShadowBannedAuthors(t *testing.T) {
+	tests := []struct {
+		name        string
+		viewer      model.Viewer
+		wantBlocked bool
+		wantParams  []interface{}
+	}{
+		{name: "without viewer", wantParams: []interface{}{false, ""}},
+		{name: "with viewer", viewer: model.Viewer{UserID: "u1"}, wantBlocked: true, wantParams: []interface{}{"u1", false, "u1"}},
+		{name: "moderator view", viewer: model.Viewer{UserID: "u1", ModeratorView: true}, wantBlocked: true, wantParams: []interface{}{"u1", true, "u1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, params := feedFilter(model.FeedSort{Viewer: tt.viewer})
+			if !strings.Contains(filter, shadowBannedUsersQuery) {
+				t.Errorf("feedFilter() = %q, want it to leave out shadow banned authors", filter)
+			}
+			if blocked := strings.Contains(filter, userRelationsTable); blocked != tt.wantBlocked {
+				t.Errorf("feedFilter() = %q, leaves out blocked authors = %v, want %v", filter, blocked, tt.wantBlocked)
+			}
+			if !reflect.DeepEqual(params, tt.wantParams) {
+				t.Errorf("feedFilter() params = %v, want %v", params, tt.wantParams)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/events"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const postDeletionsTable = "post_deletions"
+
+// addPostDeletion keeps the content and status of post as they are before it
+// is deleted.
+func addPostDeletion(tx *gorm.DB, post dbModel.Post, actor model.AuditActor) error {
+	return tx.Table(postDeletionsTable).Create(&dbModel.PostDeletion{
+		PostID:        post.ID,
+		ChannelID:     post.ChannelID,
+		Content:       post.Content,
+		Status:        post.Status,
+		DeletedBy:     actor.UserID,
+		DeletedByRole: actor.Role,
+	}).Error
+}
+
+// GetPostDeletion returns the latest delete of a post that hasn't been
+// restored yet.
+func (r *repo) GetPostDeletion(ctx context.Context, postID int64) (*dbModel.PostDeletion, error) {
+	log := logger.GetLogInstance(ctx, "GetPostDeletion")
+	var deletion dbModel.PostDeletion
+	err := r.db.MasterDB.WithContext(ctx).Table(postDeletionsTable).
+		Where("post_id = ? AND restored_at IS NULL", postID).Order("id desc").First(&deletion).Error
+	if err != nil {
+		log.Errorf("[GetPostDeletion] unable to fetch deletion of post id: %d with error: %v", postID, err)
+		return nil, err
+	}
+	return &deletion, nil
+}
+
+// GetPostDeletionRoles returns the audit role of whoever deleted each of the
+// given posts, for the ones whose latest delete hasn't been restored.
+func (r *repo) GetPostDeletionRoles(ctx context.Context, postIDs []int64) (map[int64]string, error) {
+	log := logger.GetLogInstance(ctx, "GetPostDeletionRoles")
+	roles := make(map[int64]string)
+	if len(postIDs) == 0 {
+		return roles, nil
+	}
+	var deletions []dbModel.PostDeletion
+	db := r.db.MasterDB.WithContext(ctx).Table(postDeletionsTable).
+		Select("post_id, deleted_by_role").
+		Where("post_id IN ? AND restored_at IS NULL", postIDs).
+		Order("id").
+		Find(&deletions)
+	if db.Error != nil {
+		log.Errorf("[GetPostDeletionRoles] unable to fetch deletions with error: %v", db.Error)
+		return nil, db.Error
+	}
+	for _, deletion := range deletions {
+		roles[deletion.PostID] = deletion.DeletedByRole
+	}
+	return roles, nil
+}
+
+// RestorePost undoes a delete: the post gets back its status, tags
+// and its place in the quote count of the post it quotes unless its author
+// is shadow banned. tags are the hashtags of the restored content.
+func (r *repo) RestorePost(ctx context.Context, deletionID int64, tags []string, actor model.AuditActor) (*dbModel.Post, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "RestorePost")
+	var (
+		post dbModel.Post
+		exp  *exceptions.Exception
+	)
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var deletion dbModel.PostDeletion
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Table(postDeletionsTable).Where("id = ?", deletionID).First(&deletion).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				exp = exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.NoDataFoundErrorCode, "Post can't be restored")
+			}
+			return err
+		}
+		if deletion.RestoredAt != nil {
+			exp = exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Post is already restored")
+			return exp
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Table(postsTable).Where("id = ?", deletion.PostID).First(&post).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				exp = exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode)
+			}
+			return err
+		}
+		if post.Status != common.POST_STATUS_DELETED {
+			exp = exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Post is not deleted")
+			return exp
+		}
+		before := newAuditPost(post)
+
+		now := time.Now()
+		res := tx.Table(postsTable).Where("id = ?", post.ID).Updates(map[string]interface{}{
+			"status":     deletion.Status,
+			"deleted_at": nil,
+			"updated_at": now,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		post.Status = deletion.Status
+		post.DeletedAt = time.Time{}
+		post.UpdatedAt = now
+		if err := replacePostTags(tx, post, countedTags(post.Status, tags)); err != nil {
+			return err
+		}
+		if post.QuotedPostID != 0 {
+			shadowBanned, err := isShadowBanned(tx, post.UserID)
+			if err != nil {
+				return err
+			}
+			if !shadowBanned {
+				if err := incrementPostCounter(tx, post.QuotedPostID, "quote_count"); err != nil {
+					return err
+				}
+			}
+		}
+
+		res = tx.Table(postDeletionsTable).Where("id = ?", deletion.ID).Updates(map[string]interface{}{
+			"restored_by": actor.UserID,
+			"restored_at": now,
+			"updated_at":  now,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		err := addAuditLog(tx, actor, dbModel.AuditLog{
+			Action:     common.AUDIT_ACTION_POST_RESTORE,
+			TargetType: common.AUDIT_TARGET_POST,
+			TargetID:   strconv.FormatInt(post.ID, 10),
+			ChannelID:  post.ChannelID,
+		}, before, newAuditPost(post))
+		if err != nil {
+			return err
+		}
+		return addOutboxEvent(tx, events.PostRestored, events.AggregatePost, post.ID, postEventPayload(post))
+	})
+	if exp != nil {
+		return nil, exp
+	}
+	if err != nil {
+		log.Errorf("[RestorePost] unable to restore deletion id: %d with error: %v", deletionID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return &post, nil
+}
@@ -0,0 +1,44 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/internal/events"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UpdatePostContent replaces the content of a post together with the
+// mentions and hashtags parsed from it.
+func (r *repo) UpdatePostContent(ctx context.Context, postID int64, content string, mentions []dbModel.PostMention, tags []string) (*dbModel.Post, error) {
+	log := logger.GetLogInstance(ctx, "UpdatePostContent")
+	var post dbModel.Post
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Table(postsTable).Where("id = ?", postID).First(&post).Error; err != nil {
+			return err
+		}
+		post.Content = content
+		post.UpdatedAt = time.Now()
+		if err := tx.Table(postsTable).Where("id = ?", postID).Updates(map[string]interface{}{
+			"content":    post.Content,
+			"updated_at": post.UpdatedAt,
+		}).Error; err != nil {
+			return err
+		}
+		if err := replacePostMentions(tx, post, mentions); err != nil {
+			return err
+		}
+		if err := replacePostTags(tx, post, countedTags(post.Status, tags)); err != nil {
+			return err
+		}
+		return addOutboxEvent(tx, events.PostEdited, events.AggregatePost, post.ID, postEventPayload(post))
+	})
+	if err != nil {
+		log.Errorf("[UpdatePostContent] unable to update post id: %d with error: %v", postID, err)
+		return nil, err
+	}
+	return &post, nil
+}
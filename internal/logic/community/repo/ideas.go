@@ -0,0 +1,111 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/events"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UpdateIdeaStatus sets the status and official response of an idea. The
+// idea's author and everyone who currently likes it, apart from the moderator
+// making the change, are notified and the change is audited in the same
+// transaction.
+func (r *repo) UpdateIdeaStatus(ctx context.Context, postID int64, status string, officialResponse string, actor model.AuditActor) (*dbModel.Post, error) {
+	log := logger.GetLogInstance(ctx, "UpdateIdeaStatus")
+	moderatorID := actor.UserID
+	var post dbModel.Post
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Table(postsTable).Where("id = ?", postID).First(&post).Error; err != nil {
+			return err
+		}
+		previousStatus := post.IdeaStatus
+		before := newAuditIdea(post)
+		now := time.Now()
+		res := tx.Table(postsTable).Where("id = ?", postID).Updates(map[string]interface{}{
+			"idea_status":            status,
+			"official_response":      officialResponse,
+			"official_response_by":   moderatorID,
+			"idea_status_updated_at": now,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		post.IdeaStatus = status
+		post.OfficialResponse = officialResponse
+		post.OfficialResponseBy = moderatorID
+		post.IdeaStatusUpdatedAt = &now
+
+		var likers []string
+		if err := tx.Table(userActionsTable).Where("post_id = ? AND action = ? AND value = true", postID, like).Pluck("user_id", &likers).Error; err != nil {
+			return err
+		}
+		message := fmt.Sprintf("Idea status changed to %s", humanizeIdeaStatus(status))
+		if previousStatus != "" && previousStatus != status {
+			message = fmt.Sprintf("Idea status changed from %s to %s", humanizeIdeaStatus(previousStatus), humanizeIdeaStatus(status))
+		}
+		var notifications []dbModel.Notification
+		notified := map[string]bool{moderatorID: true}
+		for _, userID := range append([]string{post.UserID}, likers...) {
+			if notified[userID] {
+				continue
+			}
+			notified[userID] = true
+			notifications = append(notifications, dbModel.Notification{
+				UserID:    userID,
+				Type:      common.NOTIFICATION_IDEA_STATUS_CHANGED,
+				ChannelID: post.ChannelID,
+				PostID:    post.ID,
+				ActorID:   moderatorID,
+				Message:   message,
+			})
+		}
+		if err := insertNotifications(tx, notifications); err != nil {
+			return err
+		}
+		err := addAuditLog(tx, actor, dbModel.AuditLog{
+			Action:     common.AUDIT_ACTION_IDEA_STATUS,
+			TargetType: common.AUDIT_TARGET_POST,
+			TargetID:   strconv.FormatInt(post.ID, 10),
+			ChannelID:  post.ChannelID,
+		}, before, newAuditIdea(post))
+		if err != nil {
+			return err
+		}
+		return addOutboxEvent(tx, events.IdeaStatusSet, events.AggregatePost, post.ID, events.IdeaStatusPayload{
+			PostID:           post.ID,
+			PreviousStatus:   previousStatus,
+			Status:           status,
+			OfficialResponse: officialResponse,
+			ModeratorID:      moderatorID,
+		})
+	})
+	if err != nil {
+		log.Errorf("[UpdateIdeaStatus] unable to update status of idea id: %d with error: %v", postID, err)
+		return nil, err
+	}
+	return &post, nil
+}
+
+// auditIdea is the status of an idea kept in the audit log.
+type auditIdea struct {
+	IdeaStatus       string `json:"idea_status"`
+	OfficialResponse string `json:"official_response"`
+}
+
+func newAuditIdea(post dbModel.Post) auditIdea {
+	return auditIdea{IdeaStatus: post.IdeaStatus, OfficialResponse: post.OfficialResponse}
+}
+
+func humanizeIdeaStatus(status string) string {
+	return strings.ToLower(strings.ReplaceAll(status, "_", " "))
+}
@@ -0,0 +1,126 @@
+package repo
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+)
+
+const postMentionsTable = "post_mentions"
+
+// GetUsersByUserNames resolves user names case-insensitively.
+func (r *repo) GetUsersByUserNames(ctx context.Context, userNames []string) ([]dbModel.UserDetails, error) {
+	log := logger.GetLogInstance(ctx, "GetUsersByUserNames")
+	var users []dbModel.UserDetails
+	if len(userNames) == 0 {
+		return users, nil
+	}
+	lowered := make([]string, 0, len(userNames))
+	for _, userName := range userNames {
+		lowered = append(lowered, strings.ToLower(userName))
+	}
+	db := r.db.MasterDB.WithContext(ctx).Table(userDetailsTable).Where("LOWER(user_name) IN ?", lowered).Find(&users)
+	if db.Error != nil {
+		log.Errorf("[GetUsersByUserNames] unable to fetch users with error: %v", db.Error)
+		return nil, db.Error
+	}
+	return users, nil
+}
+
+// SavePostMentions stores the mentions of a newly created post and notifies
+// the mentioned users.
+func (r *repo) SavePostMentions(ctx context.Context, post dbModel.Post, mentions []dbModel.PostMention) error {
+	log := logger.GetLogInstance(ctx, "SavePostMentions")
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replacePostMentions(tx, post, mentions)
+	})
+	if err != nil {
+		log.Errorf("[SavePostMentions] unable to save mentions of post id: %d with error: %v", post.ID, err)
+		return err
+	}
+	return nil
+}
+
+// replacePostMentions swaps the stored mentions of a post for the given ones.
+// Only users who weren't mentioned before are notified, so editing a post
+// doesn't notify the same people twice, and authors never notify themselves.
+func replacePostMentions(tx *gorm.DB, post dbModel.Post, mentions []dbModel.PostMention) error {
+	var previous []string
+	if err := tx.Table(postMentionsTable).Where("post_id = ?", post.ID).Pluck("user_id", &previous).Error; err != nil {
+		return err
+	}
+	if err := tx.Table(postMentionsTable).Where("post_id = ?", post.ID).Delete(&dbModel.PostMention{}).Error; err != nil {
+		return err
+	}
+	if len(mentions) == 0 {
+		return nil
+	}
+	for i := range mentions {
+		mentions[i].ID = 0
+		mentions[i].PostID = post.ID
+	}
+	if err := tx.Table(postMentionsTable).Create(&mentions).Error; err != nil {
+		return err
+	}
+
+	notified := make(map[string]bool)
+	for _, userID := range previous {
+		notified[userID] = true
+	}
+	notified[post.UserID] = true
+	var notifications []dbModel.Notification
+	for _, mention := range mentions {
+		if notified[mention.UserID] {
+			continue
+		}
+		notified[mention.UserID] = true
+		notifications = append(notifications, dbModel.Notification{
+			UserID:    mention.UserID,
+			Type:      common.NOTIFICATION_MENTIONED,
+			ChannelID: post.ChannelID,
+			PostID:    post.ID,
+			ActorID:   post.UserID,
+			Message:   "You were mentioned in a post",
+		})
+	}
+	return insertNotifications(tx, notifications)
+}
+
+// GetPostMentions returns the mentions of the given posts in content order.
+func (r *repo) GetPostMentions(ctx context.Context, postIDs []int64) ([]dbModel.PostMention, error) {
+	log := logger.GetLogInstance(ctx, "GetPostMentions")
+	var mentions []dbModel.PostMention
+	if len(postIDs) == 0 {
+		return mentions, nil
+	}
+	db := r.db.MasterDB.WithContext(ctx).Table(postMentionsTable).Where("post_id IN ?", postIDs).Order("post_id, start_offset").Find(&mentions)
+	if db.Error != nil {
+		log.Errorf("[GetPostMentions] unable to fetch mentions with error: %v", db.Error)
+		return nil, db.Error
+	}
+	return mentions, nil
+}
+
+// SearchChannelUsers returns users who have posted in the channel whose user
+// name, first or last name starts with prefix.
+func (r *repo) SearchChannelUsers(ctx context.Context, channelID string, prefix string, limit int) ([]dbModel.UserDetails, error) {
+	log := logger.GetLogInstance(ctx, "SearchChannelUsers")
+	var users []dbModel.UserDetails
+	pattern := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(strings.ToLower(prefix)) + "%"
+	db := r.db.MasterDB.WithContext(ctx).Table(userDetailsTable+" AS ud").
+		Select("ud.*").
+		Where("EXISTS (SELECT 1 FROM "+postsTable+" p WHERE p.channel_id = ? AND p.user_id = ud.user_id AND p.status <> ?)", channelID, common.POST_STATUS_DELETED).
+		Where("(LOWER(ud.user_name) LIKE ? OR LOWER(ud.first_name) LIKE ? OR LOWER(ud.last_name) LIKE ?)", pattern, pattern, pattern).
+		Order("ud.user_name").
+		Limit(limit).
+		Find(&users)
+	if db.Error != nil {
+		log.Errorf("[SearchChannelUsers] unable to search users of channel id: %s with error: %v", channelID, db.Error)
+		return nil, db.Error
+	}
+	return users, nil
+}
@@ -0,0 +1,387 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/events"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	postMergesTable = "post_merges"
+
+	mergedStubContent = "This post was merged into another post."
+)
+
+// MergePosts merges the duplicate post sourceID into targetID. Likes on the
+// duplicate are moved to the canonical post unless the user already likes
+// it, its replies are re-parented and the duplicate is left behind as a stub
+// pointing at the canonical post without any hashtags. The returned record holds what was moved
+// so that RevertPostMerge can undo it.
+func (r *repo) MergePosts(ctx context.Context, sourceID int64, targetID int64, actor model.AuditActor) (*dbModel.PostMerge, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "MergePosts")
+	moderatorID := actor.UserID
+	var (
+		merge dbModel.PostMerge
+		exp   *exceptions.Exception
+	)
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		source, target, err := lockMergePosts(tx, sourceID, targetID)
+		if err != nil {
+			return err
+		}
+		if exp = validateMerge(source, target); exp != nil {
+			return exp
+		}
+
+		movedLikes, err := moveLikes(tx, sourceID, targetID)
+		if err != nil {
+			return err
+		}
+		var movedReplyIDs []int64
+		if err := tx.Table(postsTable).Where("parent_id = ?", sourceID).Order("id").Pluck("id", &movedReplyIDs).Error; err != nil {
+			return err
+		}
+		if len(movedReplyIDs) > 0 {
+			if err := tx.Table(postsTable).Where("id IN ?", movedReplyIDs).Update("parent_id", targetID).Error; err != nil {
+				return err
+			}
+			if err := setSubtreeRoot(tx, movedReplyIDs, targetID); err != nil {
+				return err
+			}
+		}
+
+		res := tx.Table(postsTable).Where("id = ?", sourceID).Updates(map[string]interface{}{
+			"content":        mergedStubContent,
+			"status":         common.POST_STATUS_MERGED,
+			"merged_into_id": targetID,
+			"updated_at":     time.Now(),
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if err := replacePostTags(tx, *source, nil); err != nil {
+			return err
+		}
+		if err := recountPosts(tx, sourceID, targetID); err != nil {
+			return err
+		}
+
+		likesData, err := json.Marshal(movedLikes)
+		if err != nil {
+			return err
+		}
+		repliesData, err := json.Marshal(movedReplyIDs)
+		if err != nil {
+			return err
+		}
+		merge = dbModel.PostMerge{
+			SourcePostID:  sourceID,
+			TargetPostID:  targetID,
+			MergedBy:      moderatorID,
+			SourceContent: source.Content,
+			SourceStatus:  source.Status,
+			MovedLikes:    string(likesData),
+			MovedReplyIDs: string(repliesData),
+		}
+		if err := tx.Table(postMergesTable).Create(&merge).Error; err != nil {
+			return err
+		}
+		if err := addMergeAuditLog(tx, actor, common.AUDIT_ACTION_POST_MERGE, merge, newAuditPost(*source)); err != nil {
+			return err
+		}
+		return addOutboxEvent(tx, events.PostMerged, events.AggregatePost, sourceID, events.MergePayload{
+			MergeID:      merge.ID,
+			SourcePostID: sourceID,
+			TargetPostID: targetID,
+			ModeratorID:  moderatorID,
+		})
+	})
+	if exp != nil {
+		return nil, exp
+	}
+	if err != nil {
+		log.Errorf("[MergePosts] unable to merge post id: %d into post id: %d with error: %v", sourceID, targetID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return &merge, nil
+}
+
+// RevertPostMerge undoes a merge: the duplicate gets its content, hashtags,
+// likes and replies back and the likes added to the canonical post are taken away.
+func (r *repo) RevertPostMerge(ctx context.Context, mergeID int64, actor model.AuditActor) (*dbModel.PostMerge, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "RevertPostMerge")
+	moderatorID := actor.UserID
+	var (
+		merge dbModel.PostMerge
+		exp   *exceptions.Exception
+	)
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Table(postMergesTable).Where("id = ?", mergeID).First(&merge).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				exp = exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.NoDataFoundErrorCode, "Merge not found")
+			}
+			return err
+		}
+		if merge.RevertedAt != nil {
+			exp = exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Merge is already reverted")
+			return exp
+		}
+		source, _, err := lockMergePosts(tx, merge.SourcePostID, merge.TargetPostID)
+		if err != nil {
+			return err
+		}
+		if source == nil {
+			exp = exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode)
+			return exp
+		}
+
+		var (
+			movedLikes    []model.MovedLike
+			movedReplyIDs []int64
+		)
+		if err := json.Unmarshal([]byte(merge.MovedLikes), &movedLikes); err != nil {
+			return err
+		}
+		if err := json.Unmarshal([]byte(merge.MovedReplyIDs), &movedReplyIDs); err != nil {
+			return err
+		}
+		if err := restoreLikes(tx, merge.SourcePostID, merge.TargetPostID, movedLikes); err != nil {
+			return err
+		}
+		if len(movedReplyIDs) > 0 {
+			res := tx.Table(postsTable).Where("id IN ? AND parent_id = ?", movedReplyIDs, merge.TargetPostID).Update("parent_id", merge.SourcePostID)
+			if res.Error != nil {
+				return res.Error
+			}
+			if err := setSubtreeRoot(tx, movedReplyIDs, merge.SourcePostID); err != nil {
+				return err
+			}
+		}
+
+		res := tx.Table(postsTable).Where("id = ?", merge.SourcePostID).Updates(map[string]interface{}{
+			"content":        merge.SourceContent,
+			"status":         merge.SourceStatus,
+			"merged_into_id": 0,
+			"updated_at":     time.Now(),
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		restored := *source
+		restored.Content = merge.SourceContent
+		restored.Status = merge.SourceStatus
+		if err := restorePostTags(tx, restored); err != nil {
+			return err
+		}
+		if err := recountPosts(tx, merge.SourcePostID, merge.TargetPostID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		merge.RevertedAt = &now
+		merge.RevertedBy = moderatorID
+		res = tx.Table(postMergesTable).Where("id = ?", merge.ID).Updates(map[string]interface{}{
+			"reverted_at": now,
+			"reverted_by": moderatorID,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if err := addMergeAuditLog(tx, actor, common.AUDIT_ACTION_POST_MERGE_REVERT, merge, newAuditPost(*source)); err != nil {
+			return err
+		}
+		return addOutboxEvent(tx, events.PostUnmerged, events.AggregatePost, merge.SourcePostID, events.MergePayload{
+			MergeID:      merge.ID,
+			SourcePostID: merge.SourcePostID,
+			TargetPostID: merge.TargetPostID,
+			ModeratorID:  moderatorID,
+		})
+	})
+	if exp != nil {
+		return nil, exp
+	}
+	if err != nil {
+		log.Errorf("[RevertPostMerge] unable to revert merge id: %d with error: %v", mergeID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return &merge, nil
+}
+
+func (r *repo) GetPostMerge(ctx context.Context, mergeID int64) (*dbModel.PostMerge, error) {
+	log := logger.GetLogInstance(ctx, "GetPostMerge")
+	var merge dbModel.PostMerge
+	if err := r.db.MasterDB.WithContext(ctx).Table(postMergesTable).Where("id = ?", mergeID).First(&merge).Error; err != nil {
+		log.Errorf("[GetPostMerge] unable to fetch merge id: %d with error: %v", mergeID, err)
+		return nil, err
+	}
+	return &merge, nil
+}
+
+// auditMergedPost is the state of a merged duplicate kept in the audit log.
+type auditMergedPost struct {
+	auditPost
+	MergeID      int64 `json:"merge_id"`
+	TargetPostID int64 `json:"target_post_id"`
+}
+
+// addMergeAuditLog records a merge or its revert with the duplicate's state
+// before it and as it is now.
+func addMergeAuditLog(tx *gorm.DB, actor model.AuditActor, action string, merge dbModel.PostMerge, before auditPost) error {
+	after, err := readAuditPost(tx, merge.SourcePostID)
+	if err != nil {
+		return err
+	}
+	return addAuditLog(tx, actor, dbModel.AuditLog{
+		Action:     action,
+		TargetType: common.AUDIT_TARGET_POST,
+		TargetID:   strconv.FormatInt(merge.SourcePostID, 10),
+		ChannelID:  after.ChannelID,
+	}, before, auditMergedPost{auditPost: after, MergeID: merge.ID, TargetPostID: merge.TargetPostID})
+}
+
+// lockMergePosts locks both posts of a merge, always in id order so that two
+// merges touching the same posts cannot deadlock.
+func lockMergePosts(tx *gorm.DB, sourceID int64, targetID int64) (*dbModel.Post, *dbModel.Post, error) {
+	var posts []dbModel.Post
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Table(postsTable).
+		Where("id IN ?", []int64{sourceID, targetID}).Order("id").Find(&posts).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	var source, target *dbModel.Post
+	for i := range posts {
+		switch posts[i].ID {
+		case sourceID:
+			source = &posts[i]
+		case targetID:
+			target = &posts[i]
+		}
+	}
+	return source, target, nil
+}
+
+func validateMerge(source *dbModel.Post, target *dbModel.Post) *exceptions.Exception {
+	switch {
+	case source == nil || target == nil:
+		return exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode)
+	case source.ChannelID != target.ChannelID:
+		return exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Posts belong to different channels")
+	case source.Type != common.POST_TYPE_COMMENT || target.Type != common.POST_TYPE_COMMENT:
+		return exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Only comments can be merged")
+	case source.Status == common.POST_STATUS_DELETED || target.Status == common.POST_STATUS_DELETED:
+		return exceptions.GetExceptionByErrorCode(exceptions.DeletedPostErrorCode)
+	case source.Status == common.POST_STATUS_MERGED || target.Status == common.POST_STATUS_MERGED:
+		return exceptions.GetExceptionByErrorCode(exceptions.MergedPostErrorCode)
+	}
+	return nil
+}
+
+// moveLikes turns the active likes on sourceID into likes on targetID. A user
+// liking both posts keeps a single like on the target.
+func moveLikes(tx *gorm.DB, sourceID int64, targetID int64) ([]model.MovedLike, error) {
+	var likes []dbModel.UserActions
+	if err := tx.Table(userActionsTable).Where("post_id = ? AND action = ? AND value = true", sourceID, like).Find(&likes).Error; err != nil {
+		return nil, err
+	}
+	active, inactive := true, false
+	movedLikes := make([]model.MovedLike, 0, len(likes))
+	for _, sourceLike := range likes {
+		var targetLikes []dbModel.UserActions
+		if err := tx.Table(userActionsTable).Where("post_id = ? AND user_id = ? AND action = ?", targetID, sourceLike.UserID, like).Find(&targetLikes).Error; err != nil {
+			return nil, err
+		}
+		movedLike := model.MovedLike{UserID: sourceLike.UserID, Target: movedLikeTarget(targetLikes)}
+		switch movedLike.Target {
+		case model.MovedLikeExisting:
+		case model.MovedLikeReactivated:
+			if err := tx.Table(userActionsTable).Where("id = ?", targetLikes[0].ID).Updates(&dbModel.UserActions{Value: &active, Counted: sourceLike.Counted}).Error; err != nil {
+				return nil, err
+			}
+		case model.MovedLikeCreated:
+			err := tx.Table(userActionsTable).Create(&dbModel.UserActions{
+				UserID:  sourceLike.UserID,
+				PostID:  targetID,
+				Action:  like,
+				Value:   &active,
+				Counted: sourceLike.Counted,
+			}).Error
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := tx.Table(userActionsTable).Where("id = ?", sourceLike.ID).Update("value", inactive).Error; err != nil {
+			return nil, err
+		}
+		movedLikes = append(movedLikes, movedLike)
+	}
+	return movedLikes, nil
+}
+
+// movedLikeTarget tells what moving a like does to the target post given the
+// user's like rows on it: an active like is kept, an inactive one is turned
+// back on and otherwise a new like is created.
+func movedLikeTarget(targetLikes []dbModel.UserActions) string {
+	switch {
+	case len(targetLikes) == 0:
+		return model.MovedLikeCreated
+	case targetLikes[0].Value != nil && *targetLikes[0].Value:
+		return model.MovedLikeExisting
+	}
+	return model.MovedLikeReactivated
+}
+
+// restoreLikes is the reverse of moveLikes.
+func restoreLikes(tx *gorm.DB, sourceID int64, targetID int64, movedLikes []model.MovedLike) error {
+	for _, movedLike := range movedLikes {
+		res := tx.Table(userActionsTable).Where("post_id = ? AND user_id = ? AND action = ?", sourceID, movedLike.UserID, like).Update("value", true)
+		if res.Error != nil {
+			return res.Error
+		}
+		targetLike := tx.Table(userActionsTable).Where("post_id = ? AND user_id = ? AND action = ?", targetID, movedLike.UserID, like)
+		switch movedLike.Target {
+		case model.MovedLikeReactivated:
+			res = targetLike.Update("value", false)
+		case model.MovedLikeCreated:
+			res = targetLike.Delete(&dbModel.UserActions{})
+		default:
+			continue
+		}
+		if res.Error != nil {
+			return res.Error
+		}
+	}
+	return nil
+}
+
+// recountPosts recomputes the like and reply counters, and with them the sort
+// scores, of the given posts from their likes and replies. Likes made while
+// shadow banned and replies of shadow banned users aren't counted.
+func recountPosts(tx *gorm.DB, postIDs ...int64) error {
+	res := tx.Exec(`
+	UPDATE posts SET
+		like_count = (SELECT COUNT(*) FROM user_actions ua WHERE ua.post_id = posts.id AND ua.action = ? AND ua.value = true
+			AND ua.counted IS NOT FALSE),
+		reply_count = (SELECT COUNT(*) FROM posts c WHERE c.parent_id = posts.id
+			AND c.user_id NOT IN (`+shadowBannedUsersQuery+`))
+	WHERE id IN ?`, like, postIDs)
+	if res.Error != nil {
+		return res.Error
+	}
+	for _, postID := range postIDs {
+		if err := refreshPostScores(tx, postID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
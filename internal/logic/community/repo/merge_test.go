@@ -0,0 +1,117 @@
+package repo
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+)
+
+func mergePost(mutate func(post *dbModel.Post)) *dbModel.Post {
+	post := &dbModel.Post{ChannelID: "general", Type: common.POST_TYPE_COMMENT, Status: common.POST_STATUS_PUBLISHED}
+	if mutate != nil {
+		mutate(post)
+	}
+	return post
+}
+
+func TestValidateMerge(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   *dbModel.Post
+		target   *dbModel.Post
+		wantCode exceptions.ErrorCode
+	}{
+		{name: "valid", source: mergePost(nil), target: mergePost(nil)},
+		{name: "missing source", target: mergePost(nil), wantCode: exceptions.PostIdErrorCode},
+		{name: "missing target", source: mergePost(nil), wantCode: exceptions.PostIdErrorCode},
+		{
+			name:     "different channels",
+			source:   mergePost(nil),
+			target:   mergePost(func(p *dbModel.Post) { p.ChannelID = "other" }),
+			wantCode: exceptions.BadRequestErrorCode,
+		},
+		{
+			name:     "reply source",
+			source:   mergePost(func(p *dbModel.Post) { p.Type = common.POST_TYPE_REPLY }),
+			target:   mergePost(nil),
+			wantCode: exceptions.BadRequestErrorCode,
+		},
+		{
+			name:     "deleted target",
+			source:   mergePost(nil),
+			target:   mergePost(func(p *dbModel.Post) { p.Status = common.POST_STATUS_DELETED }),
+			wantCode: exceptions.DeletedPostErrorCode,
+		},
+		{
+			name:     "already merged source",
+			source:   mergePost(func(p *dbModel.Post) { p.Status = common.POST_STATUS_MERGED }),
+			target:   mergePost(nil),
+			wantCode: exceptions.MergedPostErrorCode,
+		},
+		{
+			name:     "merged target",
+			source:   mergePost(nil),
+			target:   mergePost(func(p *dbModel.Post) { p.Status = common.POST_STATUS_MERGED }),
+			wantCode: exceptions.MergedPostErrorCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exp := validateMerge(tt.source, tt.target)
+			switch {
+			case tt.wantCode == "" && exp != nil:
+				t.Errorf("validateMerge() = %s, want nil", exp.ErrorCode)
+			case tt.wantCode != "" && exp == nil:
+				t.Errorf("validateMerge() = nil, want %s", tt.wantCode)
+			case tt.wantCode != "" && exp.ErrorCode != tt.wantCode:
+				t.Errorf("validateMerge() = %s, want %s", exp.ErrorCode, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestMovedLikeTarget(t *testing.T) {
+	active, inactive := true, false
+	tests := []struct {
+		name        string
+		targetLikes []dbModel.UserActions
+		want        string
+	}{
+		{name: "no like on target", want: model.MovedLikeCreated},
+		{name: "active like on target", targetLikes: []dbModel.UserActions{{Value: &active}}, want: model.MovedLikeExisting},
+		{name: "taken back like on target", targetLikes: []dbModel.UserActions{{Value: &inactive}}, want: model.MovedLikeReactivated},
+		{name: "like without a value", targetLikes: []dbModel.UserActions{{}}, want: model.MovedLikeReactivated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := movedLikeTarget(tt.targetLikes); got != tt.want {
+				t.Errorf("movedLikeTarget() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// The moved likes are stored as JSON on the merge and read back on revert.
+func TestMovedLikesRoundTrip(t *testing.T) {
+	want := []model.MovedLike{
+		{UserID: "u1", Target: model.MovedLikeCreated},
+		{UserID: "u2", Target: model.MovedLikeExisting},
+		{UserID: "u3", Target: model.MovedLikeReactivated},
+	}
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var got []model.MovedLike
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,165 @@
+package repo
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+)
+
+const (
+	channelBlockedWordsTable = "channel_blocked_words"
+	postFlagsTable           = "post_flags"
+)
+
+// auditFlaggedPost is the state of a flagged post kept in the audit log when
+// its flags are reviewed.
+type auditFlaggedPost struct {
+	auditPost
+	FlagStatus string `json:"flag_status"`
+}
+
+func (r *repo) GetChannelBlockedWords(ctx context.Context, channelID string) ([]string, error) {
+	log := logger.GetLogInstance(ctx, "GetChannelBlockedWords")
+	var words []string
+	db := r.db.MasterDB.WithContext(ctx).Table(channelBlockedWordsTable).
+		Where("channel_id = ?", channelID).Order("word").Pluck("word", &words)
+	if db.Error != nil {
+		log.Errorf("[GetChannelBlockedWords] unable to fetch blocked words of channel id: %s with error: %v", channelID, db.Error)
+		return nil, db.Error
+	}
+	return words, nil
+}
+
+// SetChannelBlockedWords replaces the words blocked in a channel.
+func (r *repo) SetChannelBlockedWords(ctx context.Context, channelID string, words []string) error {
+	log := logger.GetLogInstance(ctx, "SetChannelBlockedWords")
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(channelBlockedWordsTable).Where("channel_id = ?", channelID).Delete(&dbModel.ChannelBlockedWord{}).Error; err != nil {
+			return err
+		}
+		if len(words) == 0 {
+			return nil
+		}
+		rows := make([]dbModel.ChannelBlockedWord, 0, len(words))
+		for _, word := range words {
+			rows = append(rows, dbModel.ChannelBlockedWord{ChannelID: channelID, Word: word})
+		}
+		return tx.Table(channelBlockedWordsTable).Create(&rows).Error
+	})
+	if err != nil {
+		log.Errorf("[SetChannelBlockedWords] unable to save blocked words of channel id: %s with error: %v", channelID, err)
+		return err
+	}
+	return nil
+}
+
+// HasRecentPost tells whether the user created a live post other than
+// excludePostID with the same content, ignoring case and surrounding spaces,
+// since the given time.
+func (r *repo) HasRecentPost(ctx context.Context, userID string, content string, since time.Time, excludePostID int64) (bool, error) {
+	log := logger.GetLogInstance(ctx, "HasRecentPost")
+	var ids []int64
+	db := r.db.MasterDB.WithContext(ctx).Table(postsTable).
+		Where("user_id = ? AND created_at >= ? AND status <> ? AND id <> ?", userID, since, common.POST_STATUS_DELETED, excludePostID).
+		Where("LOWER(TRIM(content)) = LOWER(?)", content).
+		Limit(1).Pluck("id", &ids)
+	if db.Error != nil {
+		log.Errorf("[HasRecentPost] unable to check recent posts of user id: %s with error: %v", userID, db.Error)
+		return false, db.Error
+	}
+	return len(ids) > 0, nil
+}
+
+func (r *repo) SavePostFlags(ctx context.Context, flags []dbModel.PostFlag) error {
+	log := logger.GetLogInstance(ctx, "SavePostFlags")
+	if len(flags) == 0 {
+		return nil
+	}
+	db := r.db.MasterDB.WithContext(ctx).Table(postFlagsTable).Create(&flags)
+	if db.Error != nil {
+		log.Errorf("[SavePostFlags] unable to flag post id: %d with error: %v", flags[0].PostID, db.Error)
+		return db.Error
+	}
+	return nil
+}
+
+// GetPostFlags lists the flags of a channel newest first. An empty status
+// lists flags in any status.
+func (r *repo) GetPostFlags(ctx context.Context, channelID string, status string, limit int, beforeID int64) ([]dbModel.PostFlag, error) {
+	log := logger.GetLogInstance(ctx, "GetPostFlags")
+	var flags []dbModel.PostFlag
+	query := r.db.MasterDB.WithContext(ctx).Table(postFlagsTable).Where("channel_id = ?", channelID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if beforeID != 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	db := query.Order("id desc").Limit(limit).Find(&flags)
+	if db.Error != nil {
+		log.Errorf("[GetPostFlags] unable to fetch flags of channel id: %s with error: %v", channelID, db.Error)
+		return nil, db.Error
+	}
+	return flags, nil
+}
+
+// ReviewPostFlags closes the open flags of a post with the given status. When
+// the post is removed it is hidden and loses its hashtags, when it is
+// approved a post hidden by reports is published again. It returns how many
+// flags were closed.
+func (r *repo) ReviewPostFlags(ctx context.Context, postID int64, status string, actor model.AuditActor) (int64, error) {
+	log := logger.GetLogInstance(ctx, "ReviewPostFlags")
+	var reviewed int64
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		res := tx.Table(postFlagsTable).Where("post_id = ? AND status = ?", postID, common.FLAG_STATUS_OPEN).
+			Updates(map[string]interface{}{"status": status, "reviewed_by": actor.UserID, "reviewed_at": now, "updated_at": now})
+		if res.Error != nil {
+			return res.Error
+		}
+		reviewed = res.RowsAffected
+		if reviewed == 0 {
+			return nil
+		}
+		before, err := readAuditPost(tx, postID)
+		if err != nil {
+			return err
+		}
+		after := before
+		action := common.AUDIT_ACTION_POST_APPROVE
+		from, to := common.POST_STATUS_HIDDEN, common.POST_STATUS_PUBLISHED
+		if status == common.FLAG_STATUS_REMOVED {
+			action = common.AUDIT_ACTION_POST_HIDE
+			from, to = common.POST_STATUS_PUBLISHED, common.POST_STATUS_HIDDEN
+		}
+		res = tx.Table(postsTable).Where("id = ? AND status = ?", postID, from).Update("status", to)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected > 0 {
+			after.Status = to
+			post := dbModel.Post{ID: postID, ChannelID: after.ChannelID, Content: after.Content, Status: to}
+			if err := restorePostTags(tx, post); err != nil {
+				return err
+			}
+		}
+		return addAuditLog(tx, actor, dbModel.AuditLog{
+			Action:     action,
+			TargetType: common.AUDIT_TARGET_POST,
+			TargetID:   strconv.FormatInt(postID, 10),
+			ChannelID:  before.ChannelID,
+		}, auditFlaggedPost{auditPost: before, FlagStatus: common.FLAG_STATUS_OPEN},
+			auditFlaggedPost{auditPost: after, FlagStatus: status})
+	})
+	if err != nil {
+		log.Errorf("[ReviewPostFlags] unable to review flags of post id: %d with error: %v", postID, err)
+		return 0, err
+	}
+	return reviewed, nil
+}
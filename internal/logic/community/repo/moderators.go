@@ -0,0 +1,77 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const channelModeratorsTable = "channel_moderators"
+
+func (r *repo) IsChannelModerator(ctx context.Context, channelID string, userID string) (bool, error) {
+	log := logger.GetLogInstance(ctx, "IsChannelModerator")
+	var count int64
+	db := r.db.MasterDB.WithContext(ctx).Table(channelModeratorsTable).Where("channel_id = ? AND user_id = ?", channelID, userID).Count(&count)
+	if db.Error != nil {
+		log.Errorf("[IsChannelModerator] unable to check moderator for channel id: %s and user id: %s with error: %v", channelID, userID, db.Error)
+		return false, db.Error
+	}
+	return count > 0, nil
+}
+
+// AddChannelModerator makes userID a moderator of channelID. Adding an
+// existing moderator again is not an error.
+func (r *repo) AddChannelModerator(ctx context.Context, channelID string, userID string, actor model.AuditActor) error {
+	log := logger.GetLogInstance(ctx, "AddChannelModerator")
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		db := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&dbModel.ChannelModerator{
+			ChannelID: channelID,
+			UserID:    userID,
+		})
+		if db.Error != nil || db.RowsAffected == 0 {
+			return db.Error
+		}
+		return addModeratorAuditLog(tx, actor, common.AUDIT_ACTION_MODERATOR_ADD, channelID, userID)
+	})
+	if err != nil {
+		log.Errorf("[AddChannelModerator] unable to add moderator for channel id: %s and user id: %s with error: %v", channelID, userID, err)
+		return err
+	}
+	return nil
+}
+
+func (r *repo) RemoveChannelModerator(ctx context.Context, channelID string, userID string, actor model.AuditActor) error {
+	log := logger.GetLogInstance(ctx, "RemoveChannelModerator")
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		db := tx.Where("channel_id = ? AND user_id = ?", channelID, userID).Delete(&dbModel.ChannelModerator{})
+		if db.Error != nil || db.RowsAffected == 0 {
+			return db.Error
+		}
+		return addModeratorAuditLog(tx, actor, common.AUDIT_ACTION_MODERATOR_REMOVE, channelID, userID)
+	})
+	if err != nil {
+		log.Errorf("[RemoveChannelModerator] unable to remove moderator for channel id: %s and user id: %s with error: %v", channelID, userID, err)
+		return err
+	}
+	return nil
+}
+
+// auditModerator is the role of a user in a channel kept in the audit log.
+type auditModerator struct {
+	Moderator bool `json:"moderator"`
+}
+
+func addModeratorAuditLog(tx *gorm.DB, actor model.AuditActor, action string, channelID string, userID string) error {
+	added := action == common.AUDIT_ACTION_MODERATOR_ADD
+	return addAuditLog(tx, actor, dbModel.AuditLog{
+		Action:     action,
+		TargetType: common.AUDIT_TARGET_USER,
+		TargetID:   userID,
+		ChannelID:  channelID,
+	}, auditModerator{Moderator: !added}, auditModerator{Moderator: added})
+}
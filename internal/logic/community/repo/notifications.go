@@ -0,0 +1,100 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+)
+
+const notificationsTable = "notifications"
+
+// insertNotifications writes notifications on the given transaction so they
+// are only delivered when the change they announce is committed. Nobody is
+// notified of what shadow banned users do.
+func insertNotifications(tx *gorm.DB, notifications []dbModel.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	var actorIDs []string
+	for _, notification := range notifications {
+		if notification.ActorID != "" {
+			actorIDs = append(actorIDs, notification.ActorID)
+		}
+	}
+	if len(actorIDs) > 0 {
+		var bannedIDs []string
+		err := tx.Table(userStatusesTable).
+			Where("user_id IN ? AND status = ?", actorIDs, common.USER_STATUS_SHADOW_BANNED).
+			Pluck("user_id", &bannedIDs).Error
+		if err != nil {
+			return err
+		}
+		if len(bannedIDs) > 0 {
+			banned := make(map[string]bool, len(bannedIDs))
+			for _, userID := range bannedIDs {
+				banned[userID] = true
+			}
+			kept := notifications[:0]
+			for _, notification := range notifications {
+				if !banned[notification.ActorID] {
+					kept = append(kept, notification)
+				}
+			}
+			if notifications = kept; len(notifications) == 0 {
+				return nil
+			}
+		}
+	}
+	return tx.Table(notificationsTable).Create(&notifications).Error
+}
+
+// GetNotifications returns a user's notifications, newest first, with an id
+// lower than beforeID when it is set.
+func (r *repo) GetNotifications(ctx context.Context, userID string, limit int, beforeID int64, unreadOnly bool) ([]dbModel.Notification, error) {
+	log := logger.GetLogInstance(ctx, "GetNotifications")
+	var notifications []dbModel.Notification
+	query := r.db.MasterDB.WithContext(ctx).Table(notificationsTable).Where("user_id = ?", userID)
+	if beforeID != 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	db := query.Order("id desc").Limit(limit).Find(&notifications)
+	if db.Error != nil {
+		log.Errorf("[GetNotifications] unable to fetch notifications for user id: %s with error: %v", userID, db.Error)
+		return nil, db.Error
+	}
+	return notifications, nil
+}
+
+func (r *repo) GetUnreadNotificationsCount(ctx context.Context, userID string) (int64, error) {
+	log := logger.GetLogInstance(ctx, "GetUnreadNotificationsCount")
+	var count int64
+	db := r.db.MasterDB.WithContext(ctx).Table(notificationsTable).Where("user_id = ? AND read_at IS NULL", userID).Count(&count)
+	if db.Error != nil {
+		log.Errorf("[GetUnreadNotificationsCount] unable to count notifications for user id: %s with error: %v", userID, db.Error)
+		return 0, db.Error
+	}
+	return count, nil
+}
+
+// MarkNotificationsRead marks the given notifications of a user as read, or
+// all of them when ids is empty, and returns how many were updated.
+func (r *repo) MarkNotificationsRead(ctx context.Context, userID string, ids []int64) (int64, error) {
+	log := logger.GetLogInstance(ctx, "MarkNotificationsRead")
+	query := r.db.MasterDB.WithContext(ctx).Table(notificationsTable).Where("user_id = ? AND read_at IS NULL", userID)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+	db := query.Updates(map[string]interface{}{"read_at": time.Now(), "updated_at": time.Now()})
+	if db.Error != nil {
+		log.Errorf("[MarkNotificationsRead] unable to mark notifications read for user id: %s with error: %v", userID, db.Error)
+		return 0, db.Error
+	}
+	return db.RowsAffected, nil
+}
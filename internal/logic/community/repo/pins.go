@@ -0,0 +1,54 @@
+package repo
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/events"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SetPostPinned pins or unpins a post and audits the change in the same
+// transaction. Setting the state the post is already in changes nothing.
+func (r *repo) SetPostPinned(ctx context.Context, postID int64, pinned bool, actor model.AuditActor) (*dbModel.Post, error) {
+	log := logger.GetLogInstance(ctx, "SetPostPinned")
+	var post dbModel.Post
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Table(postsTable).Where("id = ?", postID).First(&post).Error; err != nil {
+			return err
+		}
+		if post.IsPinned == pinned {
+			return nil
+		}
+		before := newAuditPost(post)
+		if err := tx.Table(postsTable).Where("id = ?", postID).Update("is_pinned", pinned).Error; err != nil {
+			return err
+		}
+		post.IsPinned = pinned
+
+		action, eventType := common.AUDIT_ACTION_POST_PIN, events.PostPinned
+		if !pinned {
+			action, eventType = common.AUDIT_ACTION_POST_UNPIN, events.PostUnpinned
+		}
+		err := addAuditLog(tx, actor, dbModel.AuditLog{
+			Action:     action,
+			TargetType: common.AUDIT_TARGET_POST,
+			TargetID:   strconv.FormatInt(post.ID, 10),
+			ChannelID:  post.ChannelID,
+		}, before, newAuditPost(post))
+		if err != nil {
+			return err
+		}
+		return addOutboxEvent(tx, eventType, events.AggregatePost, post.ID, postEventPayload(post))
+	})
+	if err != nil {
+		log.Errorf("[SetPostPinned] unable to set pinned: %v of post id: %d with error: %v", pinned, postID, err)
+		return nil, err
+	}
+	return &post, nil
+}
@@ -0,0 +1,149 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/events"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	pollsTable       = "polls"
+	pollOptionsTable = "poll_options"
+	pollBallotsTable = "poll_ballots"
+	pollVotesTable   = "poll_votes"
+)
+
+// InsertPollPost creates a POLL post together with its settings and options,
+// which are numbered in the order given.
+func (r *repo) InsertPollPost(ctx context.Context, postData dbModel.Post, poll dbModel.Poll, options []string) (*dbModel.Post, error) {
+	log := logger.GetLogInstance(ctx, "InsertPollPost")
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := createPost(tx, &postData); err != nil {
+			return err
+		}
+		poll.PostID = postData.ID
+		if err := tx.Table(pollsTable).Create(&poll).Error; err != nil {
+			return fmt.Errorf("create poll failed: %w", err)
+		}
+		pollOptions := make([]dbModel.PollOption, 0, len(options))
+		for i, text := range options {
+			pollOptions = append(pollOptions, dbModel.PollOption{PostID: postData.ID, Position: i, Text: text})
+		}
+		if err := tx.Table(pollOptionsTable).Create(&pollOptions).Error; err != nil {
+			return fmt.Errorf("create poll options failed: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[InsertPollPost] unable to create poll in channel id: %s with error: %v", postData.ChannelID, err)
+		return nil, err
+	}
+	return &postData, nil
+}
+
+func (r *repo) GetPolls(ctx context.Context, postIDs []int64) ([]dbModel.Poll, error) {
+	log := logger.GetLogInstance(ctx, "GetPolls")
+	var polls []dbModel.Poll
+	if len(postIDs) == 0 {
+		return polls, nil
+	}
+	db := r.db.MasterDB.WithContext(ctx).Table(pollsTable).Where("post_id IN ?", postIDs).Find(&polls)
+	if db.Error != nil {
+		log.Errorf("[GetPolls] unable to fetch polls with error: %v", db.Error)
+		return nil, db.Error
+	}
+	return polls, nil
+}
+
+func (r *repo) GetPollOptions(ctx context.Context, postIDs []int64) ([]dbModel.PollOption, error) {
+	log := logger.GetLogInstance(ctx, "GetPollOptions")
+	var options []dbModel.PollOption
+	if len(postIDs) == 0 {
+		return options, nil
+	}
+	db := r.db.MasterDB.WithContext(ctx).Table(pollOptionsTable).Where("post_id IN ?", postIDs).Order("post_id, position").Find(&options)
+	if db.Error != nil {
+		log.Errorf("[GetPollOptions] unable to fetch poll options with error: %v", db.Error)
+		return nil, db.Error
+	}
+	return options, nil
+}
+
+// GetUserPollVotes returns the options userID picked on the given polls.
+func (r *repo) GetUserPollVotes(ctx context.Context, userID string, postIDs []int64) ([]dbModel.PollVote, error) {
+	log := logger.GetLogInstance(ctx, "GetUserPollVotes")
+	var votes []dbModel.PollVote
+	if userID == "" || len(postIDs) == 0 {
+		return votes, nil
+	}
+	db := r.db.MasterDB.WithContext(ctx).Table(pollVotesTable).Where("user_id = ? AND post_id IN ?", userID, postIDs).Find(&votes)
+	if db.Error != nil {
+		log.Errorf("[GetUserPollVotes] unable to fetch poll votes of user id: %s with error: %v", userID, db.Error)
+		return nil, db.Error
+	}
+	return votes, nil
+}
+
+// VotePoll casts userID's ballot for optionIDs. The ballot insert relies on
+// the unique (post_id, user_id) index, so a second ballot from the same user
+// is rejected even when both arrive at once. Ballots of shadow banned users
+// are kept but not counted in the tallies.
+func (r *repo) VotePoll(ctx context.Context, postID int64, userID string, optionIDs []int64) *exceptions.Exception {
+	log := logger.GetLogInstance(ctx, "VotePoll")
+	var exp *exceptions.Exception
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ballot := tx.Table(pollBallotsTable).Clauses(clause.OnConflict{DoNothing: true}).Create(&dbModel.PollBallot{PostID: postID, UserID: userID})
+		if ballot.Error != nil {
+			return ballot.Error
+		}
+		if ballot.RowsAffected == 0 {
+			exp = exceptions.GetExceptionByErrorCode(exceptions.PollAlreadyVotedErrorCode)
+			return nil
+		}
+
+		votes := make([]dbModel.PollVote, 0, len(optionIDs))
+		for _, optionID := range optionIDs {
+			votes = append(votes, dbModel.PollVote{PostID: postID, OptionID: optionID, UserID: userID})
+		}
+		if err := tx.Table(pollVotesTable).Create(&votes).Error; err != nil {
+			return err
+		}
+		shadowBanned, err := isShadowBanned(tx, userID)
+		if err != nil {
+			return err
+		}
+		if shadowBanned {
+			return addPollVoteEvent(tx, postID, userID, optionIDs)
+		}
+		res := tx.Table(pollOptionsTable).Where("post_id = ? AND id IN ?", postID, optionIDs).Update("vote_count", gorm.Expr("vote_count + 1"))
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != int64(len(optionIDs)) {
+			return fmt.Errorf("poll %d has %d of the %d voted options", postID, res.RowsAffected, len(optionIDs))
+		}
+		if err := tx.Table(pollsTable).Where("post_id = ?", postID).Update("voter_count", gorm.Expr("voter_count + 1")).Error; err != nil {
+			return err
+		}
+		return addPollVoteEvent(tx, postID, userID, optionIDs)
+	})
+	if err != nil {
+		log.Errorf("[VotePoll] unable to vote on poll id: %d for user id: %s with error: %v", postID, userID, err)
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return exp
+}
+
+func addPollVoteEvent(tx *gorm.DB, postID int64, userID string, optionIDs []int64) error {
+	return addOutboxEvent(tx, events.PollVoted, events.AggregatePost, postID, events.PollVotePayload{
+		PostID:    postID,
+		UserID:    userID,
+		OptionIDs: optionIDs,
+	})
+}
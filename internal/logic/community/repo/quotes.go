@@ -0,0 +1,74 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+)
+
+// addQuote counts a new quote on the quoted post and lets its author know,
+// unless they quoted themselves.
+func addQuote(tx *gorm.DB, post dbModel.Post) error {
+	if post.QuotedPostID == 0 {
+		return nil
+	}
+	if err := incrementPostCounter(tx, post.QuotedPostID, "quote_count"); err != nil {
+		return err
+	}
+	var quotedUserID string
+	if err := tx.Table(postsTable).Select("user_id").Where("id = ?", post.QuotedPostID).Scan(&quotedUserID).Error; err != nil {
+		return err
+	}
+	if quotedUserID == "" || quotedUserID == post.UserID {
+		return nil
+	}
+	return insertNotifications(tx, []dbModel.Notification{{
+		UserID:    quotedUserID,
+		Type:      common.NOTIFICATION_QUOTED,
+		ChannelID: post.ChannelID,
+		PostID:    post.ID,
+		ActorID:   post.UserID,
+		Message:   "Your post was quoted",
+	}})
+}
+
+// removeQuote takes a deleted quote off the quote count of the post it quoted.
+func removeQuote(tx *gorm.DB, post dbModel.Post) error {
+	if post.QuotedPostID == 0 {
+		return nil
+	}
+	db := tx.Table(postsTable).Where("id = ?", post.QuotedPostID).
+		Update("quote_count", gorm.Expr("GREATEST(quote_count - 1, 0)"))
+	if db.Error != nil {
+		return fmt.Errorf("decrement quote_count for post %d failed: %w", post.QuotedPostID, db.Error)
+	}
+	return nil
+}
+
+// GetQuotedPosts loads the posts quoted by a page of posts along with their
+// authors. Posts that no longer exist or whose authors are hidden from the
+// viewer are simply missing from the result.
+func (r *repo) GetQuotedPosts(ctx context.Context, postIDs []int64, viewer model.Viewer) ([]model.QuotedPost, error) {
+	log := logger.GetLogInstance(ctx, "GetQuotedPosts")
+	var posts []model.QuotedPost
+	if len(postIDs) == 0 {
+		return posts, nil
+	}
+	db := r.db.MasterDB.WithContext(ctx).
+		Table("posts as p").
+		Select("p.id, p.channel_id, p.user_id, p.content, p.type, p.status, p.created_at, u.first_name, u.middle_name, u.last_name, u.profile_image_url").
+		Joins("left join user_details u on p.user_id = u.user_id").
+		Where("p.id IN ?", postIDs).
+		Where(hiddenAuthorsCondition("p.user_id"), hiddenAuthorsParams(viewer)...).
+		Scan(&posts)
+	if db.Error != nil {
+		log.Errorf("[GetQuotedPosts] unable to fetch quoted posts with error: %v", db.Error)
+		return nil, fmt.Errorf("getQuotedPosts query failed: %w", db.Error)
+	}
+	return posts, nil
+}
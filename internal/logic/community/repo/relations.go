@@ -0,0 +1,104 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm/clause"
+)
+
+const userRelationsTable = "user_relations"
+
+// hiddenAuthorsCondition keeps the posts whose author, read from column, is
+// neither blocked nor muted by the viewer nor shadow banned, unless the author
+// is the viewer or a moderator reads with the moderator view. Its parameters
+// are given by hiddenAuthorsParams.
+func hiddenAuthorsCondition(column string) string {
+	return column + " NOT IN (SELECT target_user_id FROM " + userRelationsTable + " WHERE user_id = ?)" +
+		" AND " + shadowBannedAuthorsCondition(column)
+}
+
+func hiddenAuthorsParams(viewer model.Viewer) []interface{} {
+	return append([]interface{}{viewer.UserID}, shadowBannedAuthorsParams(viewer)...)
+}
+
+// shadowBannedAuthorsCondition is the part of hiddenAuthorsCondition that
+// doesn't depend on who the viewer blocked or muted, for readers without a
+// user id. Its parameters are given by shadowBannedAuthorsParams.
+func shadowBannedAuthorsCondition(column string) string {
+	return "(? OR " + column + " = ? OR " + column + " NOT IN (" + shadowBannedUsersQuery + "))"
+}
+
+func shadowBannedAuthorsParams(viewer model.Viewer) []interface{} {
+	return []interface{}{viewer.ModeratorView, viewer.UserID}
+}
+
+// AddUserRelation records a block or a mute. Adding one that exists already
+// changes nothing.
+func (r *repo) AddUserRelation(ctx context.Context, relation dbModel.UserRelation) error {
+	log := logger.GetLogInstance(ctx, "AddUserRelation")
+	err := r.db.MasterDB.WithContext(ctx).Table(userRelationsTable).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&relation).Error
+	if err != nil {
+		log.Errorf("[AddUserRelation] unable to add %s of user id: %s by user id: %s with error: %v", relation.Type, relation.TargetUserID, relation.UserID, err)
+		return err
+	}
+	return nil
+}
+
+func (r *repo) RemoveUserRelation(ctx context.Context, userID string, targetUserID string, relationType string) error {
+	log := logger.GetLogInstance(ctx, "RemoveUserRelation")
+	err := r.db.MasterDB.WithContext(ctx).Table(userRelationsTable).
+		Where("user_id = ? AND target_user_id = ? AND type = ?", userID, targetUserID, relationType).
+		Delete(&dbModel.UserRelation{}).Error
+	if err != nil {
+		log.Errorf("[RemoveUserRelation] unable to remove %s of user id: %s by user id: %s with error: %v", relationType, targetUserID, userID, err)
+		return err
+	}
+	return nil
+}
+
+// GetUserRelations lists the users that userID blocked or muted, most recent
+// first. An empty relationType lists both.
+func (r *repo) GetUserRelations(ctx context.Context, userID string, relationType string) ([]model.UserRelationDetails, error) {
+	log := logger.GetLogInstance(ctx, "GetUserRelations")
+	var relations []model.UserRelationDetails
+	query := r.db.MasterDB.WithContext(ctx).
+		Table(userRelationsTable+" as ur").
+		Select("ur.target_user_id, ur.type, ur.created_at, u.user_name, u.first_name, u.middle_name, u.last_name, u.profile_image_url").
+		Joins("left join user_details u on ur.target_user_id = u.user_id").
+		Where("ur.user_id = ?", userID)
+	if relationType != "" {
+		query = query.Where("ur.type = ?", relationType)
+	}
+	if err := query.Order("ur.created_at DESC, ur.id DESC").Scan(&relations).Error; err != nil {
+		log.Errorf("[GetUserRelations] unable to fetch relations of user id: %s with error: %v", userID, err)
+		return nil, err
+	}
+	return relations, nil
+}
+
+// GetBlockingUsers returns which of userIDs blocked targetUserID.
+func (r *repo) GetBlockingUsers(ctx context.Context, userIDs []string, targetUserID string) (map[string]bool, error) {
+	log := logger.GetLogInstance(ctx, "GetBlockingUsers")
+	blocking := make(map[string]bool)
+	if len(userIDs) == 0 || targetUserID == "" {
+		return blocking, nil
+	}
+	var blockingIDs []string
+	err := r.db.MasterDB.WithContext(ctx).Table(userRelationsTable).
+		Where("user_id IN ? AND target_user_id = ? AND type = ?", userIDs, targetUserID, common.RELATION_BLOCK).
+		Pluck("user_id", &blockingIDs).Error
+	if err != nil {
+		log.Errorf("[GetBlockingUsers] unable to fetch blocks of user id: %s with error: %v", targetUserID, err)
+		return blocking, err
+	}
+	for _, id := range blockingIDs {
+		blocking[id] = true
+	}
+	return blocking, nil
+}
@@ -8,12 +8,14 @@ import (
 	"time"
 	"github.com/Abhishekjha321/community_service/log"
 	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/events"
 	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
 	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
 
 	"github.com/Abhishekjha321/community_service/exceptions"
 	"github.com/Abhishekjha321/community_service/pkg/store/db"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const (
@@ -25,7 +27,10 @@ const (
 	reportTable      = "reports"
 	userDetailsTable = "user_details"
 	allReplyTable    = "replies"
-	comment          = "COMMENT"
+	outboxTable      = "outbox_events"
+
+	// feedTypesCondition matches the top-level post types listed in channel feeds
+	feedTypesCondition = "type IN ('COMMENT', 'POLL')"
 )
 
 type repo struct {
@@ -73,23 +78,60 @@ func (r *repo) InsertPostData(ctx context.Context, postData dbModel.Post) (*dbMo
 
 	log := logger.GetLogInstance(ctx, "InsertPostData-repo")
 
-	db := r.db.MasterDB.WithContext(ctx).Table(postsTable).Create(&postData)
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := createPost(tx, &postData); err != nil {
+			log.Errorf("[InsertPostDataRepo] error while creating data in db: %+v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &postData, nil
+
+}
+
+// createPost inserts a post inside tx along with its scores, the parent's
+// reply counter, the quoted post's quote counter and the created event. Posts
+// of shadow banned users leave the counters of other posts unchanged.
+func createPost(tx *gorm.DB, postData *dbModel.Post) error {
+	db := tx.Table(postsTable).Create(postData)
 	if db.Error != nil {
-		log.Errorf("[InsertPostDataRepo] error while creating data in db: %+v", db.Error.Error())
-		return nil, fmt.Errorf("createPost query failed: %w", db.Error)
+		return fmt.Errorf("createPost query failed: %w", db.Error)
 	}
 	if db.RowsAffected < 1 {
-		log.Errorf("[InsertPostDataRepo] failed to insert post data in db")
-		return nil, errors.New("[InsertPostDataRepo] failed to insert post data in db")
+		return errors.New("failed to insert post data in db")
 	}
-	return &postData, nil
-
+	if err := refreshPostScores(tx, postData.ID); err != nil {
+		return err
+	}
+	shadowBanned, err := isShadowBanned(tx, postData.UserID)
+	if err != nil {
+		return err
+	}
+	eventType := events.PostCreated
+	if postData.ParentID != 0 {
+		eventType = events.ReplyCreated
+		if !shadowBanned {
+			if err := incrementPostCounter(tx, postData.ParentID, "reply_count"); err != nil {
+				return err
+			}
+		}
+	}
+	if !shadowBanned {
+		if err := addQuote(tx, *postData); err != nil {
+			return err
+		}
+	}
+	return addOutboxEvent(tx, eventType, events.AggregatePost, postData.ID, postEventPayload(*postData))
 }
 
-func (r *repo) GetEventPostsCount(ctx context.Context, channelID string) (int64, error) {
+func (r *repo) GetEventPostsCount(ctx context.Context, channelID string, feedSort model.FeedSort) (int64, error) {
 	log := logger.GetLogInstance(ctx, "GetEventPostsCount")
 	var count int64
-	db := r.db.MasterDB.WithContext(ctx).Table(postsTable).Where("channel_id = ? AND type = ?", channelID, comment).Count(&count)
+	filter, filterParams := feedFilter(feedSort)
+	db := r.db.MasterDB.WithContext(ctx).Table(postsTable).Where("channel_id = ? AND "+feedTypesCondition+filter, append([]interface{}{channelID}, filterParams...)...).Count(&count)
 	if db.Error != nil {
 		log.Errorf("[GetEventPostsCount] Error while fetching count of posts for channel id: %s from db with error: %+v", channelID, db.Error)
 		return 0, db.Error
@@ -97,10 +139,11 @@ func (r *repo) GetEventPostsCount(ctx context.Context, channelID string) (int64,
 	return count, nil
 }
 
-func (r *repo) GetUserSpecificPostsCount(ctx context.Context, channelId string, userId string) (int, error) {
+func (r *repo) GetUserSpecificPostsCount(ctx context.Context, channelId string, userId string, feedSort model.FeedSort) (int, error) {
 	var count int64
 	log := logger.GetLogInstance(ctx, "GetUserSpecificPostsCount")
-	db := r.db.MasterDB.WithContext(ctx).Table(postsTable).Where("channel_id = ? AND type = ? AND user_id = ?", channelId, comment, userId).Count(&count)
+	filter, filterParams := feedFilter(feedSort)
+	db := r.db.MasterDB.WithContext(ctx).Table(postsTable).Where("channel_id = ? AND "+feedTypesCondition+" AND user_id = ?"+filter, append([]interface{}{channelId, userId}, filterParams...)...).Count(&count)
 	if db.Error != nil {
 		log.Errorf("[GetUserSpecificPostsCount] Error while fetching count of posts for channel id: %s  and userId : %s from db with error: %+v", channelId, userId, db.Error)
 		return 0, db.Error
@@ -108,8 +151,12 @@ func (r *repo) GetUserSpecificPostsCount(ctx context.Context, channelId string,
 	return int(count), nil
 }
 
-func (r *repo) GetUserSpecificEventPosts(ctx context.Context, channelID string, limit int, currentPage int, userId string, offset int) ([]common.Post, error) {
+func (r *repo) GetUserSpecificEventPosts(ctx context.Context, channelID string, limit int, currentPage int, userId string, offset int, feedSort model.FeedSort) ([]common.Post, error) {
 	var posts []common.Post
+	filter, filterParams := feedFilter(feedSort)
+	whereClause := `WHERE user_id = ? AND channel_id = ? AND ` + feedTypesCondition + filter
+	querParams := append([]interface{}{userId, channelID}, filterParams...)
+	querParams = append(querParams, limit, offset)
 	db := r.db.MasterDB.WithContext(ctx).Raw(`
 	SELECT 
 		id,
@@ -117,22 +164,32 @@ func (r *repo) GetUserSpecificEventPosts(ctx context.Context, channelID string,
 		user_id,
 		content,
 		type,
+		is_pinned,
 		parent_id,
 		like_count,
+		bookmark_count,
+		reply_count,
+		report_count,
+		hot_score,
+		controversy_score,
+		idea_status,
+		official_response,
+		merged_into_id,
+		vote_score,
+		upvote_count,
+		downvote_count,
+		accepted_answer_id,
+		quoted_post_id,
+		quote_count,
 		status,
 		created_at,
 		updated_at,
 		deleted_at
 		FROM posts 
-		WHERE user_id = ? AND channel_id = ? AND type = 'COMMENT'
-		ORDER BY
-			CASE 
-				WHEN status = 'DELETED' THEN 1
-				ELSE 0
-			END,
-			updated_at desc
+		`+whereClause+`
+		ORDER BY `+keysetOrder(feedSortKeys("", common.USER_BASED_FLOW, feedSort.Order, model.FeedPhaseOwn, nil))+`
 			LIMIT ? OFFSET ?
-	`, userId, channelID, limit, offset)
+	`, querParams...)
 	result := db.Find(&posts)
 	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
@@ -140,7 +197,7 @@ func (r *repo) GetUserSpecificEventPosts(ctx context.Context, channelID string,
 	return posts, nil
 }
 
-func (r *repo) GetRelevantReplies(ctx context.Context, commentIds []int64, sortBy string, bookMarksOnly bool) ([]common.Post, error) {
+func (r *repo) GetRelevantReplies(ctx context.Context, commentIds []int64, sortBy string, bookMarksOnly bool, viewer model.Viewer) ([]common.Post, error) {
 	log := logger.GetLogInstance(ctx, "Get Relevant Replies")
 	var replies []common.Post
 	if bookMarksOnly {
@@ -152,7 +209,11 @@ func (r *repo) GetRelevantReplies(ctx context.Context, commentIds []int64, sortB
 				p.*, 
 				ROW_NUMBER() OVER (
 					PARTITION BY parent_id 
-					ORDER BY`
+					ORDER BY
+		CASE 
+			WHEN is_accepted_answer = true THEN 0 
+			ELSE 1 
+		END,`
 	if sortBy == common.IDEAS_BASED_FLOW {
 		baseQuery += `
 		CASE 
@@ -169,11 +230,11 @@ func (r *repo) GetRelevantReplies(ctx context.Context, commentIds []int64, sortB
 					updated_at DESC
 			) AS row_num
 		FROM posts AS p
-		WHERE parent_id IN (?)
+		WHERE parent_id IN (?) AND `+hiddenAuthorsCondition("user_id")+`
 	)
 	SELECT *
 	FROM RankedPosts
-	WHERE row_num <= 3`, commentIds).Scan(&replies)
+	WHERE row_num <= 3`, append([]interface{}{commentIds}, hiddenAuthorsParams(viewer)...)...).Scan(&replies)
 	if err := db.Error; err != nil {
 		log.Errorf("[GetRelevantReplies] Unable to fetch relevant replies from db")
 		return replies, err
@@ -183,9 +244,6 @@ func (r *repo) GetRelevantReplies(ctx context.Context, commentIds []int64, sortB
 
 func (r *repo) GetBookMarkedPosts(ctx context.Context, channelID string, limit int, currentPage int, userId string, offset int, sortBy string) ([]common.Post, error) {
 	var posts []common.Post
-	if sortBy == common.USER_BASED_FLOW {
-		return posts, nil
-	}
 	db := r.db.MasterDB.WithContext(ctx).Raw(`
 			select
 			ua.post_id as id,
@@ -193,9 +251,12 @@ func (r *repo) GetBookMarkedPosts(ctx context.Context, channelID string, limit i
 			ua.user_id,
 			p.content,
 			p.type,
+			p.is_pinned,
 			p.parent_id,
 			p.like_count,
 			p.bookmark_count,
+			p.quoted_post_id,
+			p.quote_count,
 			p.status,
 			ua.created_at,
 			ua.updated_at,
@@ -210,10 +271,12 @@ func (r *repo) GetBookMarkedPosts(ctx context.Context, channelID string, limit i
 				and ua.user_id = ?
 				and p.channel_id = ?
 				and ua.value = true
+				and `+hiddenAuthorsCondition("p.user_id")+`
 			order by
-				ua.updated_at desc
+				ua.updated_at desc,
+				ua.post_id desc
 			limit ? offset ?	
-	`, userId, channelID, limit, offset)
+	`, append(append([]interface{}{userId, channelID}, hiddenAuthorsParams(model.Viewer{UserID: userId})...), limit, offset)...)
 	result := db.Find(&posts)
 	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
@@ -221,32 +284,20 @@ func (r *repo) GetBookMarkedPosts(ctx context.Context, channelID string, limit i
 	return posts, nil
 }
 
-func (r *repo) GetEventPosts(ctx context.Context, channelID string, limit int, currentPage int, userId string, offset int, sortBy string) ([]common.Post, error) {
+func (r *repo) GetEventPosts(ctx context.Context, channelID string, limit int, currentPage int, userId string, offset int, sortBy string, feedSort model.FeedSort) ([]common.Post, error) {
 	var posts []common.Post
-	whereClause := `WHERE user_id != ? AND channel_id = ? AND type = 'COMMENT'`
-	orderByClause := `ORDER BY
-			CASE 
-				WHEN status = 'DELETED' THEN 1
-				ELSE 0
-			END,
-			like_count DESC,
-			updated_at desc`
-	querParams := []interface{}{userId, channelID, limit, offset}
+	whereClause := `WHERE user_id != ? AND channel_id = ? AND ` + feedTypesCondition
+	querParams := []interface{}{userId, channelID}
 
 	if sortBy == common.IDEAS_BASED_FLOW {
-		whereClause = `WHERE channel_id = ? AND type = 'COMMENT'`
-		orderByClause = `ORDER BY
-			CASE 
-				WHEN is_pinned = true THEN 0
-				ELSE 1
-			END,
-			CASE 
-				WHEN status = 'DELETED' THEN 1
-				ELSE 0
-			END,
-			updated_at desc`
-		querParams = []interface{}{channelID, limit, offset}
+		whereClause = `WHERE channel_id = ? AND ` + feedTypesCondition
+		querParams = []interface{}{channelID}
 	}
+	filter, filterParams := feedFilter(feedSort)
+	whereClause += filter
+	querParams = append(querParams, filterParams...)
+	orderByClause := `ORDER BY ` + keysetOrder(feedSortKeys("", sortBy, feedSort.Order, model.FeedPhaseOthers, nil))
+	querParams = append(querParams, limit, offset)
 
 	db := r.db.MasterDB.WithContext(ctx).Raw(`
 	SELECT 
@@ -259,6 +310,19 @@ func (r *repo) GetEventPosts(ctx context.Context, channelID string, limit int, c
 		parent_id AS parent_id,
 		like_count AS like_count,
 		bookmark_count AS bookmark_count,
+		reply_count,
+		report_count,
+		hot_score,
+		controversy_score,
+		idea_status,
+		official_response,
+		merged_into_id,
+		vote_score,
+		upvote_count,
+		downvote_count,
+		accepted_answer_id,
+		quoted_post_id,
+		quote_count,
 		status AS status,
 		created_at AS created_at,
 		updated_at AS updated_at,
@@ -352,8 +416,12 @@ func (r *repo) CheckPostIDValidity(ctx context.Context, postID string, channelId
 }
 
 func (r *repo) UpdateRequiredActionInPostsTable(ctx context.Context, postID string, updateExpr string, userID string, actionName string) *exceptions.Exception {
+	return updateRequiredActionInPostsTable(ctx, r.db.MasterDB.WithContext(ctx), postID, updateExpr, userID, actionName)
+}
+
+func updateRequiredActionInPostsTable(ctx context.Context, tx *gorm.DB, postID string, updateExpr string, userID string, actionName string) *exceptions.Exception {
 	log := logger.GetLogInstance(ctx, "UpdateRequiredActionInPostsTable")
-	resPosts := r.db.MasterDB.WithContext(ctx).Table(postsTable).Where("id = ?", postID).Update(GetFieldNameBasedOnAction(actionName), gorm.Expr(updateExpr, 1))
+	resPosts := tx.Table(postsTable).Where("id = ?", postID).Update(GetFieldNameBasedOnAction(actionName), gorm.Expr(updateExpr, 1))
 	if resPosts.Error != nil {
 		log.Errorf("[UpdateRequiredActionInPostsTable] Unable to update like count for user with userID: %s , postID: %s and action: %s with error: %v", userID, postID, actionName, resPosts.Error)
 		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
@@ -383,50 +451,112 @@ func (r *repo) ActionSpecificLikePost(ctx context.Context, postID string, action
 	if actionName == like || actionName == unlike {
 		actionName = like
 	}
-	var userActions []*dbModel.UserActions
-	checkAction := r.db.MasterDB.WithContext(ctx).Table(userActionsTable).Where("post_id = ? AND user_id = ? AND action = ?", postID, userID, actionName).Find(&userActions)
-	if checkAction.Error != nil {
-		log.Errorf("[ActionSpecificLikePost] unable to fetch like value for postID: %s with error: %v", postID, checkAction.Error)
-		return "", exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
-	}
-	var updateExpr string
-	var newValue bool
-	postColumnToUpdate := GetFieldNameBasedOnAction(actionName)
-	if len(userActions) == 0 || !*userActions[0].Value {
-		updateExpr = postColumnToUpdate + " + ?"
-		newValue = true
-	} else {
-		updateExpr = postColumnToUpdate + " - ?"
-		newValue = false
-	}
-	errUpdatePostTable := r.UpdateRequiredActionInPostsTable(ctx, postID, updateExpr, userID, actionName)
-	if errUpdatePostTable != nil {
-		return "", errUpdatePostTable
-	}
-	if len(userActions) > 0 {
-		updateLike := r.db.MasterDB.WithContext(ctx).Table(userActionsTable).Where("post_id = ? AND user_id = ? AND action = ?", postID, userID, actionName).Updates(&dbModel.UserActions{
-			Value: &newValue,
-		})
-		if updateLike.Error != nil {
-			log.Errorf("[ActionSpecificLikePost] Unable to update user actions Action field bool value for userID: %s with postID: %s and action: %s with error: %v", userID, postID, actionName, updateLike.Error)
-			return "", exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	var (
+		exp      *exceptions.Exception
+		newValue bool
+	)
+	err = r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var userActions []*dbModel.UserActions
+		checkAction := tx.Table(userActionsTable).Where("post_id = ? AND user_id = ? AND action = ?", postID, userID, actionName).Find(&userActions)
+		if checkAction.Error != nil {
+			log.Errorf("[ActionSpecificLikePost] unable to fetch like value for postID: %s with error: %v", postID, checkAction.Error)
+			exp = exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+			return exp
 		}
-	} else {
-		userActionCreated := r.db.MasterDB.WithContext(ctx).Table(userActionsTable).Create(&dbModel.UserActions{
-			UserID: userID,
+		var updateExpr string
+		postColumnToUpdate := GetFieldNameBasedOnAction(actionName)
+		if len(userActions) == 0 || !*userActions[0].Value {
+			updateExpr = postColumnToUpdate + " + ?"
+			newValue = true
+		} else {
+			updateExpr = postColumnToUpdate + " - ?"
+			newValue = false
+		}
+		// likes of shadow banned users are kept but not counted, and taking
+		// one back only uncounts it if it was counted when it was made
+		countAction := true
+		if !newValue {
+			countAction = userActions[0].Counted == nil || *userActions[0].Counted
+		} else if actionName == like {
+			shadowBanned, err := isShadowBanned(tx, userID)
+			if err != nil {
+				log.Errorf("[ActionSpecificLikePost] unable to fetch status of userID: %s with error: %v", userID, err)
+				exp = exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+				return exp
+			}
+			countAction = !shadowBanned
+		}
+		if countAction {
+			if errUpdatePostTable := updateRequiredActionInPostsTable(ctx, tx, postID, updateExpr, userID, actionName); errUpdatePostTable != nil {
+				exp = errUpdatePostTable
+				return exp
+			}
+		}
+		if actionName == like {
+			if err := refreshPostScores(tx, int64(convertedPostID)); err != nil {
+				return err
+			}
+		}
+		if len(userActions) > 0 {
+			updateLike := tx.Table(userActionsTable).Where("post_id = ? AND user_id = ? AND action = ?", postID, userID, actionName).Updates(&dbModel.UserActions{
+				Value:   &newValue,
+				Counted: &countAction,
+			})
+			if updateLike.Error != nil {
+				log.Errorf("[ActionSpecificLikePost] Unable to update user actions Action field bool value for userID: %s with postID: %s and action: %s with error: %v", userID, postID, actionName, updateLike.Error)
+				exp = exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+				return exp
+			}
+		} else {
+			userActionCreated := tx.Table(userActionsTable).Create(&dbModel.UserActions{
+				UserID:  userID,
+				PostID:  int64(convertedPostID),
+				Action:  actionName,
+				Value:   &newValue,
+				Counted: &countAction,
+			})
+			if userActionCreated.Error != nil {
+				log.Errorf("[ActionSpecificLikePost] Unable to create user action for userID: %s with postID: %s and action: %s with error: %v", userID, postID, actionName, userActionCreated.Error)
+				exp = exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+				return exp
+			}
+		}
+		if actionName == bookmark && !newValue {
+			if err := removeBookmarkDetail(tx, postID, userID); err != nil {
+				return err
+			}
+		}
+		return addOutboxEvent(tx, actionEventType(actionName, newValue), events.AggregatePost, int64(convertedPostID), events.ActionPayload{
 			PostID: int64(convertedPostID),
+			UserID: userID,
 			Action: actionName,
-			Value:  &newValue,
+			Value:  newValue,
 		})
-		if userActionCreated.Error != nil {
-			log.Errorf("[ActionSpecificLikePost] Unable to create user action for userID: %s with postID: %s and action: %s with error: %v", userID, postID, actionName, userActionCreated.Error)
-			return "", exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
-		}
+	})
+	if exp != nil {
+		return "", exp
 	}
-	return "", nil
+	if err != nil {
+		log.Errorf("[ActionSpecificLikePost] Unable to record %s for userID: %s with postID: %s with error: %v", actionName, userID, postID, err)
+		return "", exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return strconv.FormatBool(newValue), nil
 }
 
-func (r *repo) DeleteSpecificPost(ctx context.Context, postID string, userID string) (string, error) {
+func actionEventType(actionName string, value bool) string {
+	switch {
+	case actionName == bookmark && value:
+		return events.PostBookmarked
+	case actionName == bookmark:
+		return events.PostUnbookmarked
+	case value:
+		return events.PostLiked
+	default:
+		return events.PostUnliked
+	}
+}
+
+func (r *repo) DeleteSpecificPost(ctx context.Context, postID string, actor model.AuditActor) (string, error) {
 	log := logger.GetLogInstance(ctx, "Delete Specific Post")
 	var postSpecificUserID string
 	resUserId := r.db.MasterDB.WithContext(ctx).Table(postsTable).Select("user_id").Where("id = ?", postID).Scan(&postSpecificUserID)
@@ -434,16 +564,50 @@ func (r *repo) DeleteSpecificPost(ctx context.Context, postID string, userID str
 		log.Errorf("[DeleteSpecificPost] Error while getting userID for postID: %s with error: %v", postID, resUserId.Error)
 		return "", exceptions.GetExceptionByErrorCode(exceptions.NoDataFoundErrorCode)
 	}
-	if postSpecificUserID != userID {
+	if actor.Role == common.AUDIT_ROLE_AUTHOR && postSpecificUserID != actor.UserID {
 		return "", exceptions.GetExceptionByErrorCode(exceptions.AccessDeniedErrorCode)
 	}
-	res := r.db.MasterDB.WithContext(ctx).Table(postsTable).Where("id = ?", postID).Updates(&dbModel.Post{
-		Content:   "This comment was deleted by the post author.",
-		Status:    "DELETED",
-		DeletedAt: time.Now(),
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var post dbModel.Post
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Table(postsTable).Where("id = ?", postID).First(&post).Error; err != nil {
+			return err
+		}
+		if post.Status == common.POST_STATUS_DELETED {
+			return nil
+		}
+		before := newAuditPost(post)
+		if err := addPostDeletion(tx, post, actor); err != nil {
+			return err
+		}
+		res := tx.Table(postsTable).Where("id = ?", postID).Updates(&dbModel.Post{
+			Status:    common.POST_STATUS_DELETED,
+			DeletedAt: time.Now(),
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if err := tx.Table(postsTable).Where("id = ?", postID).First(&post).Error; err != nil {
+			return err
+		}
+		if err := replacePostTags(tx, post, nil); err != nil {
+			return err
+		}
+		if err := removeQuote(tx, post); err != nil {
+			return err
+		}
+		err := addAuditLog(tx, actor, dbModel.AuditLog{
+			Action:     common.AUDIT_ACTION_POST_DELETE,
+			TargetType: common.AUDIT_TARGET_POST,
+			TargetID:   postID,
+			ChannelID:  post.ChannelID,
+		}, before, newAuditPost(post))
+		if err != nil {
+			return err
+		}
+		return addOutboxEvent(tx, events.PostDeleted, events.AggregatePost, post.ID, postEventPayload(post))
 	})
-	if res.Error != nil {
-		log.Errorf("[DeleteSpecificPost] Error while deleting post with postID: %s with error: %v", postID, res.Error)
+	if err != nil {
+		log.Errorf("[DeleteSpecificPost] Error while deleting post with postID: %s with error: %v", postID, err)
 		return "", exceptions.GetExceptionByErrorCode(exceptions.NoDataFoundErrorCode)
 	}
 	return "", nil
@@ -452,19 +616,71 @@ func (r *repo) DeleteSpecificPost(ctx context.Context, postID string, userID str
 func (r *repo) ReportPostData(ctx context.Context, reportData dbModel.Reports) (*dbModel.Reports, error) {
 	log := logger.GetLogInstance(ctx, "ReportPostData-repo")
 
-	db := r.db.MasterDB.WithContext(ctx).Table(reportTable).Create(&reportData)
-	if db.Error != nil {
-		log.Errorf("[ReportPostDataRepo] error while creating report data in db: %+v", db.Error.Error())
-		return nil, fmt.Errorf("reportPost query failed: %w", db.Error)
-	}
-	if db.RowsAffected < 1 {
-		log.Errorf("[ReportPostDataRepo] failed to insert report data in db")
-		return nil, errors.New("[ReportPostDataRepo] failed to insert report data in db")
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		db := tx.Table(reportTable).Create(&reportData)
+		if db.Error != nil {
+			log.Errorf("[ReportPostDataRepo] error while creating report data in db: %+v", db.Error.Error())
+			return fmt.Errorf("reportPost query failed: %w", db.Error)
+		}
+		if db.RowsAffected < 1 {
+			log.Errorf("[ReportPostDataRepo] failed to insert report data in db")
+			return errors.New("[ReportPostDataRepo] failed to insert report data in db")
+		}
+		if reportedPostID, err := strconv.ParseInt(reportData.PostID, 10, 64); err == nil {
+			if err := incrementPostCounter(tx, reportedPostID, "report_count"); err != nil {
+				return err
+			}
+		}
+		return addOutboxEvent(tx, events.PostReported, events.AggregateReport, reportData.ID, events.ReportPayload{
+			ReportID:       reportData.ID,
+			PostID:         reportData.PostID,
+			ReportedBy:     reportData.ReportedBy,
+			MasterReportID: reportData.MasterReportID,
+		})
+	})
+	if err != nil {
+		return nil, err
 	}
 	return &reportData, nil
 
 }
 
+// HideReportedPost hides a published post and drops its hashtags once its
+// reports reach threshold. It reports whether the post was hidden by this call.
+func (r *repo) HideReportedPost(ctx context.Context, postID int64, threshold int, actor model.AuditActor) (bool, error) {
+	log := logger.GetLogInstance(ctx, "HideReportedPost")
+	var hidden bool
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		db := tx.Table(postsTable).
+			Where("id = ? AND status = ? AND report_count >= ?", postID, common.POST_STATUS_PUBLISHED, threshold).
+			Update("status", common.POST_STATUS_HIDDEN)
+		if db.Error != nil || db.RowsAffected == 0 {
+			return db.Error
+		}
+		hidden = true
+		after, err := readAuditPost(tx, postID)
+		if err != nil {
+			return err
+		}
+		if err := replacePostTags(tx, dbModel.Post{ID: postID, ChannelID: after.ChannelID}, nil); err != nil {
+			return err
+		}
+		before := after
+		before.Status = common.POST_STATUS_PUBLISHED
+		return addAuditLog(tx, actor, dbModel.AuditLog{
+			Action:     common.AUDIT_ACTION_POST_HIDE,
+			TargetType: common.AUDIT_TARGET_POST,
+			TargetID:   strconv.FormatInt(postID, 10),
+			ChannelID:  after.ChannelID,
+		}, before, after)
+	})
+	if err != nil {
+		log.Errorf("[HideReportedPost] unable to hide post id: %d with error: %v", postID, err)
+		return false, err
+	}
+	return hidden, nil
+}
+
 func (r *repo) PopulateUserInfoTable(ctx context.Context, userInfo common.UserInfo) error {
 	var db *gorm.DB
 	if userInfo.IsNewUser {
@@ -496,10 +712,15 @@ func (r *repo) PopulateUserInfoTable(ctx context.Context, userInfo common.UserIn
 	return nil
 }
 
-func (r *repo) GetCommentSpecificReplyCount(ctx context.Context, postID string) (int64, error) {
+// GetCommentSpecificReplyCount counts the replies to a post, leaving out the
+// ones whose authors are hidden from the viewer.
+func (r *repo) GetCommentSpecificReplyCount(ctx context.Context, postID string, viewer model.Viewer) (int64, error) {
 	log := logger.GetLogInstance(ctx, "GetCommentSpecificReplyCount")
 	var count int64
-	db := r.db.MasterDB.WithContext(ctx).Table(postsTable).Where("parent_id = ?", postID).Count(&count)
+	db := r.db.MasterDB.WithContext(ctx).Table(postsTable).
+		Where("parent_id = ?", postID).
+		Where(hiddenAuthorsCondition("user_id"), hiddenAuthorsParams(viewer)...).
+		Count(&count)
 	if db.Error != nil {
 		log.Errorf("[GetCommentSpecificReplyCount] Error while fetching count of replies for comment id: %s from db with error: %+v", postID, db.Error)
 		return 0, db.Error
@@ -513,7 +734,7 @@ func (r *repo) GetPostByPostId(ctx context.Context, postId string) (*common.AllR
 	var post *common.AllRepliesPost
 	db := r.db.MasterDB.WithContext(ctx).
 		Table("posts as p").
-		Select("p.id as post_id, p.content as content, p.type as type, p.like_count as like_count, p.status as status, p.created_at as created_at,p.updated_at as updated_at, p.user_id as user_id, u.first_name as user_name, u.profile_image_url as profile_image_url, u.user_phone, p.bookmark_count as bookmark_count").
+		Select("p.id as post_id, p.channel_id as channel_id, p.content as content, p.type as type, p.like_count as like_count, p.status as status, p.created_at as created_at,p.updated_at as updated_at, p.user_id as user_id, u.first_name as user_name, u.profile_image_url as profile_image_url, u.user_phone, p.bookmark_count as bookmark_count, p.accepted_answer_id as accepted_answer_id, p.quoted_post_id as quoted_post_id, p.quote_count as quote_count").
 		Joins("left join user_details u on p.user_id = u.user_id").
 		Where("p.id = ?", postId).
 		Scan(&post)
@@ -524,14 +745,15 @@ func (r *repo) GetPostByPostId(ctx context.Context, postId string) (*common.AllR
 	return post, nil
 }
 
-func (r *repo) GetAllRepliesOnPost(ctx context.Context, postId string, channelID string, limit int, currentPage int, sortBy string) ([]model.ReplyPost, error) {
+func (r *repo) GetAllRepliesOnPost(ctx context.Context, postId string, channelID string, limit int, currentPage int, sortBy string, viewer model.Viewer) ([]model.ReplyPost, error) {
 	log := logger.GetLogInstance(ctx, "GetAllRepliesOnPost-repo")
 	orderClause := `
     CASE 
         WHEN p.status = 'DELETED' THEN 1
         ELSE 0
     END, 
-    p.updated_at DESC`
+    p.updated_at DESC,
+    p.id DESC`
 	if sortBy == common.IDEAS_BASED_FLOW {
 		orderClause = `
 		CASE 
@@ -539,13 +761,16 @@ func (r *repo) GetAllRepliesOnPost(ctx context.Context, postId string, channelID
 		ELSE 1 
 		END, ` + orderClause
 	}
+	// a question's accepted answer is always listed first
+	orderClause = fmt.Sprintf(acceptedSortKey, "p.") + ", " + orderClause
 	var results []model.ReplyPost
 	offset := (currentPage - 1) * limit
 	db := r.db.MasterDB.WithContext(ctx).
 		Table("posts as p").
-		Select("p.id as id, p.content as content, p.type as type, p.like_count as like_count, p.status as status, p.created_at as created_at, p.updated_at as updated_at, u.first_name as first_name, u.middle_name as middle_name, u.last_name as last_name, u.profile_image_url as profile_image_url, p.user_id as user_id, u.user_phone").
+		Select("p.id as id, p.content as content, p.type as type, p.like_count as like_count, p.status as status, p.is_pinned as is_pinned, p.is_accepted_answer as is_accepted_answer, p.quoted_post_id as quoted_post_id, p.quote_count as quote_count, p.parent_id as parent_id, p.depth as depth, p.reply_count as reply_count, p.created_at as created_at, p.updated_at as updated_at, u.first_name as first_name, u.middle_name as middle_name, u.last_name as last_name, u.profile_image_url as profile_image_url, p.user_id as user_id, u.user_phone").
 		Joins("left join user_details u on p.user_id = u.user_id").
 		Where("p.parent_id = ?", postId).
+		Where(hiddenAuthorsCondition("p.user_id"), hiddenAuthorsParams(viewer)...).
 		Order(orderClause).
 		Limit(int(limit)).
 		Offset(int(offset)).
@@ -558,3 +783,56 @@ func (r *repo) GetAllRepliesOnPost(ctx context.Context, postId string, channelID
 
 	return results, nil
 }
+
+// postScoresExpr recomputes the precomputed sort scores of a post from its
+// counters. hot_score grows with the log of likes and replies plus a term for
+// creation time, so newer posts overtake older ones without a background job.
+// controversy_score is high when reactions and reports are both large and
+// close to each other.
+const postScoresExpr = `
+	hot_score = LOG(GREATEST(like_count + 2 * reply_count, 1)) + EXTRACT(EPOCH FROM created_at) / 45000,
+	controversy_score = CASE
+		WHEN like_count + reply_count > 0 AND report_count > 0 THEN
+			POWER(like_count + reply_count + report_count, LEAST(like_count + reply_count, report_count)::float8 / GREATEST(like_count + reply_count, report_count))
+		ELSE 0
+	END`
+
+func refreshPostScores(tx *gorm.DB, postID int64) error {
+	if err := tx.Exec(`UPDATE posts SET `+postScoresExpr+` WHERE id = ?`, postID).Error; err != nil {
+		return fmt.Errorf("refresh scores for post %d failed: %w", postID, err)
+	}
+	return nil
+}
+
+// incrementPostCounter bumps one of the posts counters and refreshes the
+// scores depending on it.
+func incrementPostCounter(tx *gorm.DB, postID int64, column string) error {
+	if err := tx.Table(postsTable).Where("id = ?", postID).Update(column, gorm.Expr(column+" + ?", 1)).Error; err != nil {
+		return fmt.Errorf("increment %s for post %d failed: %w", column, postID, err)
+	}
+	return refreshPostScores(tx, postID)
+}
+
+// addOutboxEvent records a domain event on the given transaction so that it is
+// committed, or rolled back, together with the change it describes.
+func addOutboxEvent(tx *gorm.DB, eventType string, aggregateType string, aggregateID int64, payload interface{}) error {
+	event, err := events.NewOutboxEvent(eventType, aggregateType, strconv.FormatInt(aggregateID, 10), payload)
+	if err != nil {
+		return err
+	}
+	if err := tx.Table(outboxTable).Create(event).Error; err != nil {
+		return fmt.Errorf("insert outbox event %s failed: %w", eventType, err)
+	}
+	return nil
+}
+
+func postEventPayload(post dbModel.Post) events.PostPayload {
+	return events.PostPayload{
+		PostID:    post.ID,
+		ChannelID: post.ChannelID,
+		UserID:    post.UserID,
+		ParentID:  post.ParentID,
+		Type:      post.Type,
+		Status:    post.Status,
+	}
+}
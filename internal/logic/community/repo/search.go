@@ -0,0 +1,112 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+)
+
+const searchHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2"
+
+// SearchPosts runs a full text query against posts.search_vector. Results are
+// ordered by rank and then id so that the (rank, id) pair of the last row can
+// be used as a keyset cursor.
+func (r *repo) SearchPosts(ctx context.Context, filter model.SearchFilter) ([]model.SearchResult, error) {
+	log := logger.GetLogInstance(ctx, "SearchPosts-repo")
+
+	conditions := []string{
+		"p.search_vector @@ q.query",
+		"p.status NOT IN (?, ?, ?)",
+	}
+	params := []interface{}{filter.Query, common.POST_STATUS_DELETED, common.POST_STATUS_HIDDEN, common.POST_STATUS_MERGED}
+
+	// posts of members-only channels are only found by those who can read them
+	conditions = append(conditions, readableChannelsCondition("p.channel_id"))
+	params = append(params, filter.Viewer, filter.Viewer)
+
+	// posts of shadow banned users are only found by their authors
+	conditions = append(conditions, "(p.user_id = ? OR p.user_id NOT IN ("+shadowBannedUsersQuery+"))")
+	params = append(params, filter.Viewer)
+
+	if filter.ChannelID != "" {
+		conditions = append(conditions, "p.channel_id = ?")
+		params = append(params, filter.ChannelID)
+	}
+	if filter.ForumID != 0 {
+		conditions = append(conditions, "p.channel_id IN (SELECT channel_id FROM forum_event_links WHERE forum_id = ?)")
+		params = append(params, filter.ForumID)
+	}
+	if filter.AuthorID != "" {
+		conditions = append(conditions, "p.user_id = ?")
+		params = append(params, filter.AuthorID)
+	}
+	if filter.Type != "" {
+		conditions = append(conditions, "p.type = ?")
+		params = append(params, filter.Type)
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "p.created_at >= ?")
+		params = append(params, filter.From)
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "p.created_at <= ?")
+		params = append(params, filter.To)
+	}
+
+	cursorClause := ""
+	if filter.Cursor != nil {
+		cursorClause = "WHERE m.rank < ? OR (m.rank = ? AND m.id < ?)"
+		params = append(params, filter.Cursor.Rank, filter.Cursor.Rank, filter.Cursor.ID)
+	}
+	params = append(params, filter.Limit)
+
+	var results []model.SearchResult
+	db := r.db.MasterDB.WithContext(ctx).Raw(`
+	WITH matches AS (
+		SELECT
+			p.id,
+			p.channel_id,
+			p.parent_id,
+			p.user_id,
+			p.type,
+			p.status,
+			p.like_count,
+			p.content,
+			p.created_at,
+			p.updated_at,
+			ts_rank(p.search_vector, q.query)::float8 AS rank,
+			q.query
+		FROM posts p, websearch_to_tsquery('english', ?) AS q(query)
+		WHERE `+strings.Join(conditions, " AND ")+`
+	)
+	SELECT
+		m.id,
+		m.channel_id,
+		m.parent_id,
+		m.user_id,
+		m.type,
+		m.status,
+		m.like_count,
+		m.rank,
+		ts_headline('english', m.content, m.query, '`+searchHeadlineOptions+`') AS snippet,
+		m.created_at,
+		m.updated_at,
+		ud.first_name,
+		ud.middle_name,
+		ud.last_name,
+		ud.profile_image_url
+	FROM matches m
+	LEFT JOIN user_details ud ON ud.user_id = m.user_id
+	`+cursorClause+`
+	ORDER BY m.rank DESC, m.id DESC
+	LIMIT ?`, params...).Scan(&results)
+	if db.Error != nil {
+		log.Errorf("[SearchPostsRepo] error while searching posts for query: %s, err: %v", filter.Query, db.Error)
+		return nil, fmt.Errorf("searchPosts query failed: %w", db.Error)
+	}
+	return results, nil
+}
@@ -0,0 +1,137 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	"github.com/Abhishekjha321/community_service/internal/richtext"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	postTagsTable    = "post_tags"
+	channelTagsTable = "channel_tags"
+)
+
+// SavePostTags stores the hashtags of a newly created post.
+func (r *repo) SavePostTags(ctx context.Context, post dbModel.Post, tags []string) error {
+	log := logger.GetLogInstance(ctx, "SavePostTags")
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replacePostTags(tx, post, tags)
+	})
+	if err != nil {
+		log.Errorf("[SavePostTags] unable to save tags of post id: %d with error: %v", post.ID, err)
+		return err
+	}
+	return nil
+}
+
+// replacePostTags makes tags the hashtags of post and moves the channel tag
+// counters by the difference with its previous tags. Passing no tags removes
+// them all, which is what happens when a post is deleted.
+func replacePostTags(tx *gorm.DB, post dbModel.Post, tags []string) error {
+	var previous []string
+	if err := tx.Table(postTagsTable).Where("post_id = ?", post.ID).Pluck("tag", &previous).Error; err != nil {
+		return err
+	}
+	kept := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		kept[tag] = true
+	}
+	existing := make(map[string]bool, len(previous))
+	var removed []string
+	for _, tag := range previous {
+		existing[tag] = true
+		if !kept[tag] {
+			removed = append(removed, tag)
+		}
+	}
+	var added []dbModel.PostTag
+	for _, tag := range tags {
+		if !existing[tag] {
+			added = append(added, dbModel.PostTag{PostID: post.ID, Tag: tag, ChannelID: post.ChannelID})
+		}
+	}
+
+	if len(removed) > 0 {
+		if err := tx.Table(postTagsTable).Where("post_id = ? AND tag IN ?", post.ID, removed).Delete(&dbModel.PostTag{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Table(channelTagsTable).Where("channel_id = ? AND tag IN ?", post.ChannelID, removed).
+			Update("post_count", gorm.Expr("GREATEST(post_count - 1, 0)")).Error; err != nil {
+			return err
+		}
+	}
+	if len(added) == 0 {
+		return nil
+	}
+	if err := tx.Table(postTagsTable).Create(&added).Error; err != nil {
+		return err
+	}
+	now := time.Now()
+	counters := make([]dbModel.ChannelTag, 0, len(added))
+	for _, postTag := range added {
+		counters = append(counters, dbModel.ChannelTag{ChannelID: post.ChannelID, Tag: postTag.Tag, PostCount: 1, LastUsedAt: now})
+	}
+	return tx.Table(channelTagsTable).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "channel_id"}, {Name: "tag"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"post_count":   gorm.Expr(channelTagsTable + ".post_count + 1"),
+			"last_used_at": now,
+		}),
+	}).Create(&counters).Error
+}
+
+// countedTags returns tags if a post with the given status counts towards the
+// channel tag counters. Only published posts do, so hidden, merged and
+// deleted posts keep no tags.
+func countedTags(status string, tags []string) []string {
+	if status != common.POST_STATUS_PUBLISHED {
+		return nil
+	}
+	return tags
+}
+
+// restorePostTags gives post back the hashtags of its content once it is
+// published again.
+func restorePostTags(tx *gorm.DB, post dbModel.Post) error {
+	return replacePostTags(tx, post, countedTags(post.Status, richtext.Tags(post.Content)))
+}
+
+// GetPopularTags ranks the tags used across the given channels by how many
+// live posts use them.
+func (r *repo) GetPopularTags(ctx context.Context, channelIDs []string, limit int) ([]model.TagCount, error) {
+	log := logger.GetLogInstance(ctx, "GetPopularTags")
+	var tags []model.TagCount
+	if len(channelIDs) == 0 {
+		return tags, nil
+	}
+	db := r.db.MasterDB.WithContext(ctx).Table(channelTagsTable).
+		Select("tag, SUM(post_count) AS post_count, MAX(last_used_at) AS last_used_at").
+		Where("channel_id IN ? AND post_count > 0", channelIDs).
+		Group("tag").
+		Order("post_count DESC, last_used_at DESC").
+		Limit(limit).
+		Scan(&tags)
+	if db.Error != nil {
+		log.Errorf("[GetPopularTags] unable to fetch popular tags with error: %v", db.Error)
+		return nil, db.Error
+	}
+	return tags, nil
+}
+
+func (r *repo) GetChannelIDsByForumID(ctx context.Context, forumID int64) ([]string, error) {
+	log := logger.GetLogInstance(ctx, "GetChannelIDsByForumID")
+	var channelIDs []string
+	db := r.db.MasterDB.WithContext(ctx).Table(forumEventLinksTable).Where("forum_id = ?", forumID).Pluck("channel_id", &channelIDs)
+	if db.Error != nil {
+		log.Errorf("[GetChannelIDsByForumID] unable to fetch channels of forum id: %d with error: %v", forumID, db.Error)
+		return nil, db.Error
+	}
+	return channelIDs, nil
+}
@@ -0,0 +1,26 @@
+package repo
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+)
+
+func TestCountedTags(t *testing.T) {
+	tags := []string{"go", "rust"}
+	tests := []struct {
+		status string
+		want   []string
+	}{
+		{status: common.POST_STATUS_PUBLISHED, want: tags},
+		{status: common.POST_STATUS_HIDDEN},
+		{status: common.POST_STATUS_MERGED},
+		{status: common.POST_STATUS_DELETED},
+	}
+	for _, tt := range tests {
+		if got := countedTags(tt.status, tags); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("countedTags(%q) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"gorm.io/gorm"
+)
+
+const threadPostColumns = "p.id as id, p.channel_id as channel_id, p.parent_id as parent_id, p.depth as depth, p.content as content, p.type as type, p.like_count as like_count, p.reply_count as reply_count, p.status as status, p.is_pinned as is_pinned, p.is_accepted_answer as is_accepted_answer, p.quoted_post_id as quoted_post_id, p.quote_count as quote_count, p.created_at as created_at, p.updated_at as updated_at, u.first_name as first_name, u.middle_name as middle_name, u.last_name as last_name, u.profile_image_url as profile_image_url, p.user_id as user_id, u.user_phone"
+
+// GetThreadPost loads the post a thread is opened on, in the shape of its
+// replies. It returns gorm.ErrRecordNotFound when the post doesn't exist.
+func (r *repo) GetThreadPost(ctx context.Context, postID int64) (*model.ReplyPost, error) {
+	log := logger.GetLogInstance(ctx, "GetThreadPost")
+	var posts []model.ReplyPost
+	db := r.db.MasterDB.WithContext(ctx).
+		Table("posts as p").
+		Select(threadPostColumns).
+		Joins("left join user_details u on p.user_id = u.user_id").
+		Where("p.id = ?", postID).
+		Scan(&posts)
+	if db.Error != nil {
+		log.Errorf("[GetThreadPost] unable to fetch post id: %d with error: %v", postID, db.Error)
+		return nil, fmt.Errorf("getThreadPost query failed: %w", db.Error)
+	}
+	if len(posts) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &posts[0], nil
+}
+
+// GetThreadReplies returns up to perParent direct replies of each of the given
+// posts, oldest first, leaving out authors hidden from the viewer. With a
+// cursor only replies of cursor.ParentID coming after the cursor are read,
+// which is how a single branch is paged.
+func (r *repo) GetThreadReplies(ctx context.Context, parentIDs []int64, perParent int, viewer model.Viewer, cursor *model.ThreadCursor) ([]model.ReplyPost, error) {
+	log := logger.GetLogInstance(ctx, "GetThreadReplies")
+	var replies []model.ReplyPost
+	if len(parentIDs) == 0 {
+		return replies, nil
+	}
+	filter := "p.parent_id IN ?"
+	params := []interface{}{parentIDs}
+	if cursor != nil {
+		filter = "p.parent_id = ? AND (p.created_at, p.id) > (?, ?)"
+		params = []interface{}{cursor.ParentID, cursor.CreatedAt, cursor.ID}
+	}
+	filter += " AND " + hiddenAuthorsCondition("p.user_id")
+	params = append(append(params, hiddenAuthorsParams(viewer)...), perParent)
+	db := r.db.MasterDB.WithContext(ctx).Raw(`
+		SELECT * FROM (
+			SELECT `+threadPostColumns+`,
+				ROW_NUMBER() OVER (PARTITION BY p.parent_id ORDER BY p.created_at, p.id) AS branch_rank
+			FROM posts p
+			LEFT JOIN user_details u ON p.user_id = u.user_id
+			WHERE `+filter+`
+		) branch
+		WHERE branch_rank <= ?
+		ORDER BY parent_id, created_at, id
+	`, params...).Scan(&replies)
+	if db.Error != nil {
+		log.Errorf("[GetThreadReplies] unable to fetch replies of post ids: %v with error: %v", parentIDs, db.Error)
+		return nil, fmt.Errorf("getThreadReplies query failed: %w", db.Error)
+	}
+	return replies, nil
+}
+
+// setSubtreeRoot makes rootID the root of the given replies and of every
+// reply below them. Merges use it when they move replies between comments.
+func setSubtreeRoot(tx *gorm.DB, replyIDs []int64, rootID int64) error {
+	if len(replyIDs) == 0 {
+		return nil
+	}
+	res := tx.Exec(`
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM posts WHERE id IN ?
+			UNION ALL
+			SELECT p.id FROM posts p JOIN subtree s ON p.parent_id = s.id
+		)
+		UPDATE posts SET root_id = ? WHERE id IN (SELECT id FROM subtree)`, replyIDs, rootID)
+	if res.Error != nil {
+		return fmt.Errorf("set root of replies %v failed: %w", replyIDs, res.Error)
+	}
+	return nil
+}
+
+// GetUserLikedPostIDs returns which of the given posts the user likes, so a
+// whole thread can be decorated with a single query.
+func (r *repo) GetUserLikedPostIDs(ctx context.Context, userID string, postIDs []int64) (map[int64]bool, error) {
+	log := logger.GetLogInstance(ctx, "GetUserLikedPostIDs")
+	liked := make(map[int64]bool)
+	if userID == "" || len(postIDs) == 0 {
+		return liked, nil
+	}
+	var likedIDs []int64
+	db := r.db.MasterDB.WithContext(ctx).Table(userActionsTable).
+		Where("user_id = ? AND post_id IN ? AND action = ? AND value = true", userID, postIDs, like).
+		Pluck("post_id", &likedIDs)
+	if db.Error != nil {
+		log.Errorf("[GetUserLikedPostIDs] unable to fetch likes of user id: %s with error: %v", userID, db.Error)
+		return liked, db.Error
+	}
+	for _, id := range likedIDs {
+		liked[id] = true
+	}
+	return liked, nil
+}
@@ -0,0 +1,46 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+)
+
+const forumEventLinksTable = "forum_event_links"
+
+func (r *repo) GetForumIDsByChannelID(ctx context.Context, channelID string) ([]int64, error) {
+	log := logger.GetLogInstance(ctx, "GetForumIDsByChannelID")
+	var forumIDs []int64
+	db := r.db.MasterDB.WithContext(ctx).Table(forumEventLinksTable).Where("channel_id = ?", channelID).Pluck("forum_id", &forumIDs)
+	if db.Error != nil {
+		log.Errorf("[GetForumIDsByChannelID] unable to fetch forums for channel id: %s with error: %v", channelID, db.Error)
+		return nil, db.Error
+	}
+	return forumIDs, nil
+}
+
+// GetTrendingPostsByIDs loads the posts picked by the trending sets. Posts
+// deleted, hidden or merged since they were scored, and posts of shadow banned
+// users, are left out; the order of the result is not defined.
+func (r *repo) GetTrendingPostsByIDs(ctx context.Context, postIDs []int64) ([]model.TrendingPost, error) {
+	log := logger.GetLogInstance(ctx, "GetTrendingPostsByIDs")
+	var posts []model.TrendingPost
+	if len(postIDs) == 0 {
+		return posts, nil
+	}
+	db := r.db.MasterDB.WithContext(ctx).
+		Table("posts as p").
+		Select("p.id, p.channel_id, p.user_id, p.content, p.like_count, p.bookmark_count, p.reply_count, p.created_at, u.first_name, u.middle_name, u.last_name, u.profile_image_url").
+		Joins("left join user_details u on p.user_id = u.user_id").
+		Where("p.id IN ? AND p.status NOT IN ?", postIDs, []string{common.POST_STATUS_DELETED, common.POST_STATUS_HIDDEN, common.POST_STATUS_MERGED}).
+		Where("p.user_id NOT IN (" + shadowBannedUsersQuery + ")").
+		Scan(&posts)
+	if db.Error != nil {
+		log.Errorf("[GetTrendingPostsByIDs] unable to fetch trending posts with error: %v", db.Error)
+		return nil, fmt.Errorf("getTrendingPostsByIDs query failed: %w", db.Error)
+	}
+	return posts, nil
+}
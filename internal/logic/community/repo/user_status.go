@@ -0,0 +1,96 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const userStatusesTable = "user_statuses"
+
+// shadowBannedUsersQuery selects the users whose posts are hidden from
+// everyone else.
+const shadowBannedUsersQuery = "SELECT user_id FROM " + userStatusesTable + " WHERE status = '" + common.USER_STATUS_SHADOW_BANNED + "'"
+
+// GetUserStatus returns the status of a user, or nil when none was set.
+func (r *repo) GetUserStatus(ctx context.Context, userID string) (*dbModel.UserStatus, error) {
+	log := logger.GetLogInstance(ctx, "GetUserStatus")
+	var status dbModel.UserStatus
+	err := r.db.MasterDB.WithContext(ctx).Table(userStatusesTable).Where("user_id = ?", userID).First(&status).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Errorf("[GetUserStatus] unable to fetch status of user id: %s with error: %v", userID, err)
+		return nil, err
+	}
+	return &status, nil
+}
+
+// auditUserStatus is the status of a user kept in the audit log.
+type auditUserStatus struct {
+	Status string `json:"status"`
+}
+
+// SetUserStatus changes the status of a user, adding it when the user had
+// none. Users without a status are active.
+func (r *repo) SetUserStatus(ctx context.Context, userID string, status string, actor model.AuditActor) error {
+	log := logger.GetLogInstance(ctx, "SetUserStatus")
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current []dbModel.UserStatus
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Table(userStatusesTable).Where("user_id = ?", userID).Find(&current).Error; err != nil {
+			return err
+		}
+		previous := common.USER_STATUS_ACTIVE
+		if len(current) > 0 && current[0].Status != "" {
+			previous = current[0].Status
+		}
+		now := time.Now()
+		if len(current) > 0 {
+			err := tx.Table(userStatusesTable).Where("user_id = ?", userID).
+				Updates(map[string]interface{}{"status": status, "updated_at": now}).Error
+			if err != nil {
+				return err
+			}
+		} else {
+			err := tx.Table(userStatusesTable).Create(&dbModel.UserStatus{
+				UserID:    userID,
+				Status:    status,
+				CreatedAt: now,
+				UpdatedAt: now,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		if previous == status {
+			return nil
+		}
+		return addAuditLog(tx, actor, dbModel.AuditLog{
+			Action:     common.AUDIT_ACTION_USER_STATUS,
+			TargetType: common.AUDIT_TARGET_USER,
+			TargetID:   userID,
+		}, auditUserStatus{Status: previous}, auditUserStatus{Status: status})
+	})
+	if err != nil {
+		log.Errorf("[SetUserStatus] unable to set status: %s of user id: %s with error: %v", status, userID, err)
+		return err
+	}
+	return nil
+}
+
+// isShadowBanned tells within a transaction whether a user is shadow banned.
+func isShadowBanned(tx *gorm.DB, userID string) (bool, error) {
+	var count int64
+	err := tx.Table(userStatusesTable).
+		Where("user_id = ? AND status = ?", userID, common.USER_STATUS_SHADOW_BANNED).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,140 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Abhishekjha321/community_service/internal/events"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const postVotesTable = "post_votes"
+
+// VotePost sets userID's vote on a post to value (1, -1, or 0 to withdraw it)
+// and moves the post's counters by the difference to the previous vote. Votes
+// cast while shadow banned are kept but not counted. The post row is locked
+// so concurrent votes on the same post apply one by one.
+func (r *repo) VotePost(ctx context.Context, postID int64, userID string, value int) (*dbModel.Post, error) {
+	log := logger.GetLogInstance(ctx, "VotePost")
+	var post dbModel.Post
+	err := r.db.MasterDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Table(postsTable).Where("id = ?", postID).First(&post).Error; err != nil {
+			return err
+		}
+
+		var vote dbModel.PostVote
+		previous := 0
+		err := tx.Table(postVotesTable).Where("post_id = ? AND user_id = ?", postID, userID).First(&vote).Error
+		switch {
+		case err == nil:
+			previous = vote.Value
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+		if previous == value {
+			return nil
+		}
+		shadowBanned, err := isShadowBanned(tx, userID)
+		if err != nil {
+			return err
+		}
+		counted := !shadowBanned
+
+		switch {
+		case value == 0:
+			err = tx.Table(postVotesTable).Where("id = ?", vote.ID).Delete(&dbModel.PostVote{}).Error
+		case previous == 0:
+			err = tx.Create(&dbModel.PostVote{PostID: postID, UserID: userID, Value: value, Counted: &counted}).Error
+		default:
+			err = tx.Table(postVotesTable).Where("id = ?", vote.ID).Updates(map[string]interface{}{"value": value, "counted": counted}).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		delta := countedVoteDelta(previous, vote.Counted == nil || *vote.Counted, value, counted)
+		if delta != (voteDelta{}) {
+			res := tx.Table(postsTable).Where("id = ?", postID).Updates(map[string]interface{}{
+				"vote_score":     gorm.Expr("vote_score + ?", delta.score),
+				"upvote_count":   gorm.Expr("upvote_count + ?", delta.upvotes),
+				"downvote_count": gorm.Expr("downvote_count + ?", delta.downvotes),
+			})
+			if res.Error != nil {
+				return fmt.Errorf("update vote counters for post %d failed: %w", postID, res.Error)
+			}
+			post.VoteScore += delta.score
+			post.UpvoteCount += delta.upvotes
+			post.DownvoteCount += delta.downvotes
+		}
+
+		return addOutboxEvent(tx, events.PostVoted, events.AggregatePost, postID, events.VotePayload{
+			PostID:        postID,
+			UserID:        userID,
+			Value:         value,
+			PreviousValue: previous,
+		})
+	})
+	if err != nil {
+		log.Errorf("[VotePost] unable to vote on post id: %d for user id: %s with error: %v", postID, userID, err)
+		return nil, err
+	}
+	return &post, nil
+}
+
+// voteDelta is how a vote change moves a post's counters.
+type voteDelta struct {
+	score     int64
+	upvotes   int64
+	downvotes int64
+}
+
+// countedVoteDelta takes the previous vote out of the counters if it was
+// counted and puts the new one in if it is.
+func countedVoteDelta(previous int, previousCounted bool, value int, counted bool) voteDelta {
+	var delta voteDelta
+	if previousCounted {
+		upvotes, downvotes := voteCounts(previous)
+		delta = voteDelta{score: -int64(previous), upvotes: -upvotes, downvotes: -downvotes}
+	}
+	if counted {
+		upvotes, downvotes := voteCounts(value)
+		delta.score += int64(value)
+		delta.upvotes += upvotes
+		delta.downvotes += downvotes
+	}
+	return delta
+}
+
+func voteCounts(value int) (upvotes int64, downvotes int64) {
+	switch value {
+	case 1:
+		return 1, 0
+	case -1:
+		return 0, 1
+	}
+	return 0, 0
+}
+
+// GetUserVotes returns userID's votes on the given posts keyed by post id;
+// posts without a vote are absent from the map.
+func (r *repo) GetUserVotes(ctx context.Context, userID string, postIDs []int64) (map[int64]int, error) {
+	log := logger.GetLogInstance(ctx, "GetUserVotes")
+	votes := make(map[int64]int)
+	if userID == "" || len(postIDs) == 0 {
+		return votes, nil
+	}
+	var rows []dbModel.PostVote
+	db := r.db.MasterDB.WithContext(ctx).Table(postVotesTable).Where("user_id = ? AND post_id IN ?", userID, postIDs).Find(&rows)
+	if db.Error != nil {
+		log.Errorf("[GetUserVotes] unable to fetch votes of user id: %s with error: %v", userID, db.Error)
+		return votes, db.Error
+	}
+	for _, row := range rows {
+		votes[row.PostID] = row.Value
+	}
+	return votes, nil
+}
@@ -0,0 +1,30 @@
+package repo
+
+import "testing"
+
+func TestCountedVoteDelta(t *testing.T) {
+	tests := []struct {
+		name            string
+		previous        int
+		previousCounted bool
+		value           int
+		counted         bool
+		want            voteDelta
+	}{
+		{name: "new upvote", previousCounted: true, value: 1, counted: true, want: voteDelta{score: 1, upvotes: 1}},
+		{name: "upvote to downvote", previous: 1, previousCounted: true, value: -1, counted: true, want: voteDelta{score: -2, upvotes: -1, downvotes: 1}},
+		{name: "withdraw downvote", previous: -1, previousCounted: true, counted: true, want: voteDelta{score: 1, downvotes: -1}},
+		{name: "new vote while shadow banned", previousCounted: true, value: 1},
+		{name: "change an uncounted vote while shadow banned", previous: 1, value: -1},
+		{name: "withdraw a vote cast while shadow banned", previous: 1, counted: true},
+		{name: "change an uncounted vote after the ban", previous: -1, value: 1, counted: true, want: voteDelta{score: 1, upvotes: 1}},
+		{name: "change a counted vote while shadow banned", previous: 1, previousCounted: true, value: -1, want: voteDelta{score: -1, upvotes: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := countedVoteDelta(tt.previous, tt.previousCounted, tt.value, tt.counted); got != tt.want {
+				t.Errorf("countedVoteDelta() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"gorm.io/gorm"
+)
+
+// AcceptAnswer marks a reply as the accepted answer of a question in a Q&A
+// channel, or clears it when AnswerID is 0. Only the question's author or a
+// moderator of the channel may do so.
+func (s *service) AcceptAnswer(ctx context.Context, requestBody *dto.RequestAcceptAnswer, userID string) (*dto.ResponseAcceptAnswer, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "AcceptAnswerService")
+
+	question, exp := s.getLivePost(ctx, requestBody.QuestionID)
+	if exp != nil {
+		return nil, exp
+	}
+	if question.Type != common.POST_TYPE_COMMENT {
+		log.Errorf("[AcceptAnswerService] post id: %d is not a question", question.ID)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode)
+	}
+	if exp := s.checkChannelRead(ctx, question.ChannelID, userID); exp != nil {
+		return nil, exp
+	}
+
+	settings, err := s.repo.GetChannelSettings(ctx, question.ChannelID)
+	if err != nil {
+		log.Errorf("[AcceptAnswerService] couldn't fetch settings for channel id: %s, err: %v", question.ChannelID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if settings.ChannelMode != common.CHANNEL_MODE_QA {
+		log.Errorf("[AcceptAnswerService] channel id: %s is not a Q&A channel", question.ChannelID)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.NotQAChannelErrorCode)
+	}
+
+	if question.UserID != userID {
+		isModerator, err := s.repo.IsChannelModerator(ctx, question.ChannelID, userID)
+		if err != nil {
+			log.Errorf("[AcceptAnswerService] couldn't check moderator for user id: %s, err: %v", userID, err)
+			return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+		}
+		if !isModerator {
+			log.Errorf("[AcceptAnswerService] user id: %s can't accept answers on question id: %d", userID, question.ID)
+			return nil, exceptions.GetExceptionByErrorCode(exceptions.AccessDeniedErrorCode)
+		}
+	}
+
+	if requestBody.AnswerID != 0 {
+		answer, exp := s.getLivePost(ctx, requestBody.AnswerID)
+		if exp != nil {
+			return nil, exp
+		}
+		if answer.ParentID != question.ID {
+			log.Errorf("[AcceptAnswerService] post id: %d is not a reply to question id: %d", answer.ID, question.ID)
+			return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Answer is not a reply to the question")
+		}
+	}
+
+	updated, err := s.repo.SetAcceptedAnswer(ctx, question.ID, requestBody.AnswerID, userID)
+	if err != nil {
+		log.Errorf("[AcceptAnswerService] couldn't set accepted answer of question id: %d, err: %v", question.ID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+
+	return &dto.ResponseAcceptAnswer{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data: dto.ResponseAcceptAnswerData{
+			QuestionID:       updated.ID,
+			AcceptedAnswerID: updated.AcceptedAnswerID,
+		},
+	}, nil
+}
+
+// getLivePost fetches a post that can still be acted on, i.e. one that is
+// neither deleted nor merged into another post.
+func (s *service) getLivePost(ctx context.Context, postID int64) (*common.Post, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "GetLivePost")
+	post, err := s.repo.CheckPostIDValidity(ctx, strconv.FormatInt(postID, 10), "")
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode)
+		}
+		log.Errorf("[GetLivePost] couldn't fetch post id: %d, err: %v", postID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if post.ID == 0 {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode)
+	}
+	if post.Status == deleted {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.DeletedPostErrorCode)
+	}
+	if post.Status == common.POST_STATUS_MERGED {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.MergedPostErrorCode)
+	}
+	return &post, nil
+}
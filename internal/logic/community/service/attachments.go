@@ -0,0 +1,370 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	model "github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	"github.com/Abhishekjha321/community_service/internal/media"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"github.com/Abhishekjha321/community_service/storage/objectstore"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultMaxUploadSize  = 10 << 20
+	maxPostAttachments    = 10
+	maxAttachmentNameSize = 100
+	attachmentKeyPrefix   = "attachments"
+	thumbnailFileName     = "thumbnail.jpg"
+)
+
+// UploadAttachment stores a file sent through the service and returns it
+// ready to be linked to a post.
+func (s *service) UploadAttachment(ctx context.Context, userID string, fileName string, body io.Reader, size int64) (*dto.ResponseAttachment, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "UploadAttachmentService")
+
+	if size > s.maxUploadSize {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.AttachmentTooLargeErrorCode)
+	}
+	data, exp := s.readAttachment(body)
+	if exp != nil {
+		return nil, exp
+	}
+	attachment := &dbModel.Attachment{
+		UserID:     userID,
+		StorageKey: newAttachmentKey(fileName),
+		FileName:   attachmentFileName(fileName),
+		Status:     common.ATTACHMENT_STATUS_PENDING,
+	}
+	if exp := s.inspectAttachment(ctx, attachment, data); exp != nil {
+		return nil, exp
+	}
+	if err := s.objectStore.Put(ctx, attachment.StorageKey, bytes.NewReader(data), int64(len(data)), attachment.ContentType); err != nil {
+		log.Errorf("[UploadAttachmentService] couldn't store attachment of user id: %s, err: %v", userID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	attachment.Status = common.ATTACHMENT_STATUS_READY
+	if err := s.repo.InsertAttachment(ctx, attachment); err != nil {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return &dto.ResponseAttachment{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data:    s.buildAttachmentData(*attachment),
+	}, nil
+}
+
+// CreateAttachmentUploadURL registers a pending attachment and returns a
+// signed URL the client uploads the file to directly. The attachment becomes
+// usable once CompleteAttachmentUpload has inspected the stored file.
+func (s *service) CreateAttachmentUploadURL(ctx context.Context, requestBody *dto.RequestAttachmentUploadURL, userID string) (*dto.ResponseAttachmentUploadURL, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "CreateAttachmentUploadURLService")
+
+	if requestBody.Size > s.maxUploadSize {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.AttachmentTooLargeErrorCode)
+	}
+	contentType := requestBody.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	attachment := &dbModel.Attachment{
+		UserID:      userID,
+		StorageKey:  newAttachmentKey(requestBody.FileName),
+		FileName:    attachmentFileName(requestBody.FileName),
+		ContentType: contentType,
+		Size:        requestBody.Size,
+		Status:      common.ATTACHMENT_STATUS_PENDING,
+	}
+	uploadURL, expiresAt, err := s.objectStore.SignedUploadURL(ctx, attachment.StorageKey, contentType)
+	if err != nil {
+		log.Errorf("[CreateAttachmentUploadURLService] couldn't sign upload url for user id: %s, err: %v", userID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if err := s.repo.InsertAttachment(ctx, attachment); err != nil {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return &dto.ResponseAttachmentUploadURL{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data: dto.ResponseAttachmentUploadURLData{
+			AttachmentID: attachment.ID,
+			UploadURL:    uploadURL,
+			Method:       http.MethodPut,
+			ContentType:  contentType,
+			ExpiresAt:    fmt.Sprint(expiresAt.Unix()),
+		},
+	}, nil
+}
+
+// ReceiveSignedUpload stores the body of an upload made to a URL signed by
+// the local object store. Stores that sign URLs for another host don't send
+// uploads here.
+func (s *service) ReceiveSignedUpload(ctx context.Context, upload model.SignedUpload, body io.Reader, size int64) *exceptions.Exception {
+	log := logger.GetLogInstance(ctx, "ReceiveSignedUploadService")
+
+	verifier, ok := s.objectStore.(objectstore.UploadVerifier)
+	if !ok {
+		return exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Uploads aren't accepted by this service")
+	}
+	if err := verifier.VerifyUpload(upload.Key, upload.ContentType, upload.Expires, upload.Signature); err != nil {
+		log.Errorf("[ReceiveSignedUploadService] rejected upload of key: %s, err: %v", upload.Key, err)
+		return exceptions.GetExceptionByErrorCode(exceptions.AccessDeniedErrorCode)
+	}
+	if size > s.maxUploadSize {
+		return exceptions.GetExceptionByErrorCode(exceptions.AttachmentTooLargeErrorCode)
+	}
+	data, exp := s.readAttachment(body)
+	if exp != nil {
+		return exp
+	}
+	if err := s.objectStore.Put(ctx, upload.Key, bytes.NewReader(data), int64(len(data)), upload.ContentType); err != nil {
+		log.Errorf("[ReceiveSignedUploadService] couldn't store key: %s, err: %v", upload.Key, err)
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return nil
+}
+
+// CompleteAttachmentUpload inspects a file uploaded through a signed URL and
+// marks its attachment ready. Completing an attachment twice returns it as is.
+func (s *service) CompleteAttachmentUpload(ctx context.Context, requestBody *dto.RequestCompleteAttachmentUpload, userID string) (*dto.ResponseAttachment, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "CompleteAttachmentUploadService")
+
+	attachments, err := s.repo.GetAttachmentsByIDs(ctx, []int64{requestBody.AttachmentID})
+	if err != nil {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if len(attachments) == 0 || attachments[0].UserID != userID {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.InvalidAttachmentErrorCode)
+	}
+	attachment := attachments[0]
+	if attachment.Status == common.ATTACHMENT_STATUS_READY {
+		return &dto.ResponseAttachment{Code: APISuccessCode, Message: APISuccessMessage, Data: s.buildAttachmentData(attachment)}, nil
+	}
+
+	object, err := s.objectStore.Get(ctx, attachment.StorageKey)
+	if errors.Is(err, objectstore.ErrNotFound) {
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.InvalidAttachmentErrorCode, "Attachment hasn't been uploaded yet")
+	}
+	if err != nil {
+		log.Errorf("[CompleteAttachmentUploadService] couldn't read attachment id: %d, err: %v", attachment.ID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	defer object.Close()
+	data, exp := s.readAttachment(object)
+	if exp != nil {
+		if err := s.objectStore.Delete(ctx, attachment.StorageKey); err != nil {
+			log.Errorf("[CompleteAttachmentUploadService] couldn't delete oversized attachment id: %d, err: %v", attachment.ID, err)
+		}
+		return nil, exp
+	}
+	if exp := s.inspectAttachment(ctx, &attachment, data); exp != nil {
+		return nil, exp
+	}
+	if err := s.repo.MarkAttachmentReady(ctx, &attachment); err != nil {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	attachment.Status = common.ATTACHMENT_STATUS_READY
+	return &dto.ResponseAttachment{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data:    s.buildAttachmentData(attachment),
+	}, nil
+}
+
+// GetAttachmentFile streams a stored file together with its content type,
+// which is guessed from the key's extension. Only ready attachments of live
+// posts are served, and only to users who can read the post's channel.
+func (s *service) GetAttachmentFile(ctx context.Context, key string, userID string) (io.ReadCloser, string, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "GetAttachmentFileService")
+
+	attachment, err := s.repo.GetAttachmentByKey(ctx, key)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, "", exceptions.GetExceptionByErrorCode(exceptions.AttachmentNotFoundErrorCode)
+	}
+	if err != nil {
+		return nil, "", exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if attachment.Status != common.ATTACHMENT_STATUS_READY || attachment.PostID == 0 {
+		return nil, "", exceptions.GetExceptionByErrorCode(exceptions.AttachmentNotFoundErrorCode)
+	}
+	post, err := s.repo.CheckPostIDValidity(ctx, strconv.FormatInt(attachment.PostID, 10), "")
+	if err != nil {
+		log.Errorf("[GetAttachmentFileService] couldn't fetch post id: %d of attachment id: %d, err: %v", attachment.PostID, attachment.ID, err)
+		return nil, "", exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if post.ID == 0 || post.Status == deleted {
+		return nil, "", exceptions.GetExceptionByErrorCode(exceptions.AttachmentNotFoundErrorCode)
+	}
+	if exp := s.checkChannelRead(ctx, post.ChannelID, userID); exp != nil {
+		return nil, "", exp
+	}
+
+	object, err := s.objectStore.Get(ctx, key)
+	if errors.Is(err, objectstore.ErrNotFound) || errors.Is(err, objectstore.ErrInvalidKey) {
+		return nil, "", exceptions.GetExceptionByErrorCode(exceptions.AttachmentNotFoundErrorCode)
+	}
+	if err != nil {
+		log.Errorf("[GetAttachmentFileService] couldn't read key: %s, err: %v", key, err)
+		return nil, "", exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return object, contentType, nil
+}
+
+// checkPostAttachments makes sure the attachments requested for a new post
+// belong to the author, are ready and aren't used by another post.
+func (s *service) checkPostAttachments(ctx context.Context, ids []int64, userID string) *exceptions.Exception {
+	if len(ids) == 0 {
+		return nil
+	}
+	if len(ids) > maxPostAttachments {
+		return exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode,
+			fmt.Sprintf("A post can have at most %d attachments", maxPostAttachments))
+	}
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Attachments must be distinct")
+		}
+		seen[id] = true
+	}
+	attachments, err := s.repo.GetAttachmentsByIDs(ctx, ids)
+	if err != nil {
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if len(attachments) != len(ids) {
+		return exceptions.GetExceptionByErrorCode(exceptions.InvalidAttachmentErrorCode)
+	}
+	for _, attachment := range attachments {
+		if attachment.UserID != userID || attachment.PostID != 0 || attachment.Status != common.ATTACHMENT_STATUS_READY {
+			return exceptions.GetExceptionByErrorCode(exceptions.InvalidAttachmentErrorCode)
+		}
+	}
+	return nil
+}
+
+// loadAttachments returns the attachments of the given posts keyed by post
+// id. Failures are logged and leave the attachments out.
+func (s *service) loadAttachments(ctx context.Context, postIDs []int64) map[int64][]dto.ResponseAttachmentData {
+	log := logger.GetLogInstance(ctx, "LoadAttachments")
+	result := make(map[int64][]dto.ResponseAttachmentData)
+
+	attachments, err := s.repo.GetPostAttachments(ctx, postIDs)
+	if err != nil {
+		log.Errorf("[LoadAttachments] couldn't fetch attachments, err: %v", err)
+		return result
+	}
+	for _, attachment := range attachments {
+		result[attachment.PostID] = append(result[attachment.PostID], s.buildAttachmentData(attachment))
+	}
+	return result
+}
+
+// readAttachment reads the whole body, failing once it grows past the
+// allowed upload size.
+func (s *service) readAttachment(body io.Reader) ([]byte, *exceptions.Exception) {
+	data, err := io.ReadAll(io.LimitReader(body, s.maxUploadSize+1))
+	if err != nil {
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Couldn't read the uploaded file")
+	}
+	if int64(len(data)) > s.maxUploadSize {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.AttachmentTooLargeErrorCode)
+	}
+	if len(data) == 0 {
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Uploaded file is empty")
+	}
+	return data, nil
+}
+
+// inspectAttachment fills in the size and sniffed content type of the file
+// and, for images, its dimensions and a stored thumbnail.
+func (s *service) inspectAttachment(ctx context.Context, attachment *dbModel.Attachment, data []byte) *exceptions.Exception {
+	log := logger.GetLogInstance(ctx, "InspectAttachment")
+
+	attachment.Size = int64(len(data))
+	attachment.ContentType = http.DetectContentType(data)
+	if !media.IsImage(attachment.ContentType) {
+		return nil
+	}
+	width, height, err := media.Dimensions(data)
+	if err != nil {
+		return exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Image couldn't be decoded")
+	}
+	if media.TooManyPixels(width, height) {
+		return exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.AttachmentTooLargeErrorCode,
+			fmt.Sprintf("Images can have at most %d pixels", media.MaxPixels))
+	}
+	attachment.Width, attachment.Height = width, height
+
+	thumbnail, err := media.Thumbnail(data)
+	if err != nil {
+		log.Errorf("[InspectAttachment] couldn't create thumbnail of key: %s, err: %v", attachment.StorageKey, err)
+		return nil
+	}
+	thumbnailKey := path.Join(path.Dir(attachment.StorageKey), thumbnailFileName)
+	if err := s.objectStore.Put(ctx, thumbnailKey, bytes.NewReader(thumbnail), int64(len(thumbnail)), media.ThumbnailContentType); err != nil {
+		log.Errorf("[InspectAttachment] couldn't store thumbnail of key: %s, err: %v", attachment.StorageKey, err)
+		return nil
+	}
+	attachment.ThumbnailKey = thumbnailKey
+	return nil
+}
+
+func (s *service) buildAttachmentData(attachment dbModel.Attachment) dto.ResponseAttachmentData {
+	data := dto.ResponseAttachmentData{
+		ID:          attachment.ID,
+		URL:         s.objectStore.URL(attachment.StorageKey),
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Width:       attachment.Width,
+		Height:      attachment.Height,
+	}
+	if attachment.ThumbnailKey != "" {
+		data.ThumbnailURL = s.objectStore.URL(attachment.ThumbnailKey)
+	}
+	return data
+}
+
+// newAttachmentKey puts every attachment in its own directory so its
+// thumbnail can sit next to it and file names never collide.
+func newAttachmentKey(fileName string) string {
+	return path.Join(attachmentKeyPrefix, uuid.NewString(), attachmentFileName(fileName))
+}
+
+// attachmentFileName keeps the base name of the client's file name and
+// replaces anything that isn't safe in a storage key or URL.
+func attachmentFileName(fileName string) string {
+	fileName = path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	safe := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, fileName)
+	safe = strings.TrimLeft(safe, ".")
+	if len(safe) > maxAttachmentNameSize {
+		safe = safe[len(safe)-maxAttachmentNameSize:]
+	}
+	if safe == "" || safe == thumbnailFileName {
+		safe = "file" + path.Ext(safe)
+	}
+	return safe
+}
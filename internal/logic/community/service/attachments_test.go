@@ -0,0 +1,38 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAttachmentFileName(t *testing.T) {
+	long := strings.Repeat("a", maxAttachmentNameSize) + ".png"
+	tests := []struct {
+		name     string
+		fileName string
+		want     string
+	}{
+		{name: "plain", fileName: "photo.png", want: "photo.png"},
+		{name: "unix path", fileName: "/home/me/photo.png", want: "photo.png"},
+		{name: "windows path", fileName: `C:\Users\me\photo.png`, want: "photo.png"},
+		{name: "traversal", fileName: "../../etc/passwd", want: "passwd"},
+		{name: "unsafe characters", fileName: "my photo (1)?.png", want: "my_photo__1__.png"},
+		{name: "unicode", fileName: "фото.png", want: "____.png"},
+		{name: "hidden file", fileName: ".env", want: "env"},
+		{name: "only dots", fileName: "...", want: "file"},
+		{name: "empty", fileName: "", want: "file"},
+		{name: "thumbnail name is reserved", fileName: thumbnailFileName, want: "file.jpg"},
+		{name: "long name keeps the extension", fileName: long, want: long[len(long)-maxAttachmentNameSize:]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := attachmentFileName(tt.fileName)
+			if got != tt.want {
+				t.Errorf("attachmentFileName(%q) = %q, want %q", tt.fileName, got, tt.want)
+			}
+			if len(got) > maxAttachmentNameSize {
+				t.Errorf("attachmentFileName(%q) is %d bytes long, want at most %d", tt.fileName, len(got), maxAttachmentNameSize)
+			}
+		})
+	}
+}
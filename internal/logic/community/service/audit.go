@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+)
+
+const (
+	maxAuditReasonLength  = 1000
+	defaultAuditLogsLimit = 50
+	maxAuditLogsLimit     = 200
+	auditExportBatchSize  = 500
+
+	reportThresholdReason = "Post reached the channel's report threshold"
+)
+
+var auditExportHeader = []string{"id", "created_at", "actor_id", "actor_role", "action", "target_type", "target_id",
+	"channel_id", "reason", "request_id", "before", "after"}
+
+// newAuditActor describes who takes a moderation action in the request
+// served by ctx.
+func newAuditActor(ctx context.Context, userID string, role string, reason string) (model.AuditActor, *exceptions.Exception) {
+	reason = strings.TrimSpace(reason)
+	if utf8.RuneCountInString(reason) > maxAuditReasonLength {
+		return model.AuditActor{}, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode,
+			fmt.Sprintf("Reason can't be longer than %d characters", maxAuditReasonLength))
+	}
+	return model.AuditActor{
+		UserID:    userID,
+		Role:      role,
+		Reason:    reason,
+		RequestID: common.RequestID(ctx),
+	}, nil
+}
+
+// GetAuditLogs lists the audit log entries matching filter, newest first.
+func (s *service) GetAuditLogs(ctx context.Context, filter model.AuditLogFilter) (*dto.ResponseAuditLogs, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "GetAuditLogsService")
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditLogsLimit
+	}
+	if filter.Limit > maxAuditLogsLimit {
+		filter.Limit = maxAuditLogsLimit
+	}
+	limit := filter.Limit
+	// fetch one extra row to know whether another page exists
+	filter.Limit++
+	logs, err := s.repo.GetAuditLogs(ctx, filter)
+	if err != nil {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.QueryFailedErrorCode)
+	}
+	var nextCursor string
+	if len(logs) > limit {
+		logs = logs[:limit]
+		nextCursor, err = common.EncodeCursor(model.AuditLogCursor{ID: logs[len(logs)-1].ID})
+		if err != nil {
+			log.Errorf("[GetAuditLogsService] couldn't build next cursor, err: %v", err)
+			return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+		}
+	}
+
+	data := make([]dto.ResponseAuditLogData, 0, len(logs))
+	for _, entry := range logs {
+		data = append(data, dto.ResponseAuditLogData{
+			ID:         entry.ID,
+			ActorID:    entry.ActorID,
+			ActorRole:  entry.ActorRole,
+			Action:     entry.Action,
+			TargetType: entry.TargetType,
+			TargetID:   entry.TargetID,
+			ChannelID:  entry.ChannelID,
+			Before:     auditData(entry.BeforeData),
+			After:      auditData(entry.AfterData),
+			Reason:     entry.Reason,
+			RequestID:  entry.RequestID,
+			CreatedAt:  fmt.Sprint(entry.CreatedAt.Unix()),
+		})
+	}
+	return &dto.ResponseAuditLogs{
+		Code:       APISuccessCode,
+		Message:    APISuccessMessage,
+		Data:       data,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// ExportAuditLogs writes every audit log entry matching filter to w as CSV,
+// newest first. The limit of filter is ignored.
+func (s *service) ExportAuditLogs(ctx context.Context, filter model.AuditLogFilter, w io.Writer) *exceptions.Exception {
+	log := logger.GetLogInstance(ctx, "ExportAuditLogsService")
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(auditExportHeader); err != nil {
+		log.Errorf("[ExportAuditLogsService] couldn't write export header, err: %v", err)
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	filter.Limit = auditExportBatchSize
+	for {
+		logs, err := s.repo.GetAuditLogs(ctx, filter)
+		if err != nil {
+			return exceptions.GetExceptionByErrorCode(exceptions.QueryFailedErrorCode)
+		}
+		for _, entry := range logs {
+			err := writer.Write([]string{
+				strconv.FormatInt(entry.ID, 10),
+				fmt.Sprint(entry.CreatedAt.Unix()),
+				entry.ActorID,
+				entry.ActorRole,
+				entry.Action,
+				entry.TargetType,
+				entry.TargetID,
+				entry.ChannelID,
+				entry.Reason,
+				entry.RequestID,
+				entry.BeforeData,
+				entry.AfterData,
+			})
+			if err != nil {
+				log.Errorf("[ExportAuditLogsService] couldn't write audit log id: %d, err: %v", entry.ID, err)
+				return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+			}
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			log.Errorf("[ExportAuditLogsService] couldn't flush export, err: %v", err)
+			return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+		}
+		if len(logs) < filter.Limit {
+			return nil
+		}
+		filter.Cursor = &model.AuditLogCursor{ID: logs[len(logs)-1].ID}
+	}
+}
+
+// auditData returns a stored snapshot as raw JSON, null when there is none.
+func auditData(data string) json.RawMessage {
+	if data == "" {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(data)
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	model "github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+)
+
+const (
+	maxBookmarkCollections       = 100
+	maxBookmarkCollectionNameLen = 50
+	maxBookmarkNoteLen           = 1000
+	defaultSavedItems            = 20
+	maxSavedItems                = 50
+)
+
+func (s *service) CreateBookmarkCollection(ctx context.Context, requestBody *dto.RequestBookmarkCollection, userID string) (*dto.ResponseBookmarkCollection, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "CreateBookmarkCollectionService")
+
+	name, exp := s.checkBookmarkCollectionName(ctx, userID, requestBody.Name, 0)
+	if exp != nil {
+		return nil, exp
+	}
+	collections, err := s.repo.GetBookmarkCollections(ctx, userID)
+	if err != nil {
+		log.Errorf("[CreateBookmarkCollectionService] couldn't fetch collections of user id: %s, err: %v", userID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if len(collections) >= maxBookmarkCollections {
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode,
+			fmt.Sprintf("A user can have at most %d collections", maxBookmarkCollections))
+	}
+
+	collection := dbModel.BookmarkCollection{UserID: userID, Name: name}
+	if err := s.repo.CreateBookmarkCollection(ctx, &collection); err != nil {
+		log.Errorf("[CreateBookmarkCollectionService] couldn't create collection for user id: %s, err: %v", userID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return &dto.ResponseBookmarkCollection{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data: buildBookmarkCollectionData(model.BookmarkCollectionCount{
+			ID:        collection.ID,
+			Name:      collection.Name,
+			CreatedAt: collection.CreatedAt,
+			UpdatedAt: collection.UpdatedAt,
+		}),
+	}, nil
+}
+
+func (s *service) GetBookmarkCollections(ctx context.Context, userID string) (*dto.ResponseBookmarkCollections, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "GetBookmarkCollectionsService")
+	collections, err := s.repo.GetBookmarkCollections(ctx, userID)
+	if err != nil {
+		log.Errorf("[GetBookmarkCollectionsService] couldn't fetch collections of user id: %s, err: %v", userID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	data := make([]dto.ResponseBookmarkCollectionData, 0, len(collections))
+	for _, collection := range collections {
+		data = append(data, buildBookmarkCollectionData(collection))
+	}
+	return &dto.ResponseBookmarkCollections{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data:    data,
+	}, nil
+}
+
+func (s *service) RenameBookmarkCollection(ctx context.Context, requestBody *dto.RequestBookmarkCollection, userID string) (*dto.ResponseBookmarkCollection, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "RenameBookmarkCollectionService")
+
+	name, exp := s.checkBookmarkCollectionName(ctx, userID, requestBody.Name, requestBody.CollectionID)
+	if exp != nil {
+		return nil, exp
+	}
+	collection, err := s.repo.RenameBookmarkCollection(ctx, userID, requestBody.CollectionID, name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.NoDataFoundErrorCode, "Collection not found")
+		}
+		log.Errorf("[RenameBookmarkCollectionService] couldn't rename collection id: %d, err: %v", requestBody.CollectionID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return &dto.ResponseBookmarkCollection{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data: buildBookmarkCollectionData(model.BookmarkCollectionCount{
+			ID:        collection.ID,
+			Name:      collection.Name,
+			CreatedAt: collection.CreatedAt,
+			UpdatedAt: collection.UpdatedAt,
+		}),
+	}, nil
+}
+
+// DeleteBookmarkCollection removes a collection without removing the
+// bookmarks filed in it.
+func (s *service) DeleteBookmarkCollection(ctx context.Context, collectionID int64, userID string) *exceptions.Exception {
+	log := logger.GetLogInstance(ctx, "DeleteBookmarkCollectionService")
+	if err := s.repo.DeleteBookmarkCollection(ctx, userID, collectionID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.NoDataFoundErrorCode, "Collection not found")
+		}
+		log.Errorf("[DeleteBookmarkCollectionService] couldn't delete collection id: %d, err: %v", collectionID, err)
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return nil
+}
+
+// SaveBookmark bookmarks a post, unless it already is, and files it in the
+// requested collection with the user's note.
+func (s *service) SaveBookmark(ctx context.Context, requestBody *dto.RequestSaveBookmark, userID string) (*dto.ResponseSaveBookmark, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "SaveBookmarkService")
+
+	note := strings.TrimSpace(requestBody.Note)
+	if utf8.RuneCountInString(note) > maxBookmarkNoteLen {
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode,
+			fmt.Sprintf("Note can't be longer than %d characters", maxBookmarkNoteLen))
+	}
+	if exp := s.checkPostRead(ctx, requestBody.PostID, userID); exp != nil {
+		return nil, exp
+	}
+	if requestBody.CollectionID != 0 {
+		if _, err := s.repo.GetBookmarkCollection(ctx, userID, requestBody.CollectionID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Collection not found")
+			}
+			log.Errorf("[SaveBookmarkService] couldn't fetch collection id: %d, err: %v", requestBody.CollectionID, err)
+			return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+		}
+	}
+
+	err := s.repo.SaveBookmark(ctx, dbModel.BookmarkDetail{
+		UserID:       userID,
+		PostID:       requestBody.PostID,
+		CollectionID: requestBody.CollectionID,
+		Note:         note,
+	})
+	if err != nil {
+		log.Errorf("[SaveBookmarkService] couldn't save bookmark of post id: %d for user id: %s, err: %v", requestBody.PostID, userID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return &dto.ResponseSaveBookmark{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data: dto.ResponseSavedBookmarkData{
+			PostID:       requestBody.PostID,
+			CollectionID: requestBody.CollectionID,
+			Note:         note,
+			IsBookmarked: true,
+		},
+	}, nil
+}
+
+// GetSavedItems lists the user's bookmarks across every channel, whatever the
+// flow of the channel, optionally only those of one collection.
+func (s *service) GetSavedItems(ctx context.Context, userID string, collectionID *int64, limit int, cursor *model.SavedItemsCursor) (*dto.ResponseSavedItems, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "GetSavedItemsService")
+
+	if limit <= 0 {
+		limit = defaultSavedItems
+	}
+	if limit > maxSavedItems {
+		limit = maxSavedItems
+	}
+	items, err := s.repo.GetSavedItems(ctx, userID, collectionID, limit+1, cursor)
+	if err != nil {
+		log.Errorf("[GetSavedItemsService] couldn't fetch saved items of user id: %s, err: %v", userID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+
+	posts := make([]common.Post, 0, len(items))
+	for _, item := range items {
+		posts = append(posts, item.Post)
+	}
+	feedPosts, exp := s.buildFeedPosts(ctx, posts, model.Viewer{UserID: userID}, common.IDEAS_BASED_FLOW, true)
+	if exp != nil {
+		return nil, exp
+	}
+	feedPostsByID := make(map[int64]dto.ResponseGetPostsPostData, len(feedPosts))
+	for _, post := range feedPosts {
+		feedPostsByID[post.ID] = *post
+	}
+
+	data := make([]dto.ResponseSavedItem, 0, len(items))
+	for _, item := range items {
+		data = append(data, dto.ResponseSavedItem{
+			ChannelID:    item.ChannelID,
+			CollectionID: item.CollectionID,
+			Note:         item.Note,
+			SavedAt:      fmt.Sprint(item.SavedAt.Unix()),
+			Post:         feedPostsByID[item.ID],
+		})
+	}
+
+	var nextCursor string
+	if hasMore && len(items) > 0 {
+		last := items[len(items)-1]
+		nextCursor, err = common.EncodeCursor(model.SavedItemsCursor{SavedAt: last.SavedAt, ID: last.ID})
+		if err != nil {
+			log.Errorf("[GetSavedItemsService] couldn't build next cursor for user id: %s, err: %v", userID, err)
+			return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+		}
+	}
+	return &dto.ResponseSavedItems{
+		Code:       APISuccessCode,
+		Message:    APISuccessMessage,
+		Data:       data,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// checkBookmarkCollectionName trims a collection name and makes sure it is
+// usable and not taken by another collection of the user.
+func (s *service) checkBookmarkCollectionName(ctx context.Context, userID string, name string, collectionID int64) (string, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "CheckBookmarkCollectionName")
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxBookmarkCollectionNameLen {
+		return "", exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode,
+			fmt.Sprintf("Collection name should have 1 to %d characters", maxBookmarkCollectionNameLen))
+	}
+	existing, err := s.repo.GetBookmarkCollectionByName(ctx, userID, name)
+	if err != nil {
+		log.Errorf("[CheckBookmarkCollectionName] couldn't look up collection name for user id: %s, err: %v", userID, err)
+		return "", exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if existing != nil && existing.ID != collectionID {
+		return "", exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "A collection with this name already exists")
+	}
+	return name, nil
+}
+
+func buildBookmarkCollectionData(collection model.BookmarkCollectionCount) dto.ResponseBookmarkCollectionData {
+	return dto.ResponseBookmarkCollectionData{
+		ID:        collection.ID,
+		Name:      collection.Name,
+		ItemCount: collection.ItemCount,
+		CreatedAt: fmt.Sprint(collection.CreatedAt.Unix()),
+		UpdatedAt: fmt.Sprint(collection.UpdatedAt.Unix()),
+	}
+}
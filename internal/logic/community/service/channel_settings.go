@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+)
+
+const (
+	maxPostLengthSetting = 100000
+	maxSlowModeSeconds   = 24 * 60 * 60
+)
+
+// GetChannelSettings returns the settings of a channel the user can read.
+func (s *service) GetChannelSettings(ctx context.Context, channelID string, userID string) (*dto.ResponseChannelSettings, *exceptions.Exception) {
+	if exp := s.checkChannelRead(ctx, channelID, userID); exp != nil {
+		return nil, exp
+	}
+	settings, exp := s.getChannelSettings(ctx, channelID)
+	if exp != nil {
+		return nil, exp
+	}
+	return buildChannelSettingsResponse(settings), nil
+}
+
+// UpdateChannelSettings changes the settings given in the request and keeps
+// the others as they are.
+func (s *service) UpdateChannelSettings(ctx context.Context, requestBody *dto.RequestChannelSettings) (*dto.ResponseChannelSettings, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "UpdateChannelSettingsService")
+
+	settings, exp := s.getChannelSettings(ctx, requestBody.ChannelID)
+	if exp != nil {
+		return nil, exp
+	}
+	if requestBody.ScoringMode != "" {
+		scoringMode := strings.ToLower(requestBody.ScoringMode)
+		if scoringMode != common.SCORING_MODE_LIKES && scoringMode != common.SCORING_MODE_VOTES {
+			log.Errorf("[UpdateChannelSettingsService] invalid scoring mode: %s", requestBody.ScoringMode)
+			return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Scoring mode should be likes or votes")
+		}
+		settings.ScoringMode = scoringMode
+	}
+	if requestBody.ChannelMode != "" {
+		channelMode := strings.ToLower(requestBody.ChannelMode)
+		switch channelMode {
+		case common.CHANNEL_MODE_DISCUSSION, common.CHANNEL_MODE_QA, common.CHANNEL_MODE_IDEAS:
+		default:
+			log.Errorf("[UpdateChannelSettingsService] invalid channel mode: %s", requestBody.ChannelMode)
+			return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Channel mode should be discussion, qa or ideas")
+		}
+		settings.ChannelMode = channelMode
+	}
+	if requestBody.DefaultFlow != "" {
+		flow := strings.ToLower(requestBody.DefaultFlow)
+		if flow != common.USER_BASED_FLOW && flow != common.IDEAS_BASED_FLOW {
+			log.Errorf("[UpdateChannelSettingsService] invalid default flow: %s", requestBody.DefaultFlow)
+			return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Default flow should be user-based or ideas-based")
+		}
+		settings.DefaultFlow = flow
+	}
+	if requestBody.RepliesEnabled != nil {
+		settings.RepliesDisabled = !*requestBody.RepliesEnabled
+	}
+	if requestBody.ReactionsEnabled != nil {
+		settings.ReactionsDisabled = !*requestBody.ReactionsEnabled
+	}
+	if requestBody.MaxPostLength != nil {
+		if *requestBody.MaxPostLength < 0 || *requestBody.MaxPostLength > maxPostLengthSetting {
+			return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode,
+				fmt.Sprintf("Max post length should be between 0 and %d", maxPostLengthSetting))
+		}
+		settings.MaxPostLength = *requestBody.MaxPostLength
+	}
+	if requestBody.SlowModeSeconds != nil {
+		if *requestBody.SlowModeSeconds < 0 || *requestBody.SlowModeSeconds > maxSlowModeSeconds {
+			return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode,
+				fmt.Sprintf("Slow mode should be between 0 and %d seconds", maxSlowModeSeconds))
+		}
+		settings.SlowModeSeconds = *requestBody.SlowModeSeconds
+	}
+	if requestBody.ReportThreshold != nil {
+		if *requestBody.ReportThreshold < 0 {
+			return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Report threshold can't be negative")
+		}
+		settings.ReportThreshold = *requestBody.ReportThreshold
+	}
+
+	if err := s.repo.UpsertChannelSettings(ctx, settings); err != nil {
+		log.Errorf("[UpdateChannelSettingsService] couldn't save settings for channel id: %s, err: %v", requestBody.ChannelID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return buildChannelSettingsResponse(settings), nil
+}
+
+func (s *service) getChannelSettings(ctx context.Context, channelID string) (*dbModel.ChannelSetting, *exceptions.Exception) {
+	settings, err := s.repo.GetChannelSettings(ctx, channelID)
+	if err != nil {
+		logger.GetLogInstance(ctx, "GetChannelSettings").Errorf("[GetChannelSettings] couldn't fetch settings for channel id: %s, err: %v", channelID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return settings, nil
+}
+
+// defaultFlow is the flow of a channel's feed when the request names none.
+func (s *service) defaultFlow(ctx context.Context, channelID string) (string, *exceptions.Exception) {
+	settings, exp := s.getChannelSettings(ctx, channelID)
+	if exp != nil {
+		return "", exp
+	}
+	if settings.DefaultFlow == "" {
+		return common.USER_BASED_FLOW, nil
+	}
+	return settings.DefaultFlow, nil
+}
+
+// checkReactionsEnabled refuses new likes and votes in channels that turned
+// reactions off.
+func (s *service) checkReactionsEnabled(ctx context.Context, channelID string) *exceptions.Exception {
+	settings, exp := s.getChannelSettings(ctx, channelID)
+	if exp != nil {
+		return exp
+	}
+	if settings.ReactionsDisabled {
+		logger.GetLogInstance(ctx, "CheckReactionsEnabled").Errorf("[CheckReactionsEnabled] reactions are turned off in channel id: %s", channelID)
+		return exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Reactions are turned off in this channel")
+	}
+	return nil
+}
+
+// hideOverReportThreshold hides a reported post once its reports reach the
+// channel's threshold. Failures are logged; the report itself is kept.
+func (s *service) hideOverReportThreshold(ctx context.Context, post common.Post) {
+	log := logger.GetLogInstance(ctx, "HideOverReportThreshold")
+	settings, err := s.repo.GetChannelSettings(ctx, post.ChannelID)
+	if err != nil {
+		log.Errorf("[HideOverReportThreshold] couldn't fetch settings for channel id: %s, err: %v", post.ChannelID, err)
+		return
+	}
+	if settings.ReportThreshold <= 0 {
+		return
+	}
+	actor, _ := newAuditActor(ctx, "", common.AUDIT_ROLE_SYSTEM, reportThresholdReason)
+	hidden, err := s.repo.HideReportedPost(ctx, post.ID, settings.ReportThreshold, actor)
+	if err != nil {
+		log.Errorf("[HideOverReportThreshold] couldn't hide post id: %d, err: %v", post.ID, err)
+		return
+	}
+	if hidden {
+		log.Infof("[HideOverReportThreshold] post id: %d reached %d reports and was hidden", post.ID, settings.ReportThreshold)
+	}
+}
+
+// checkPostLength refuses content longer than the channel allows.
+func checkPostLength(settings *dbModel.ChannelSetting, content string) *exceptions.Exception {
+	if settings.MaxPostLength > 0 && utf8.RuneCountInString(content) > settings.MaxPostLength {
+		return exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode,
+			fmt.Sprintf("Post can't be longer than %d characters", settings.MaxPostLength))
+	}
+	return nil
+}
+
+func buildChannelSettingsResponse(settings *dbModel.ChannelSetting) *dto.ResponseChannelSettings {
+	return &dto.ResponseChannelSettings{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data: dto.ResponseChannelSettingsData{
+			ChannelID:        settings.ChannelID,
+			ScoringMode:      settings.ScoringMode,
+			ChannelMode:      settings.ChannelMode,
+			DefaultFlow:      settings.DefaultFlow,
+			RepliesEnabled:   !settings.RepliesDisabled,
+			ReactionsEnabled: !settings.ReactionsDisabled,
+			MaxPostLength:    settings.MaxPostLength,
+			SlowModeSeconds:  settings.SlowModeSeconds,
+			ReportThreshold:  settings.ReportThreshold,
+		},
+	}
+}
@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+)
+
+const (
+	// maxChannelInvites caps how many users one request can invite or add.
+	maxChannelInvites        = 500
+	maxChannelTitleLen       = 100
+	maxChannelDescriptionLen = 1000
+)
+
+// channelAccess is what a user may do in a channel. Channels that were never
+// registered are public.
+type channelAccess struct {
+	channel    dbModel.Channel
+	membership string
+	moderator  bool
+}
+
+// canRead reports whether the user can read the channel: members-only
+// channels are read by their active members and moderators only.
+func (a channelAccess) canRead() bool {
+	if a.channel.Visibility != common.CHANNEL_VISIBILITY_MEMBERS {
+		return true
+	}
+	return a.moderator || a.membership == common.MEMBERSHIP_STATUS_ACTIVE
+}
+
+// canPost reports whether the user can post or reply in the channel. Nobody
+// posts in archived channels, and only moderators post in announcement
+// channels.
+func (a channelAccess) canPost() bool {
+	if a.channel.Archived {
+		return false
+	}
+	switch a.channel.Visibility {
+	case common.CHANNEL_VISIBILITY_ANNOUNCEMENT:
+		return a.moderator
+	case common.CHANNEL_VISIBILITY_MEMBERS:
+		return a.canRead()
+	}
+	return true
+}
+
+// getChannelAccess loads the visibility of a channel and, unless full is
+// false and the channel is public, the user's membership and moderator role.
+func (s *service) getChannelAccess(ctx context.Context, channelID string, userID string, full bool) (channelAccess, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "GetChannelAccess")
+	access := channelAccess{channel: dbModel.Channel{ChannelID: channelID, Visibility: common.CHANNEL_VISIBILITY_PUBLIC}}
+	channel, err := s.repo.GetChannel(ctx, channelID)
+	if err != nil {
+		log.Errorf("[GetChannelAccess] couldn't fetch channel id: %s, err: %v", channelID, err)
+		return access, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if channel != nil {
+		access.channel = *channel
+	}
+	if (!full && access.channel.Visibility == common.CHANNEL_VISIBILITY_PUBLIC) || userID == "" {
+		return access, nil
+	}
+	member, err := s.repo.GetChannelMember(ctx, channelID, userID)
+	if err != nil {
+		log.Errorf("[GetChannelAccess] couldn't fetch membership of user id: %s in channel id: %s, err: %v", userID, channelID, err)
+		return access, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if member != nil {
+		access.membership = member.Status
+	}
+	access.moderator, err = s.repo.IsChannelModerator(ctx, channelID, userID)
+	if err != nil {
+		return access, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return access, nil
+}
+
+// checkChannelRead refuses users who can't read the channel.
+func (s *service) checkChannelRead(ctx context.Context, channelID string, userID string) *exceptions.Exception {
+	access, exp := s.getChannelAccess(ctx, channelID, userID, false)
+	if exp != nil {
+		return exp
+	}
+	if !access.canRead() {
+		logger.GetLogInstance(ctx, "CheckChannelRead").Errorf("[CheckChannelRead] user id: %s can't read channel id: %s", userID, channelID)
+		return exceptions.GetExceptionByErrorCode(exceptions.ChannelAccessErrorCode)
+	}
+	return nil
+}
+
+// checkChannelPost refuses users who can't post in the channel.
+func (s *service) checkChannelPost(ctx context.Context, channelID string, userID string) *exceptions.Exception {
+	access, exp := s.getChannelAccess(ctx, channelID, userID, false)
+	if exp != nil {
+		return exp
+	}
+	if !access.canRead() {
+		logger.GetLogInstance(ctx, "CheckChannelPost").Errorf("[CheckChannelPost] user id: %s can't read channel id: %s", userID, channelID)
+		return exceptions.GetExceptionByErrorCode(exceptions.ChannelAccessErrorCode)
+	}
+	if !access.canPost() {
+		logger.GetLogInstance(ctx, "CheckChannelPost").Errorf("[CheckChannelPost] user id: %s can't post in channel id: %s", userID, channelID)
+		if access.channel.Archived {
+			return exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.ChannelAccessErrorCode, "This channel is archived")
+		}
+		return exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.ChannelAccessErrorCode, "Only moderators can post in this channel")
+	}
+	return nil
+}
+
+// checkPostRead refuses users who can't read the channel of a post.
+func (s *service) checkPostRead(ctx context.Context, postID int64, userID string) *exceptions.Exception {
+	post, exp := s.getLivePost(ctx, postID)
+	if exp != nil {
+		return exp
+	}
+	return s.checkChannelRead(ctx, post.ChannelID, userID)
+}
+
+// restrictedChannels returns which of channelIDs the user can't read. On
+// failure every channel is treated as restricted.
+func (s *service) restrictedChannels(ctx context.Context, userID string, channelIDs []string) map[string]bool {
+	restricted, err := s.repo.GetRestrictedChannels(ctx, userID, channelIDs)
+	if err != nil {
+		logger.GetLogInstance(ctx, "RestrictedChannels").Errorf("[RestrictedChannels] couldn't check channels of user id: %s, err: %v", userID, err)
+		restricted = make(map[string]bool, len(channelIDs))
+		for _, channelID := range channelIDs {
+			restricted[channelID] = true
+		}
+	}
+	return restricted
+}
+
+func (s *service) GetChannel(ctx context.Context, channelID string, userID string) (*dto.ResponseChannel, *exceptions.Exception) {
+	access, exp := s.getChannelAccess(ctx, channelID, userID, true)
+	if exp != nil {
+		return nil, exp
+	}
+	return buildChannelResponse(access), nil
+}
+
+// JoinChannel makes the user an active member. Members-only channels can only
+// be joined on invitation.
+func (s *service) JoinChannel(ctx context.Context, channelID string, userID string) (*dto.ResponseChannel, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "JoinChannelService")
+	access, exp := s.getChannelAccess(ctx, channelID, userID, true)
+	if exp != nil {
+		return nil, exp
+	}
+	if access.channel.Visibility == common.CHANNEL_VISIBILITY_MEMBERS && access.membership == "" && !access.moderator {
+		log.Errorf("[JoinChannelService] user id: %s isn't invited to channel id: %s", userID, channelID)
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.ChannelAccessErrorCode, "This channel can only be joined on invitation")
+	}
+	if access.membership != common.MEMBERSHIP_STATUS_ACTIVE {
+		if err := s.repo.ActivateChannelMembers(ctx, channelID, []string{userID}, ""); err != nil {
+			return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+		}
+		access.membership = common.MEMBERSHIP_STATUS_ACTIVE
+	}
+	return buildChannelResponse(access), nil
+}
+
+// LeaveChannel ends the user's membership, or declines an invitation.
+func (s *service) LeaveChannel(ctx context.Context, channelID string, userID string) *exceptions.Exception {
+	if err := s.repo.RemoveChannelMember(ctx, channelID, userID, nil); err != nil {
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return nil
+}
+
+// InviteToChannel lets a moderator invite users, who can then join the
+// channel.
+func (s *service) InviteToChannel(ctx context.Context, requestBody *dto.RequestChannelMembers, userID string) *exceptions.Exception {
+	log := logger.GetLogInstance(ctx, "InviteToChannelService")
+	userIDs, exp := checkChannelMembers(requestBody)
+	if exp != nil {
+		return exp
+	}
+	isModerator, err := s.repo.IsChannelModerator(ctx, requestBody.ChannelID, userID)
+	if err != nil {
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if !isModerator {
+		log.Errorf("[InviteToChannelService] user id: %s isn't a moderator of channel id: %s", userID, requestBody.ChannelID)
+		return exceptions.GetExceptionByErrorCode(exceptions.AccessDeniedErrorCode)
+	}
+	if _, err := s.repo.InviteChannelMembers(ctx, requestBody.ChannelID, userIDs, userID); err != nil {
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return nil
+}
+
+// UpdateChannel registers a channel, or changes the details of a registered
+// one. Fields missing from the request keep their value.
+func (s *service) UpdateChannel(ctx context.Context, requestBody *dto.RequestUpdateChannel) (*dto.ResponseChannel, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "UpdateChannelService")
+	existing, err := s.repo.GetChannel(ctx, requestBody.ChannelID)
+	if err != nil {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	channel := dbModel.Channel{ChannelID: requestBody.ChannelID, Visibility: common.CHANNEL_VISIBILITY_PUBLIC}
+	if existing != nil {
+		channel = *existing
+	}
+	if requestBody.Visibility != "" {
+		visibility := strings.ToLower(strings.TrimSpace(requestBody.Visibility))
+		switch visibility {
+		case common.CHANNEL_VISIBILITY_PUBLIC, common.CHANNEL_VISIBILITY_MEMBERS, common.CHANNEL_VISIBILITY_ANNOUNCEMENT:
+		default:
+			log.Errorf("[UpdateChannelService] invalid visibility: %s", requestBody.Visibility)
+			return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Visibility should be one of public, members or announcement")
+		}
+		channel.Visibility = visibility
+	}
+	if requestBody.Title != nil {
+		channel.Title = strings.TrimSpace(*requestBody.Title)
+		if utf8.RuneCountInString(channel.Title) > maxChannelTitleLen {
+			return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode,
+				fmt.Sprintf("Title can't be longer than %d characters", maxChannelTitleLen))
+		}
+	}
+	if requestBody.Description != nil {
+		channel.Description = strings.TrimSpace(*requestBody.Description)
+		if utf8.RuneCountInString(channel.Description) > maxChannelDescriptionLen {
+			return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode,
+				fmt.Sprintf("Description can't be longer than %d characters", maxChannelDescriptionLen))
+		}
+	}
+	if requestBody.OwnerID != nil {
+		channel.OwnerID = strings.TrimSpace(*requestBody.OwnerID)
+	}
+	if requestBody.Archived != nil {
+		channel.Archived = *requestBody.Archived
+	}
+	if err := s.repo.UpsertChannel(ctx, &channel); err != nil {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return buildChannelResponse(channelAccess{channel: channel}), nil
+}
+
+// AddChannelMembers makes the users active members without an invitation,
+// e.g. the registered attendees of an event.
+func (s *service) AddChannelMembers(ctx context.Context, requestBody *dto.RequestChannelMembers) *exceptions.Exception {
+	userIDs, exp := checkChannelMembers(requestBody)
+	if exp != nil {
+		return exp
+	}
+	if err := s.repo.ActivateChannelMembers(ctx, requestBody.ChannelID, userIDs, ""); err != nil {
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return nil
+}
+
+// RemoveChannelMember lets an admin take a user out of a channel. It is
+// recorded in the audit log.
+func (s *service) RemoveChannelMember(ctx context.Context, requestBody *dto.RequestChannelMember, actorID string) *exceptions.Exception {
+	actor, exp := newAuditActor(ctx, actorID, common.AUDIT_ROLE_ADMIN, requestBody.Reason)
+	if exp != nil {
+		return exp
+	}
+	if err := s.repo.RemoveChannelMember(ctx, requestBody.ChannelID, requestBody.UserID, &actor); err != nil {
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return nil
+}
+
+// checkChannelMembers validates a request adding users to a channel and
+// returns its distinct user ids.
+func checkChannelMembers(requestBody *dto.RequestChannelMembers) ([]string, *exceptions.Exception) {
+	seen := make(map[string]bool, len(requestBody.UserIDs))
+	var userIDs []string
+	for _, userID := range requestBody.UserIDs {
+		userID = strings.TrimSpace(userID)
+		if userID != "" && !seen[userID] {
+			seen[userID] = true
+			userIDs = append(userIDs, userID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "User ids are required")
+	}
+	if len(userIDs) > maxChannelInvites {
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Too many user ids in one request")
+	}
+	return userIDs, nil
+}
+
+func buildChannelResponse(access channelAccess) *dto.ResponseChannel {
+	var createdAt string
+	if !access.channel.CreatedAt.IsZero() {
+		createdAt = fmt.Sprint(access.channel.CreatedAt.Unix())
+	}
+	return &dto.ResponseChannel{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data: dto.ResponseChannelData{
+			ChannelID:        access.channel.ChannelID,
+			Title:            access.channel.Title,
+			Description:      access.channel.Description,
+			OwnerID:          access.channel.OwnerID,
+			Visibility:       access.channel.Visibility,
+			Archived:         access.channel.Archived,
+			CreatedAt:        createdAt,
+			MembershipStatus: access.membership,
+			IsModerator:      access.moderator,
+			CanRead:          access.canRead(),
+			CanPost:          access.canPost(),
+		},
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	model "github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+)
+
+// getPostsAfterCursor serves the feed for clients paging with next_cursor. The
+// totals in the pagination block are not recomputed on this path; only the
+// record count of the returned page is filled in.
+func (s *service) getPostsAfterCursor(ctx context.Context, ChannelID string, userID string, limit int, sortBy string, feedSort model.FeedSort, bookMarksOnly bool, cursor *model.FeedCursor) (*dto.ResponseGetPosts, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "GetPostsService")
+
+	if !bookMarksOnly && cursor.Order != feedSort.Order {
+		log.Errorf("[GetPostsService] cursor was issued for sort order: %s, requested: %s", cursor.Order, feedSort.Order)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.InvalidCursorErrorCode)
+	}
+
+	posts, hasMore, err := s.fetchPostsAfterCursor(ctx, ChannelID, userID, limit, sortBy, feedSort, bookMarksOnly, cursor)
+	if err != nil {
+		log.Errorf("[GetPostsService] couldn't fetch posts after cursor for channel id: %s, err: %v", ChannelID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+
+	filteredPosts, exp := s.buildFeedPosts(ctx, posts, feedSort.Viewer, sortBy, bookMarksOnly)
+	if exp != nil {
+		return nil, exp
+	}
+
+	s.clearUnreadFlag(ctx, userID, ChannelID)
+
+	var nextCursor string
+	if hasMore && len(posts) > 0 {
+		nextCursor, err = encodeFeedCursor(posts[len(posts)-1], userID, sortBy, feedSort, bookMarksOnly)
+		if err != nil {
+			log.Errorf("[GetPostsService] couldn't build next cursor for channel id: %s, err: %v", ChannelID, err)
+			return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+		}
+	}
+
+	return &dto.ResponseGetPosts{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data:    dereferencePostData(filteredPosts),
+		Pagination: dto.ResponseGetPostsPagination{
+			SinglePageRecordCount: int64(len(filteredPosts)),
+		},
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// fetchPostsAfterCursor reads one row more than limit to find out whether a
+// further page exists. In the user based flow the viewer's own posts are
+// drained first and the page is topped up with other users' posts.
+func (s *service) fetchPostsAfterCursor(ctx context.Context, ChannelID string, userID string, limit int, sortBy string, feedSort model.FeedSort, bookMarksOnly bool, cursor *model.FeedCursor) ([]common.Post, bool, error) {
+	var (
+		posts []common.Post
+		err   error
+	)
+	switch {
+	case bookMarksOnly:
+		posts, err = s.repo.GetBookMarkedPostsAfterCursor(ctx, ChannelID, userID, limit+1, sortBy, cursor)
+	case sortBy == common.IDEAS_BASED_FLOW:
+		posts, err = s.repo.GetEventPostsAfterCursor(ctx, ChannelID, userID, limit+1, sortBy, feedSort, "", cursor)
+	default:
+		othersCursor := cursor
+		if cursor == nil || cursor.Phase == model.FeedPhaseOwn {
+			posts, err = s.repo.GetEventPostsAfterCursor(ctx, ChannelID, userID, limit+1, sortBy, feedSort, model.FeedPhaseOwn, cursor)
+			if err != nil || len(posts) > limit {
+				break
+			}
+			othersCursor = nil
+		}
+		var others []common.Post
+		others, err = s.repo.GetEventPostsAfterCursor(ctx, ChannelID, userID, limit-len(posts)+1, sortBy, feedSort, model.FeedPhaseOthers, othersCursor)
+		posts = append(posts, others...)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if len(posts) > limit {
+		return posts[:limit], true, nil
+	}
+	return posts, false, nil
+}
+
+func encodeFeedCursor(post common.Post, userID string, sortBy string, feedSort model.FeedSort, bookMarksOnly bool) (string, error) {
+	cursor := model.FeedCursor{
+		Pinned:    post.IsPinned,
+		Deleted:   post.Status == deleted,
+		LikeCount: post.LikeCount,
+		CreatedAt: post.CreatedAt,
+		UpdatedAt: post.UpdatedAt,
+		ID:        post.ID,
+	}
+	if !bookMarksOnly {
+		cursor.Order = feedSort.Order
+		switch feedSort.Order {
+		case common.SORT_HOT:
+			cursor.Score = post.HotScore
+		case common.SORT_CONTROVERSIAL:
+			cursor.Score = post.ControversyScore
+		case common.SORT_SCORE:
+			cursor.VoteScore = post.VoteScore
+		}
+	}
+	if !bookMarksOnly && sortBy != common.IDEAS_BASED_FLOW {
+		cursor.Phase = model.FeedPhaseOthers
+		if post.UserID == userID {
+			cursor.Phase = model.FeedPhaseOwn
+		}
+	}
+	return common.EncodeCursor(cursor)
+}
+
+func encodeReplyCursor(reply model.ReplyPost) (string, error) {
+	updatedAt, err := time.Parse(time.RFC3339Nano, reply.UpdatedAt)
+	if err != nil {
+		return "", err
+	}
+	return common.EncodeCursor(model.FeedCursor{
+		Pinned:    reply.IsPinned,
+		Accepted:  reply.IsAcceptedAnswer,
+		Deleted:   reply.Status == deleted,
+		LikeCount: reply.LikeCount,
+		UpdatedAt: updatedAt,
+		ID:        reply.ID,
+	})
+}
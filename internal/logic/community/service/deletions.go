@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/richtext"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultRestoreWindow = 30 * 24 * time.Hour
+
+	authorDeletedContent    = "This comment was deleted by the post author."
+	moderatorDeletedContent = "This comment was removed by a moderator."
+)
+
+// deletedPostContent is the placeholder shown instead of a post deleted by
+// someone in the given audit role. Posts deleted before deletions were
+// recorded could only be deleted by their authors.
+func deletedPostContent(role string) string {
+	if role == common.AUDIT_ROLE_MODERATOR || role == common.AUDIT_ROLE_ADMIN {
+		return moderatorDeletedContent
+	}
+	return authorDeletedContent
+}
+
+// shownContent is the content of a post as it's shown to readers: deleted
+// posts keep their content but only the placeholder is shown. deletedBy holds
+// the audit role of whoever deleted each post.
+func shownContent(postID int64, status string, content string, deletedBy map[int64]string) string {
+	if status != common.POST_STATUS_DELETED {
+		return content
+	}
+	return deletedPostContent(deletedBy[postID])
+}
+
+// loadDeletionRoles returns who deleted which of the given posts. Posts
+// missing from it are shown with the author placeholder when deleted.
+func (s *service) loadDeletionRoles(ctx context.Context, postIDs []int64) map[int64]string {
+	log := logger.GetLogInstance(ctx, "LoadDeletionRoles")
+	roles, err := s.repo.GetPostDeletionRoles(ctx, postIDs)
+	if err != nil {
+		log.Errorf("[LoadDeletionRoles] couldn't fetch deletions, err: %v", err)
+		return map[int64]string{}
+	}
+	return roles
+}
+
+// RestorePost brings back a deleted post as it was before it was deleted.
+// Only moderators of the post's channel may restore it, and only within the
+// restore window.
+func (s *service) RestorePost(ctx context.Context, requestBody *dto.RequestRestorePost, userID string) (*dto.ResponseRestorePost, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "RestorePostService")
+	postID := requestBody.PostID
+
+	if exp := s.checkPostModerator(ctx, postID, userID); exp != nil {
+		return nil, exp
+	}
+	deletion, err := s.repo.GetPostDeletion(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.NoDataFoundErrorCode, "Post can't be restored")
+		}
+		log.Errorf("[RestorePostService] couldn't fetch deletion of post id: %d, err: %v", postID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if time.Since(deletion.CreatedAt) > s.restoreWindow {
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode,
+			fmt.Sprintf("Posts can only be restored within %s of being deleted", s.restoreWindow))
+	}
+	actor, exp := newAuditActor(ctx, userID, common.AUDIT_ROLE_MODERATOR, requestBody.Reason)
+	if exp != nil {
+		return nil, exp
+	}
+
+	post, exp := s.repo.RestorePost(ctx, deletion.ID, richtext.Tags(deletion.Content), actor)
+	if exp != nil {
+		log.Errorf("[RestorePostService] couldn't restore post id: %d, err: %v", postID, exp)
+		return nil, exp
+	}
+	return &dto.ResponseRestorePost{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data: dto.ResponseRestorePostData{
+			PostID:        post.ID,
+			Content:       post.Content,
+			Status:        post.Status,
+			DeletedBy:     deletion.DeletedBy,
+			DeletedByRole: deletion.DeletedByRole,
+			DeletedAt:     fmt.Sprint(deletion.CreatedAt.Unix()),
+			RestoredBy:    userID,
+		},
+	}, nil
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Abhishekjha321/community_service/internal/common"
+)
+
+func TestShownContent(t *testing.T) {
+	deletedBy := map[int64]string{
+		1: common.AUDIT_ROLE_AUTHOR,
+		2: common.AUDIT_ROLE_MODERATOR,
+		3: common.AUDIT_ROLE_ADMIN,
+	}
+	tests := []struct {
+		name   string
+		postID int64
+		status string
+		want   string
+	}{
+		{name: "live post", postID: 1, status: common.POST_STATUS_PUBLISHED, want: "original"},
+		{name: "deleted by author", postID: 1, status: common.POST_STATUS_DELETED, want: authorDeletedContent},
+		{name: "deleted by moderator", postID: 2, status: common.POST_STATUS_DELETED, want: moderatorDeletedContent},
+		{name: "deleted by admin", postID: 3, status: common.POST_STATUS_DELETED, want: moderatorDeletedContent},
+		{name: "deleted without a recorded deletion", postID: 4, status: common.POST_STATUS_DELETED, want: authorDeletedContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shownContent(tt.postID, tt.status, "original", deletedBy); got != tt.want {
+				t.Errorf("shownContent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/richtext"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+)
+
+// EditPost lets authors change the content of their own live posts. The new
+// content goes through moderation like a new post. Mentions and hashtags are
+// parsed again and only newly mentioned users are notified.
+func (s *service) EditPost(ctx context.Context, requestBody *dto.RequestEditPost, userID string) (*dto.ResponseEditPost, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "EditPostService")
+
+	post, exp := s.getLivePost(ctx, requestBody.PostID)
+	if exp != nil {
+		return nil, exp
+	}
+	if post.UserID != userID {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.AccessDeniedErrorCode)
+	}
+	if exp := s.checkChannelPost(ctx, post.ChannelID, userID); exp != nil {
+		return nil, exp
+	}
+	settings, exp := s.getChannelSettings(ctx, post.ChannelID)
+	if exp != nil {
+		return nil, exp
+	}
+	if exp := checkPostLength(settings, requestBody.Content); exp != nil {
+		return nil, exp
+	}
+	attachments, err := s.repo.GetPostAttachments(ctx, []int64{post.ID})
+	if err != nil {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	flags, exp := s.moderatePost(ctx, dbModel.Post{
+		ID:        post.ID,
+		UserID:    post.UserID,
+		ChannelID: post.ChannelID,
+		Type:      post.Type,
+		ParentID:  post.ParentID,
+		Content:   requestBody.Content,
+	}, len(attachments) > 0)
+	if exp != nil {
+		return nil, exp
+	}
+	mentions, err := s.resolveMentions(ctx, requestBody.Content, userID)
+	if err != nil {
+		log.Errorf("[EditPostService] couldn't resolve mentions of post id: %d, err: %v", post.ID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	updated, err := s.repo.UpdatePostContent(ctx, post.ID, requestBody.Content, mentions, richtext.Tags(requestBody.Content))
+	if err != nil {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	s.flagPost(ctx, updated, flags)
+	return &dto.ResponseEditPost{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data: dto.ResponseEditPostData{
+			PostID:    updated.ID,
+			Content:   updated.Content,
+			UpdatedAt: fmt.Sprint(updated.UpdatedAt.Unix()),
+			Mentions:  buildMentionsData(mentions),
+		},
+	}, nil
+}
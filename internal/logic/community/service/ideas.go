@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"gorm.io/gorm"
+)
+
+// UpdateIdeaStatus lets a moderator of an ideas channel move an idea through
+// its lifecycle, optionally with an official response.
+func (s *service) UpdateIdeaStatus(ctx context.Context, requestBody *dto.RequestUpdateIdeaStatus, userID string) (*dto.ResponseUpdateIdeaStatus, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "UpdateIdeaStatusService")
+
+	status := strings.ToUpper(requestBody.Status)
+	if !common.IsValidIdeaStatus(status) {
+		log.Errorf("[UpdateIdeaStatusService] invalid idea status: %s", requestBody.Status)
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Idea status is not correct")
+	}
+
+	postID := strconv.FormatInt(requestBody.PostID, 10)
+	post, err := s.repo.CheckPostIDValidity(ctx, postID, "")
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode)
+		}
+		log.Errorf("[UpdateIdeaStatusService] couldn't fetch idea id: %s, err: %v", postID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if post.ID == 0 || post.Type != common.POST_TYPE_COMMENT {
+		log.Errorf("[UpdateIdeaStatusService] post id: %s is not an idea", postID)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode)
+	}
+	if post.Status == deleted {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.DeletedPostErrorCode)
+	}
+	settings, err := s.repo.GetChannelSettings(ctx, post.ChannelID)
+	if err != nil {
+		log.Errorf("[UpdateIdeaStatusService] couldn't fetch settings for channel id: %s, err: %v", post.ChannelID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if settings.ChannelMode != common.CHANNEL_MODE_IDEAS {
+		log.Errorf("[UpdateIdeaStatusService] channel id: %s is not an ideas channel", post.ChannelID)
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.PostIdErrorCode, "Post is not in an ideas channel")
+	}
+
+	isModerator, err := s.repo.IsChannelModerator(ctx, post.ChannelID, userID)
+	if err != nil {
+		log.Errorf("[UpdateIdeaStatusService] couldn't check moderator for user id: %s, err: %v", userID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if !isModerator {
+		log.Errorf("[UpdateIdeaStatusService] user id: %s is not a moderator of channel id: %s", userID, post.ChannelID)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.AccessDeniedErrorCode)
+	}
+
+	actor, exp := newAuditActor(ctx, userID, common.AUDIT_ROLE_MODERATOR, requestBody.Reason)
+	if exp != nil {
+		return nil, exp
+	}
+	idea, err := s.repo.UpdateIdeaStatus(ctx, requestBody.PostID, status, strings.TrimSpace(requestBody.OfficialResponse), actor)
+	if err != nil {
+		log.Errorf("[UpdateIdeaStatusService] couldn't update idea id: %s, err: %v", postID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+
+	return &dto.ResponseUpdateIdeaStatus{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data: dto.ResponseUpdateIdeaStatusData{
+			PostID:             idea.ID,
+			Status:             idea.IdeaStatus,
+			OfficialResponse:   idea.OfficialResponse,
+			OfficialResponseBy: idea.OfficialResponseBy,
+			UpdatedAt:          fmt.Sprint(idea.IdeaStatusUpdatedAt.Unix()),
+		},
+	}, nil
+}
+
+func (s *service) AddChannelModerator(ctx context.Context, requestBody *dto.RequestChannelModerator, actorID string) *exceptions.Exception {
+	log := logger.GetLogInstance(ctx, "AddChannelModeratorService")
+	channelID, userID := requestBody.ChannelID, requestBody.UserID
+	actor, exp := newAuditActor(ctx, actorID, common.AUDIT_ROLE_ADMIN, requestBody.Reason)
+	if exp != nil {
+		return exp
+	}
+	if err := s.repo.AddChannelModerator(ctx, channelID, userID, actor); err != nil {
+		log.Errorf("[AddChannelModeratorService] couldn't add moderator for channel id: %s and user id: %s, err: %v", channelID, userID, err)
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return nil
+}
+
+func (s *service) RemoveChannelModerator(ctx context.Context, requestBody *dto.RequestChannelModerator, actorID string) *exceptions.Exception {
+	log := logger.GetLogInstance(ctx, "RemoveChannelModeratorService")
+	channelID, userID := requestBody.ChannelID, requestBody.UserID
+	actor, exp := newAuditActor(ctx, actorID, common.AUDIT_ROLE_ADMIN, requestBody.Reason)
+	if exp != nil {
+		return exp
+	}
+	if err := s.repo.RemoveChannelModerator(ctx, channelID, userID, actor); err != nil {
+		log.Errorf("[RemoveChannelModeratorService] couldn't remove moderator for channel id: %s and user id: %s, err: %v", channelID, userID, err)
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return nil
+}
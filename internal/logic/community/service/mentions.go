@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/richtext"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+)
+
+const (
+	// maxPostMentions caps how many distinct users one post can mention, and
+	// so notify.
+	maxPostMentions        = 20
+	defaultMentionSearches = 10
+	maxMentionSearches     = 50
+)
+
+// resolveMentions parses the @username mentions of content and keeps those
+// matching a user. Unknown names, and users who blocked the author, stay plain
+// text.
+func (s *service) resolveMentions(ctx context.Context, content string, authorID string) ([]dbModel.PostMention, error) {
+	spans := richtext.Mentions(content)
+	if len(spans) == 0 {
+		return nil, nil
+	}
+	var userNames []string
+	seen := make(map[string]bool)
+	for _, span := range spans {
+		userName := strings.ToLower(span.Value)
+		if !seen[userName] && len(userNames) < maxPostMentions {
+			seen[userName] = true
+			userNames = append(userNames, userName)
+		}
+	}
+	users, err := s.repo.GetUsersByUserNames(ctx, userNames)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.UserID)
+	}
+	blocking, err := s.repo.GetBlockingUsers(ctx, userIDs, authorID)
+	if err != nil {
+		return nil, err
+	}
+	usersByName := make(map[string]dbModel.UserDetails, len(users))
+	for _, user := range users {
+		if !blocking[user.UserID] {
+			usersByName[strings.ToLower(user.UserName)] = user
+		}
+	}
+
+	var mentions []dbModel.PostMention
+	for _, span := range spans {
+		user, ok := usersByName[strings.ToLower(span.Value)]
+		if !ok {
+			continue
+		}
+		mentions = append(mentions, dbModel.PostMention{
+			UserID:      user.UserID,
+			UserName:    user.UserName,
+			StartOffset: span.Start,
+			EndOffset:   span.End,
+		})
+	}
+	return mentions, nil
+}
+
+// loadMentions returns the mention spans of the given posts keyed by post id.
+// Failures are logged and leave the mentions out.
+func (s *service) loadMentions(ctx context.Context, postIDs []int64) map[int64][]dto.ResponseMention {
+	log := logger.GetLogInstance(ctx, "LoadMentions")
+	result := make(map[int64][]dto.ResponseMention)
+
+	mentions, err := s.repo.GetPostMentions(ctx, postIDs)
+	if err != nil {
+		log.Errorf("[LoadMentions] couldn't fetch mentions, err: %v", err)
+		return result
+	}
+	for _, mention := range mentions {
+		result[mention.PostID] = append(result[mention.PostID], buildMentionData(mention))
+	}
+	return result
+}
+
+func buildMentionData(mention dbModel.PostMention) dto.ResponseMention {
+	return dto.ResponseMention{
+		UserID:   mention.UserID,
+		UserName: mention.UserName,
+		Start:    mention.StartOffset,
+		End:      mention.EndOffset,
+	}
+}
+
+func buildMentionsData(mentions []dbModel.PostMention) []dto.ResponseMention {
+	var result []dto.ResponseMention
+	for _, mention := range mentions {
+		result = append(result, buildMentionData(mention))
+	}
+	return result
+}
+
+// SearchMentionUsers suggests users to mention among the people who have
+// posted in the channel, matching the start of their user or real name.
+func (s *service) SearchMentionUsers(ctx context.Context, channelID string, prefix string, userID string, limit int) (*dto.ResponseMentionUsers, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "SearchMentionUsersService")
+
+	if exp := s.checkChannelRead(ctx, channelID, userID); exp != nil {
+		return nil, exp
+	}
+
+	if limit <= 0 {
+		limit = defaultMentionSearches
+	}
+	if limit > maxMentionSearches {
+		limit = maxMentionSearches
+	}
+	users, err := s.repo.SearchChannelUsers(ctx, channelID, strings.TrimPrefix(strings.TrimSpace(prefix), "@"), limit)
+	if err != nil {
+		log.Errorf("[SearchMentionUsersService] couldn't search users of channel id: %s, err: %v", channelID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	data := make([]dto.ResponseMentionUser, 0, len(users))
+	for _, user := range users {
+		fullName := user.FirstName
+		if user.MiddleName != "" {
+			fullName += " " + user.MiddleName
+		}
+		if user.LastName != "" {
+			fullName += " " + user.LastName
+		}
+		data = append(data, dto.ResponseMentionUser{
+			UserID:          user.UserID,
+			UserName:        user.UserName,
+			FullName:        fullName,
+			ProfileImageURL: user.ProfileImageUrl,
+		})
+	}
+	return &dto.ResponseMentionUsers{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data:    data,
+	}, nil
+}
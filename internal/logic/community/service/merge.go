@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	model "github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+)
+
+// MergePosts lets a moderator of the duplicate's channel merge it into the
+// canonical post.
+func (s *service) MergePosts(ctx context.Context, requestBody *dto.RequestMergePosts, userID string) (*dto.ResponsePostMerge, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "MergePostsService")
+
+	if requestBody.SourcePostID == requestBody.TargetPostID {
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "A post cannot be merged into itself")
+	}
+	if exp := s.checkPostModerator(ctx, requestBody.SourcePostID, userID); exp != nil {
+		return nil, exp
+	}
+	actor, exp := newAuditActor(ctx, userID, common.AUDIT_ROLE_MODERATOR, requestBody.Reason)
+	if exp != nil {
+		return nil, exp
+	}
+
+	merge, exp := s.repo.MergePosts(ctx, requestBody.SourcePostID, requestBody.TargetPostID, actor)
+	if exp != nil {
+		log.Errorf("[MergePostsService] couldn't merge post id: %d into post id: %d, err: %v", requestBody.SourcePostID, requestBody.TargetPostID, exp)
+		return nil, exp
+	}
+	return buildPostMergeResponse(merge), nil
+}
+
+// RevertPostMerge undoes a merge. Any moderator of the channel may revert it,
+// not only the one who merged.
+func (s *service) RevertPostMerge(ctx context.Context, requestBody *dto.RequestRevertPostMerge, userID string) (*dto.ResponsePostMerge, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "RevertPostMergeService")
+	mergeID := requestBody.MergeID
+
+	merge, err := s.repo.GetPostMerge(ctx, mergeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.NoDataFoundErrorCode, "Merge not found")
+		}
+		log.Errorf("[RevertPostMergeService] couldn't fetch merge id: %d, err: %v", mergeID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if exp := s.checkPostModerator(ctx, merge.SourcePostID, userID); exp != nil {
+		return nil, exp
+	}
+	actor, exp := newAuditActor(ctx, userID, common.AUDIT_ROLE_MODERATOR, requestBody.Reason)
+	if exp != nil {
+		return nil, exp
+	}
+
+	merge, exp = s.repo.RevertPostMerge(ctx, mergeID, actor)
+	if exp != nil {
+		log.Errorf("[RevertPostMergeService] couldn't revert merge id: %d, err: %v", mergeID, exp)
+		return nil, exp
+	}
+	return buildPostMergeResponse(merge), nil
+}
+
+// checkPostModerator makes sure userID moderates the channel of postID.
+func (s *service) checkPostModerator(ctx context.Context, postID int64, userID string) *exceptions.Exception {
+	log := logger.GetLogInstance(ctx, "CheckPostModerator")
+	post, err := s.repo.CheckPostIDValidity(ctx, strconv.FormatInt(postID, 10), "")
+	if err != nil {
+		log.Errorf("[CheckPostModerator] couldn't fetch post id: %d, err: %v", postID, err)
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if post.ID == 0 {
+		return exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode)
+	}
+	isModerator, err := s.repo.IsChannelModerator(ctx, post.ChannelID, userID)
+	if err != nil {
+		log.Errorf("[CheckPostModerator] couldn't check moderator for user id: %s, err: %v", userID, err)
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if !isModerator {
+		log.Errorf("[CheckPostModerator] user id: %s is not a moderator of channel id: %s", userID, post.ChannelID)
+		return exceptions.GetExceptionByErrorCode(exceptions.AccessDeniedErrorCode)
+	}
+	return nil
+}
+
+func buildPostMergeResponse(merge *dbModel.PostMerge) *dto.ResponsePostMerge {
+	var (
+		movedLikes    []model.MovedLike
+		movedReplyIDs []int64
+	)
+	_ = json.Unmarshal([]byte(merge.MovedLikes), &movedLikes)
+	_ = json.Unmarshal([]byte(merge.MovedReplyIDs), &movedReplyIDs)
+
+	data := dto.ResponsePostMergeData{
+		MergeID:         merge.ID,
+		SourcePostID:    merge.SourcePostID,
+		TargetPostID:    merge.TargetPostID,
+		MergedBy:        merge.MergedBy,
+		MovedLikesCount: len(movedLikes),
+		MovedReplyCount: len(movedReplyIDs),
+		RevertedBy:      merge.RevertedBy,
+		CreatedAt:       fmt.Sprint(merge.CreatedAt.Unix()),
+	}
+	if merge.RevertedAt != nil {
+		data.RevertedAt = fmt.Sprint(merge.RevertedAt.Unix())
+	}
+	return &dto.ResponsePostMerge{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data:    data,
+	}
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	"github.com/Abhishekjha321/community_service/internal/moderation"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+)
+
+const (
+	maxBlockedWords       = 500
+	maxBlockedWordLength  = 100
+	defaultPostFlagsLimit = 20
+	maxPostFlagsLimit     = 100
+
+	reviewActionApprove = "approve"
+	reviewActionRemove  = "remove"
+)
+
+// moderatePost runs the moderation pipeline on a post about to be created or
+// edited. It refuses rejected posts and returns the flags to record once the
+// post is saved.
+func (s *service) moderatePost(ctx context.Context, post dbModel.Post, hasAttachments bool) ([]moderation.Decision, *exceptions.Exception) {
+	result := s.moderation.Run(ctx, moderation.Content{
+		PostID:         post.ID,
+		UserID:         post.UserID,
+		ChannelID:      post.ChannelID,
+		Type:           post.Type,
+		ParentID:       post.ParentID,
+		Text:           post.Content,
+		HasAttachments: hasAttachments,
+	})
+	if result.Rejected() {
+		logger.GetLogInstance(ctx, "ModeratePost").Infof("[ModeratePost] post of user id: %s in channel id: %s rejected by %s: %s",
+			post.UserID, post.ChannelID, result.Rejection.Stage, result.Rejection.Reason)
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.ModerationErrorCode, result.Rejection.Reason)
+	}
+	return result.Flags, nil
+}
+
+// flagPost records the flags raised on a post for moderators to review.
+// Failures are logged; the post itself is kept.
+func (s *service) flagPost(ctx context.Context, post *dbModel.Post, flags []moderation.Decision) {
+	if len(flags) == 0 {
+		return
+	}
+	postFlags := make([]dbModel.PostFlag, 0, len(flags))
+	for _, flag := range flags {
+		postFlags = append(postFlags, dbModel.PostFlag{
+			PostID:    post.ID,
+			ChannelID: post.ChannelID,
+			UserID:    post.UserID,
+			Stage:     flag.Stage,
+			Reason:    flag.Reason,
+			Status:    common.FLAG_STATUS_OPEN,
+		})
+	}
+	if err := s.repo.SavePostFlags(ctx, postFlags); err != nil {
+		logger.GetLogInstance(ctx, "FlagPost").Errorf("[FlagPost] couldn't flag post id: %d, err: %v", post.ID, err)
+	}
+}
+
+func (s *service) GetChannelBlocklist(ctx context.Context, channelID string) (*dto.ResponseChannelBlocklist, *exceptions.Exception) {
+	words, err := s.repo.GetChannelBlockedWords(ctx, channelID)
+	if err != nil {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return buildChannelBlocklistResponse(channelID, words), nil
+}
+
+// UpdateChannelBlocklist replaces the words blocked in a channel.
+func (s *service) UpdateChannelBlocklist(ctx context.Context, requestBody *dto.RequestChannelBlocklist) (*dto.ResponseChannelBlocklist, *exceptions.Exception) {
+	words := make([]string, 0, len(requestBody.Words))
+	seen := make(map[string]bool)
+	for _, word := range requestBody.Words {
+		word = moderation.NormalizeWord(word)
+		if word == "" || seen[word] {
+			continue
+		}
+		if utf8.RuneCountInString(word) > maxBlockedWordLength {
+			return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode,
+				fmt.Sprintf("Blocked words can't be longer than %d characters", maxBlockedWordLength))
+		}
+		seen[word] = true
+		words = append(words, word)
+	}
+	if len(words) > maxBlockedWords {
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode,
+			fmt.Sprintf("A channel can block up to %d words", maxBlockedWords))
+	}
+	if err := s.repo.SetChannelBlockedWords(ctx, requestBody.ChannelID, words); err != nil {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return buildChannelBlocklistResponse(requestBody.ChannelID, words), nil
+}
+
+// GetPostFlags lists the posts flagged in a channel to its moderators. An
+// empty status lists the open flags.
+func (s *service) GetPostFlags(ctx context.Context, channelID string, status string, userID string, limit int, cursor *model.PostFlagCursor) (*dto.ResponsePostFlags, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "GetPostFlagsService")
+
+	status = strings.ToUpper(strings.TrimSpace(status))
+	switch status {
+	case "":
+		status = common.FLAG_STATUS_OPEN
+	case common.FLAG_STATUS_OPEN, common.FLAG_STATUS_APPROVED, common.FLAG_STATUS_REMOVED:
+	default:
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Status should be one of OPEN, APPROVED or REMOVED")
+	}
+	isModerator, err := s.repo.IsChannelModerator(ctx, channelID, userID)
+	if err != nil {
+		log.Errorf("[GetPostFlagsService] couldn't check moderator for user id: %s, err: %v", userID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if !isModerator {
+		log.Errorf("[GetPostFlagsService] user id: %s is not a moderator of channel id: %s", userID, channelID)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.AccessDeniedErrorCode)
+	}
+
+	if limit <= 0 {
+		limit = defaultPostFlagsLimit
+	}
+	if limit > maxPostFlagsLimit {
+		limit = maxPostFlagsLimit
+	}
+	var beforeID int64
+	if cursor != nil {
+		beforeID = cursor.ID
+	}
+	// fetch one extra row to know whether another page exists
+	flags, err := s.repo.GetPostFlags(ctx, channelID, status, limit+1, beforeID)
+	if err != nil {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.QueryFailedErrorCode)
+	}
+	var nextCursor string
+	if len(flags) > limit {
+		flags = flags[:limit]
+		nextCursor, err = common.EncodeCursor(model.PostFlagCursor{ID: flags[len(flags)-1].ID})
+		if err != nil {
+			log.Errorf("[GetPostFlagsService] couldn't build next cursor, err: %v", err)
+			return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+		}
+	}
+
+	data := make([]dto.ResponsePostFlagData, 0, len(flags))
+	for _, flag := range flags {
+		var reviewedAt string
+		if flag.ReviewedAt != nil {
+			reviewedAt = fmt.Sprint(flag.ReviewedAt.Unix())
+		}
+		data = append(data, dto.ResponsePostFlagData{
+			ID:         flag.ID,
+			PostID:     flag.PostID,
+			ChannelID:  flag.ChannelID,
+			UserID:     flag.UserID,
+			Stage:      flag.Stage,
+			Reason:     flag.Reason,
+			Status:     flag.Status,
+			ReviewedBy: flag.ReviewedBy,
+			ReviewedAt: reviewedAt,
+			CreatedAt:  fmt.Sprint(flag.CreatedAt.Unix()),
+		})
+	}
+	return &dto.ResponsePostFlags{
+		Code:       APISuccessCode,
+		Message:    APISuccessMessage,
+		Data:       data,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// ReviewPostFlags lets a moderator of the post's channel approve a flagged
+// post, which publishes it again if reports hid it, or remove it, which
+// hides the post.
+func (s *service) ReviewPostFlags(ctx context.Context, requestBody *dto.RequestReviewPostFlags, userID string) *exceptions.Exception {
+	var status string
+	switch strings.ToLower(strings.TrimSpace(requestBody.Action)) {
+	case reviewActionApprove:
+		status = common.FLAG_STATUS_APPROVED
+	case reviewActionRemove:
+		status = common.FLAG_STATUS_REMOVED
+	default:
+		return exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Action should be approve or remove")
+	}
+	if exp := s.checkPostModerator(ctx, requestBody.PostID, userID); exp != nil {
+		return exp
+	}
+	actor, exp := newAuditActor(ctx, userID, common.AUDIT_ROLE_MODERATOR, requestBody.Reason)
+	if exp != nil {
+		return exp
+	}
+	reviewed, err := s.repo.ReviewPostFlags(ctx, requestBody.PostID, status, actor)
+	if err != nil {
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if reviewed == 0 {
+		return exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.NoDataFoundErrorCode, "Post has no open flags")
+	}
+	return nil
+}
+
+func buildChannelBlocklistResponse(channelID string, words []string) *dto.ResponseChannelBlocklist {
+	if words == nil {
+		words = []string{}
+	}
+	return &dto.ResponseChannelBlocklist{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data: dto.ResponseChannelBlocklistData{
+			ChannelID: channelID,
+			Words:     words,
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	model "github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+)
+
+const (
+	defaultNotificationsLimit = 20
+	maxNotificationsLimit     = 100
+)
+
+func (s *service) GetNotifications(ctx context.Context, userID string, limit int, unreadOnly bool, cursor *model.NotificationCursor) (*dto.ResponseGetNotifications, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "GetNotificationsService")
+
+	if limit <= 0 {
+		limit = defaultNotificationsLimit
+	}
+	if limit > maxNotificationsLimit {
+		limit = maxNotificationsLimit
+	}
+	var beforeID int64
+	if cursor != nil {
+		beforeID = cursor.ID
+	}
+
+	// fetch one extra row to know whether another page exists
+	notifications, err := s.repo.GetNotifications(ctx, userID, limit+1, beforeID, unreadOnly)
+	if err != nil {
+		log.Errorf("[GetNotificationsService] couldn't fetch notifications for user id: %s, err: %v", userID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.QueryFailedErrorCode)
+	}
+	var nextCursor string
+	if len(notifications) > limit {
+		notifications = notifications[:limit]
+		nextCursor, err = common.EncodeCursor(model.NotificationCursor{ID: notifications[len(notifications)-1].ID})
+		if err != nil {
+			log.Errorf("[GetNotificationsService] couldn't build next cursor, err: %v", err)
+			return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+		}
+	}
+
+	unreadCount, err := s.repo.GetUnreadNotificationsCount(ctx, userID)
+	if err != nil {
+		log.Errorf("[GetNotificationsService] couldn't count unread notifications for user id: %s, err: %v", userID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.QueryFailedErrorCode)
+	}
+
+	data := make([]dto.ResponseNotificationData, 0, len(notifications))
+	for _, notification := range notifications {
+		data = append(data, dto.ResponseNotificationData{
+			ID:        notification.ID,
+			Type:      notification.Type,
+			ChannelID: notification.ChannelID,
+			PostID:    notification.PostID,
+			ActorID:   notification.ActorID,
+			Message:   notification.Message,
+			IsRead:    notification.ReadAt != nil,
+			CreatedAt: fmt.Sprint(notification.CreatedAt.Unix()),
+		})
+	}
+
+	return &dto.ResponseGetNotifications{
+		Code:        APISuccessCode,
+		Message:     APISuccessMessage,
+		Data:        data,
+		UnreadCount: unreadCount,
+		NextCursor:  nextCursor,
+	}, nil
+}
+
+// ReadNotifications marks the given notifications as read, or every unread
+// notification of the user when ids is empty.
+func (s *service) ReadNotifications(ctx context.Context, userID string, ids []int64) (*dto.ResponseReadNotifications, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "ReadNotificationsService")
+
+	updated, err := s.repo.MarkNotificationsRead(ctx, userID, ids)
+	if err != nil {
+		log.Errorf("[ReadNotificationsService] couldn't mark notifications read for user id: %s, err: %v", userID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.QueryFailedErrorCode)
+	}
+	return &dto.ResponseReadNotifications{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data: dto.ResponseReadNotificationsData{
+			UpdatedCount: updated,
+		},
+	}, nil
+}
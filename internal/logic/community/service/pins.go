@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	logger "github.com/Abhishekjha321/community_service/log"
+)
+
+// PinPost pins a post to the top of its channel or unpins it. Only moderators
+// of the post's channel may do so, and only live top level posts can be
+// pinned.
+func (s *service) PinPost(ctx context.Context, requestBody *dto.RequestPinPost, userID string) (*dto.ResponsePinPost, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "PinPostService")
+	postID := requestBody.PostID
+
+	post, exp := s.getLivePost(ctx, postID)
+	if exp != nil {
+		return nil, exp
+	}
+	if requestBody.Pinned {
+		if post.ParentID != 0 {
+			return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "A reply can't be pinned")
+		}
+		if post.Status == common.POST_STATUS_HIDDEN {
+			return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "A hidden post can't be pinned")
+		}
+	}
+	if exp := s.checkPostModerator(ctx, postID, userID); exp != nil {
+		return nil, exp
+	}
+	actor, exp := newAuditActor(ctx, userID, common.AUDIT_ROLE_MODERATOR, requestBody.Reason)
+	if exp != nil {
+		return nil, exp
+	}
+
+	updated, err := s.repo.SetPostPinned(ctx, postID, requestBody.Pinned, actor)
+	if err != nil {
+		log.Errorf("[PinPostService] couldn't set pinned: %v of post id: %d, err: %v", requestBody.Pinned, postID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return &dto.ResponsePinPost{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data: dto.ResponsePinPostData{
+			PostID:   updated.ID,
+			IsPinned: updated.IsPinned,
+		},
+	}, nil
+}
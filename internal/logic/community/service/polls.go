@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+)
+
+const (
+	minPollOptions = 2
+	maxPollOptions = 10
+)
+
+// newPoll validates the poll of a POLL post and returns it together with its
+// trimmed options.
+func newPoll(post dbModel.Post, requestPoll *dto.RequestCreatePoll) (dbModel.Poll, []string, *exceptions.Exception) {
+	if requestPoll == nil {
+		return dbModel.Poll{}, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Poll details are required for a poll")
+	}
+	if post.ParentID != 0 {
+		return dbModel.Poll{}, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "A poll can't be a reply")
+	}
+	options := make([]string, 0, len(requestPoll.Options))
+	seen := make(map[string]bool)
+	for _, option := range requestPoll.Options {
+		option = strings.TrimSpace(option)
+		if option == "" || seen[strings.ToLower(option)] {
+			return dbModel.Poll{}, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Poll options must be non-empty and distinct")
+		}
+		seen[strings.ToLower(option)] = true
+		options = append(options, option)
+	}
+	if len(options) < minPollOptions || len(options) > maxPollOptions {
+		return dbModel.Poll{}, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode,
+			fmt.Sprintf("A poll needs between %d and %d options", minPollOptions, maxPollOptions))
+	}
+
+	poll := dbModel.Poll{
+		MultiChoice:           requestPoll.MultiChoice,
+		HideResultsUntilVoted: requestPoll.HideResultsUntilVoted,
+	}
+	if requestPoll.ClosesAt != 0 {
+		closesAt := time.Unix(requestPoll.ClosesAt, 0)
+		if !closesAt.After(time.Now()) {
+			return dbModel.Poll{}, nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Poll close time must be in the future")
+		}
+		poll.ClosesAt = &closesAt
+	}
+	return poll, options, nil
+}
+
+// createPoll stores the post, the poll and its options together.
+func (s *service) createPoll(ctx context.Context, post dbModel.Post, poll dbModel.Poll, options []string) (*dbModel.Post, *dto.ResponsePollData, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "CreatePoll")
+
+	created, err := s.repo.InsertPollPost(ctx, post, poll, options)
+	if err != nil {
+		log.Errorf("[CreatePoll] Error while creating poll, err: %s", err)
+		return nil, nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	pollData := s.loadPolls(ctx, []common.Post{{ID: created.ID, UserID: created.UserID, Type: created.Type}}, created.UserID)[created.ID]
+	return created, pollData, nil
+}
+
+// VotePoll casts the caller's ballot on a poll and returns the updated tallies.
+func (s *service) VotePoll(ctx context.Context, requestBody *dto.RequestVotePoll, userID string) (*dto.ResponseVotePoll, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "VotePollService")
+
+	post, exp := s.getLivePost(ctx, requestBody.PostID)
+	if exp != nil {
+		return nil, exp
+	}
+	if post.Type != common.POST_TYPE_POLL {
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Post is not a poll")
+	}
+	if exp := s.checkChannelRead(ctx, post.ChannelID, userID); exp != nil {
+		return nil, exp
+	}
+	polls, err := s.repo.GetPolls(ctx, []int64{post.ID})
+	if err != nil {
+		log.Errorf("[VotePollService] couldn't fetch poll id: %d, err: %v", post.ID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if len(polls) == 0 {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode)
+	}
+	poll := polls[0]
+	if isPollClosed(poll, time.Now()) {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.PollClosedErrorCode)
+	}
+
+	options, err := s.repo.GetPollOptions(ctx, []int64{post.ID})
+	if err != nil {
+		log.Errorf("[VotePollService] couldn't fetch options of poll id: %d, err: %v", post.ID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	validOptions := make(map[int64]bool, len(options))
+	for _, option := range options {
+		validOptions[option.ID] = true
+	}
+	var optionIDs []int64
+	picked := make(map[int64]bool)
+	for _, optionID := range requestBody.OptionIDs {
+		if !validOptions[optionID] {
+			return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Option doesn't belong to this poll")
+		}
+		if !picked[optionID] {
+			picked[optionID] = true
+			optionIDs = append(optionIDs, optionID)
+		}
+	}
+	if len(optionIDs) == 0 {
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "At least one option is required")
+	}
+	if !poll.MultiChoice && len(optionIDs) > 1 {
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "This poll allows a single choice")
+	}
+
+	if exp := s.repo.VotePoll(ctx, post.ID, userID, optionIDs); exp != nil {
+		log.Errorf("[VotePollService] couldn't vote on poll id: %d for user id: %s, err: %v", post.ID, userID, exp)
+		return nil, exp
+	}
+
+	pollData := s.loadPolls(ctx, []common.Post{*post}, userID)[post.ID]
+	if pollData == nil {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return &dto.ResponseVotePoll{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data:    *pollData,
+	}, nil
+}
+
+// loadPolls builds the poll data of the POLL posts among posts as seen by
+// userID, keyed by post id. Failures are logged and leave the polls out, the
+// same way the other per-viewer feed details are handled.
+func (s *service) loadPolls(ctx context.Context, posts []common.Post, userID string) map[int64]*dto.ResponsePollData {
+	log := logger.GetLogInstance(ctx, "LoadPolls")
+	result := make(map[int64]*dto.ResponsePollData)
+
+	authors := make(map[int64]string)
+	var postIDs []int64
+	for _, post := range posts {
+		if post.Type == common.POST_TYPE_POLL {
+			postIDs = append(postIDs, post.ID)
+			authors[post.ID] = post.UserID
+		}
+	}
+	if len(postIDs) == 0 {
+		return result
+	}
+
+	polls, err := s.repo.GetPolls(ctx, postIDs)
+	if err != nil {
+		log.Errorf("[LoadPolls] couldn't fetch polls, err: %v", err)
+		return result
+	}
+	options, err := s.repo.GetPollOptions(ctx, postIDs)
+	if err != nil {
+		log.Errorf("[LoadPolls] couldn't fetch poll options, err: %v", err)
+		return result
+	}
+	votes, err := s.repo.GetUserPollVotes(ctx, userID, postIDs)
+	if err != nil {
+		log.Errorf("[LoadPolls] couldn't fetch poll votes of user id: %s, err: %v", userID, err)
+		return result
+	}
+
+	optionsByPoll := make(map[int64][]dbModel.PollOption)
+	for _, option := range options {
+		optionsByPoll[option.PostID] = append(optionsByPoll[option.PostID], option)
+	}
+	selected := make(map[int64]bool)
+	voted := make(map[int64]bool)
+	for _, vote := range votes {
+		selected[vote.OptionID] = true
+		voted[vote.PostID] = true
+	}
+
+	now := time.Now()
+	for _, poll := range polls {
+		isClosed := isPollClosed(poll, now)
+		hidden := poll.HideResultsUntilVoted && !voted[poll.PostID] && !isClosed && authors[poll.PostID] != userID
+		data := &dto.ResponsePollData{
+			MultiChoice:           poll.MultiChoice,
+			HideResultsUntilVoted: poll.HideResultsUntilVoted,
+			IsClosed:              isClosed,
+			HasVoted:              voted[poll.PostID],
+			ResultsHidden:         hidden,
+			Options:               []dto.ResponsePollOption{},
+		}
+		if poll.ClosesAt != nil {
+			data.ClosesAt = fmt.Sprint(poll.ClosesAt.Unix())
+		}
+		if !hidden {
+			data.VoterCount = poll.VoterCount
+		}
+		for _, option := range optionsByPoll[poll.PostID] {
+			pollOption := dto.ResponsePollOption{
+				ID:         option.ID,
+				Text:       option.Text,
+				IsSelected: selected[option.ID],
+			}
+			if !hidden {
+				pollOption.VoteCount = option.VoteCount
+			}
+			data.Options = append(data.Options, pollOption)
+		}
+		result[poll.PostID] = data
+	}
+	return result
+}
+
+func isPollClosed(poll dbModel.Poll, now time.Time) bool {
+	return poll.ClosesAt != nil && !now.Before(*poll.ClosesAt)
+}
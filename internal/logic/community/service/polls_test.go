@@ -0,0 +1,55 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+)
+
+func TestNewPoll(t *testing.T) {
+	tooMany := make([]string, maxPollOptions+1)
+	for i := range tooMany {
+		tooMany[i] = string(rune('a' + i))
+	}
+	tests := []struct {
+		name        string
+		post        dbModel.Post
+		poll        *dto.RequestCreatePoll
+		wantOptions []string
+		wantErr     bool
+	}{
+		{name: "valid", poll: &dto.RequestCreatePoll{Options: []string{" yes ", "no"}}, wantOptions: []string{"yes", "no"}},
+		{name: "missing poll", wantErr: true},
+		{name: "reply", post: dbModel.Post{ParentID: 1}, poll: &dto.RequestCreatePoll{Options: []string{"yes", "no"}}, wantErr: true},
+		{name: "empty option", poll: &dto.RequestCreatePoll{Options: []string{"yes", " "}}, wantErr: true},
+		{name: "duplicate options", poll: &dto.RequestCreatePoll{Options: []string{"Yes", "yes"}}, wantErr: true},
+		{name: "too few options", poll: &dto.RequestCreatePoll{Options: []string{"yes"}}, wantErr: true},
+		{name: "too many options", poll: &dto.RequestCreatePoll{Options: tooMany}, wantErr: true},
+		{name: "closes in the past", poll: &dto.RequestCreatePoll{Options: []string{"yes", "no"}, ClosesAt: time.Now().Add(-time.Hour).Unix()}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, options, exp := newPoll(tt.post, tt.poll)
+			if (exp != nil) != tt.wantErr {
+				t.Fatalf("newPoll() error = %v, wantErr %v", exp, tt.wantErr)
+			}
+			if !reflect.DeepEqual(options, tt.wantOptions) {
+				t.Errorf("newPoll() options = %v, want %v", options, tt.wantOptions)
+			}
+		})
+	}
+}
+
+func TestNewPollClosesAt(t *testing.T) {
+	closesAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	poll, _, exp := newPoll(dbModel.Post{}, &dto.RequestCreatePoll{Options: []string{"yes", "no"}, MultiChoice: true, ClosesAt: closesAt.Unix()})
+	if exp != nil {
+		t.Fatalf("newPoll() error = %v", exp)
+	}
+	if !poll.MultiChoice || poll.ClosesAt == nil || !poll.ClosesAt.Equal(closesAt) {
+		t.Errorf("newPoll() = %+v, want a multi choice poll closing at %v", poll, closesAt)
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	"github.com/Abhishekjha321/community_service/internal/richtext"
+	logger "github.com/Abhishekjha321/community_service/log"
+)
+
+const (
+	// maxQuoteExcerptLen is how many characters of the quoted post are shown
+	// inside a quote.
+	maxQuoteExcerptLen = 280
+	// quoteUnavailableStatus stands for a quoted post that no longer exists.
+	quoteUnavailableStatus  = "UNAVAILABLE"
+	quoteUnavailableExcerpt = "This post is no longer available."
+)
+
+// checkQuotedPost makes sure a new post by userID can quote quotedPostID.
+// Posts of any channel the user can read can be quoted as long as they are
+// live and not hidden.
+func (s *service) checkQuotedPost(ctx context.Context, quotedPostID int64, userID string) *exceptions.Exception {
+	if quotedPostID == 0 {
+		return nil
+	}
+	log := logger.GetLogInstance(ctx, "CheckQuotedPost")
+	quoted, exp := s.getLivePost(ctx, quotedPostID)
+	if exp != nil {
+		if exp.ErrorCode == exceptions.SomethingWentWrongErrorCode {
+			return exp
+		}
+		log.Errorf("[CheckQuotedPost] post id: %d can't be quoted: %s", quotedPostID, exp.ErrorMessage)
+		return exceptions.GetExceptionByErrorCode(exceptions.QuotedPostErrorCode)
+	}
+	if quoted.Status == common.POST_STATUS_HIDDEN {
+		log.Errorf("[CheckQuotedPost] post id: %d is hidden", quotedPostID)
+		return exceptions.GetExceptionByErrorCode(exceptions.QuotedPostErrorCode)
+	}
+	if exp := s.checkChannelRead(ctx, quoted.ChannelID, userID); exp != nil {
+		if exp.ErrorCode == exceptions.SomethingWentWrongErrorCode {
+			return exp
+		}
+		return exceptions.GetExceptionByErrorCode(exceptions.QuotedPostErrorCode)
+	}
+	return nil
+}
+
+// loadQuotedPosts returns the snapshots of the given quoted posts keyed by
+// their id. Quoted posts that are gone or whose authors are hidden from the
+// viewer come back unavailable, and failures are logged and leave the quotes
+// out.
+func (s *service) loadQuotedPosts(ctx context.Context, quotedPostIDs []int64, viewerID string) map[int64]*dto.ResponseQuotedPost {
+	log := logger.GetLogInstance(ctx, "LoadQuotedPosts")
+	result := make(map[int64]*dto.ResponseQuotedPost)
+
+	var ids []int64
+	for _, id := range quotedPostIDs {
+		if id != 0 && result[id] == nil {
+			result[id] = unavailableQuote(id, quoteUnavailableStatus)
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return result
+	}
+	// the moderator view of a channel doesn't reach posts quoted from others
+	posts, err := s.repo.GetQuotedPosts(ctx, ids, model.Viewer{UserID: viewerID})
+	if err != nil {
+		log.Errorf("[LoadQuotedPosts] couldn't fetch quoted posts, err: %v", err)
+		return map[int64]*dto.ResponseQuotedPost{}
+	}
+	channelIDs := make([]string, 0, len(posts))
+	for _, post := range posts {
+		channelIDs = append(channelIDs, post.ChannelID)
+	}
+	restricted := s.restrictedChannels(ctx, viewerID, channelIDs)
+	for _, post := range posts {
+		if restricted[post.ChannelID] {
+			continue
+		}
+		if post.Status == common.POST_STATUS_DELETED || post.Status == common.POST_STATUS_HIDDEN {
+			result[post.ID] = unavailableQuote(post.ID, post.Status)
+			continue
+		}
+		result[post.ID] = &dto.ResponseQuotedPost{
+			PostID:    post.ID,
+			ChannelID: post.ChannelID,
+			UserID:    post.UserID,
+			UserName:  buildUserName(post.FirstName, post.MiddleName, post.LastName),
+			Avatar:    post.ProfileImageUrl,
+			Type:      post.Type,
+			Excerpt:   richtext.Excerpt(post.Content, maxQuoteExcerptLen),
+			Status:    post.Status,
+			Available: true,
+			CreatedAt: fmt.Sprint(post.CreatedAt.Unix()),
+		}
+	}
+	return result
+}
+
+// unavailableQuote stands in for a quoted post whose content can't be shown
+// anymore, without its author or content.
+func unavailableQuote(postID int64, status string) *dto.ResponseQuotedPost {
+	return &dto.ResponseQuotedPost{
+		PostID:  postID,
+		Excerpt: quoteUnavailableExcerpt,
+		Status:  status,
+	}
+}
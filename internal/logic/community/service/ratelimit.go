@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/ratelimit"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+)
+
+const (
+	DefaultPostLimit  = 10
+	DefaultPostWindow = time.Minute
+
+	postRateScope = "post"
+)
+
+// checkPostRate counts a post against the user's posting limit and the
+// channel's slow mode, refusing it when either is used up. Moderators of the
+// channel are exempt. Posting isn't blocked when Redis can't be reached.
+func (s *service) checkPostRate(ctx context.Context, settings *dbModel.ChannelSetting, userID string) *exceptions.Exception {
+	if s.limiter == nil {
+		return nil
+	}
+	log := logger.GetLogInstance(ctx, "CheckPostRate")
+
+	isModerator, err := s.repo.IsChannelModerator(ctx, settings.ChannelID, userID)
+	if err != nil {
+		log.Errorf("[CheckPostRate] couldn't check moderator of channel id: %s, err: %v", settings.ChannelID, err)
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if isModerator {
+		return nil
+	}
+
+	userRule := ratelimit.Rule{Key: ratelimit.Key(postRateScope, userID), Rate: s.postRate}
+	slowModeRule := ratelimit.Rule{
+		Key:  ratelimit.Key(postRateScope, userID, "channel", settings.ChannelID),
+		Rate: ratelimit.Rate{Limit: 1, Window: time.Duration(settings.SlowModeSeconds) * time.Second},
+	}
+	refused, wait, err := s.limiter.Hit(ctx, userRule, slowModeRule)
+	if err != nil {
+		log.Errorf("[CheckPostRate] couldn't count post of user id: %s, err: %v", userID, err)
+		return nil
+	}
+	if refused == nil {
+		return nil
+	}
+	log.Infof("[CheckPostRate] user id: %s is rate limited in channel id: %s for %s", userID, settings.ChannelID, wait)
+	if refused.Key == slowModeRule.Key {
+		return exceptions.GetRateLimitedException(
+			fmt.Sprintf("Slow mode is on, you can post in this channel once every %d seconds", settings.SlowModeSeconds), wait)
+	}
+	return exceptions.GetRateLimitedException("You're posting too fast, please try again later", wait)
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+)
+
+// AddUserRelation blocks or mutes another user. Blocking or muting someone
+// twice is not an error.
+func (s *service) AddUserRelation(ctx context.Context, requestBody *dto.RequestUserRelation, userID string) *exceptions.Exception {
+	log := logger.GetLogInstance(ctx, "AddUserRelationService")
+
+	relationType, exp := checkUserRelation(requestBody, userID)
+	if exp != nil {
+		return exp
+	}
+	target, err := s.repo.GetUserDetailsByUserId(ctx, requestBody.TargetUserID)
+	if err != nil {
+		log.Errorf("[AddUserRelationService] couldn't fetch user id: %s, err: %v", requestBody.TargetUserID, err)
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if target.UserID == "" {
+		return exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.NoDataFoundErrorCode, "User not found")
+	}
+
+	err = s.repo.AddUserRelation(ctx, dbModel.UserRelation{
+		UserID:       userID,
+		TargetUserID: requestBody.TargetUserID,
+		Type:         relationType,
+	})
+	if err != nil {
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return nil
+}
+
+// RemoveUserRelation takes a block or a mute back. Removing one that doesn't
+// exist is not an error.
+func (s *service) RemoveUserRelation(ctx context.Context, requestBody *dto.RequestUserRelation, userID string) *exceptions.Exception {
+	relationType, exp := checkUserRelation(requestBody, userID)
+	if exp != nil {
+		return exp
+	}
+	if err := s.repo.RemoveUserRelation(ctx, userID, requestBody.TargetUserID, relationType); err != nil {
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return nil
+}
+
+// GetUserRelations lists the users the user blocked or muted. An empty
+// relationType lists both.
+func (s *service) GetUserRelations(ctx context.Context, userID string, relationType string) (*dto.ResponseUserRelations, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "GetUserRelationsService")
+
+	relationType = strings.ToUpper(strings.TrimSpace(relationType))
+	if relationType != "" && !isUserRelationType(relationType) {
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Type should be one of BLOCK or MUTE")
+	}
+	relations, err := s.repo.GetUserRelations(ctx, userID, relationType)
+	if err != nil {
+		log.Errorf("[GetUserRelationsService] couldn't fetch relations of user id: %s, err: %v", userID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	data := make([]dto.ResponseUserRelation, 0, len(relations))
+	for _, relation := range relations {
+		data = append(data, dto.ResponseUserRelation{
+			UserID:          relation.TargetUserID,
+			UserName:        relation.UserName,
+			FullName:        buildUserName(relation.FirstName, relation.MiddleName, relation.LastName),
+			ProfileImageURL: relation.ProfileImageUrl,
+			Type:            relation.Type,
+			CreatedAt:       fmt.Sprint(relation.CreatedAt.Unix()),
+		})
+	}
+	return &dto.ResponseUserRelations{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data:    data,
+	}, nil
+}
+
+// checkNotBlockedBy refuses userID acting on a post of ownerID once ownerID
+// blocked them.
+func (s *service) checkNotBlockedBy(ctx context.Context, ownerID string, userID string) *exceptions.Exception {
+	if ownerID == "" || ownerID == userID {
+		return nil
+	}
+	log := logger.GetLogInstance(ctx, "CheckNotBlockedBy")
+	blocking, err := s.repo.GetBlockingUsers(ctx, []string{ownerID}, userID)
+	if err != nil {
+		log.Errorf("[CheckNotBlockedBy] couldn't fetch blocks of user id: %s, err: %v", userID, err)
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if blocking[ownerID] {
+		log.Errorf("[CheckNotBlockedBy] user id: %s is blocked by user id: %s", userID, ownerID)
+		return exceptions.GetExceptionByErrorCode(exceptions.BlockedUserErrorCode)
+	}
+	return nil
+}
+
+// checkUserRelation validates a block or mute request and returns its type.
+func checkUserRelation(requestBody *dto.RequestUserRelation, userID string) (string, *exceptions.Exception) {
+	relationType := strings.ToUpper(strings.TrimSpace(requestBody.Type))
+	if !isUserRelationType(relationType) {
+		return "", exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Type should be one of BLOCK or MUTE")
+	}
+	if requestBody.TargetUserID == "" {
+		return "", exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Target user id is required")
+	}
+	if requestBody.TargetUserID == userID {
+		return "", exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Users can't block or mute themselves")
+	}
+	return relationType, nil
+}
+
+func isUserRelationType(relationType string) bool {
+	return relationType == common.RELATION_BLOCK || relationType == common.RELATION_MUTE
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	model "github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+)
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+)
+
+func (s *service) SearchPosts(ctx context.Context, filter model.SearchFilter) (*dto.ResponseSearchPosts, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "SearchPostsService")
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultSearchLimit
+	}
+	if filter.Limit > maxSearchLimit {
+		filter.Limit = maxSearchLimit
+	}
+	limit := filter.Limit
+	// fetch one extra row to know whether another page exists
+	filter.Limit = limit + 1
+
+	results, err := s.repo.SearchPosts(ctx, filter)
+	if err != nil {
+		log.Errorf("[SearchPostsService] failed to search posts for query: %s, err: %v", filter.Query, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.QueryFailedErrorCode)
+	}
+
+	var nextCursor string
+	if len(results) > limit {
+		results = results[:limit]
+		last := results[len(results)-1]
+		nextCursor, err = common.EncodeCursor(model.SearchCursor{Rank: last.Rank, ID: last.ID})
+		if err != nil {
+			log.Errorf("[SearchPostsService] failed to build next cursor: %v", err)
+			return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+		}
+	}
+
+	data := make([]dto.ResponseSearchPostsData, 0, len(results))
+	for _, result := range results {
+		data = append(data, dto.ResponseSearchPostsData{
+			ID:        result.ID,
+			ChannelID: result.ChannelID,
+			ParentID:  result.ParentID,
+			UserID:    result.UserID,
+			UserName:  buildUserName(result.FirstName, result.MiddleName, result.LastName),
+			Avatar:    result.ProfileImageUrl,
+			Type:      result.Type,
+			Status:    result.Status,
+			LikeCount: result.LikeCount,
+			Snippet:   result.Snippet,
+			Rank:      result.Rank,
+			CreatedAt: fmt.Sprint(result.CreatedAt.Unix()),
+			UpdatedAt: fmt.Sprint(result.UpdatedAt.Unix()),
+		})
+	}
+
+	return &dto.ResponseSearchPosts{
+		Code:       APISuccessCode,
+		Message:    APISuccessMessage,
+		Data:       data,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+func buildUserName(firstName string, middleName string, lastName string) string {
+	userName := firstName
+	if middleName != "" {
+		userName += " " + middleName
+	}
+	if lastName != "" {
+		userName += " " + lastName
+	}
+	return userName
+}
@@ -12,7 +12,12 @@ import (
 
 	"github.com/Abhishekjha321/community_service/exceptions"
 	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/moderation"
+	"github.com/Abhishekjha321/community_service/internal/ratelimit"
+	"github.com/Abhishekjha321/community_service/internal/richtext"
+	"github.com/Abhishekjha321/community_service/internal/trending"
 	"github.com/Abhishekjha321/community_service/storage/cache"
+	"github.com/Abhishekjha321/community_service/storage/objectstore"
 	"github.com/go-redis/redis"
 
 	"gorm.io/gorm"
@@ -30,7 +35,6 @@ const (
 	DEFAULT_COUNTRY_MOBILE_CODE = "+63"
 	like                        = common.Like_Action
 	deleted                     = "DELETED"
-	IDEAS_CHANNEL_ID            = common.IDEAS_CHANNEL_ID
 	bookmark                    = common.Bookmark_Action
 	reply                       = common.POST_REPLY
 )
@@ -38,6 +42,19 @@ const (
 type service struct {
 	repo        model.Repo
 	redisClient cache.CacheBase
+	trending    *trending.Tracker
+	objectStore objectstore.ObjectStore
+	// maxUploadSize is the largest attachment accepted, in bytes.
+	maxUploadSize int64
+	// maxReplyDepth is how deep replies can be nested below a comment.
+	maxReplyDepth int
+	limiter       *ratelimit.Limiter
+	// postRate is how many posts a user can make across all channels.
+	postRate ratelimit.Rate
+	// moderation checks new posts before they are saved.
+	moderation *moderation.Pipeline
+	// restoreWindow is how long after a delete moderators can restore a post.
+	restoreWindow time.Duration
 	// clients     *client.ClientImpl
 }
 
@@ -49,11 +66,30 @@ func dereferencePostData(posts []*dto.ResponseGetPostsPostData) []dto.ResponseGe
 	return result
 }
 
-func NewService(repo model.Repo, redisClient cache.CacheBase) model.Service {
-
+func NewService(repo model.Repo, redisClient cache.CacheBase, trendingTracker *trending.Tracker, objectStore objectstore.ObjectStore, maxUploadSize int64, maxReplyDepth int, limiter *ratelimit.Limiter, postRate ratelimit.Rate, pipeline *moderation.Pipeline, restoreWindow time.Duration) model.Service {
+	if maxUploadSize <= 0 {
+		maxUploadSize = DefaultMaxUploadSize
+	}
+	if maxReplyDepth <= 0 {
+		maxReplyDepth = DefaultMaxReplyDepth
+	}
+	if postRate.Limit == 0 || postRate.Window <= 0 {
+		postRate = ratelimit.Rate{Limit: DefaultPostLimit, Window: DefaultPostWindow}
+	}
+	if restoreWindow <= 0 {
+		restoreWindow = DefaultRestoreWindow
+	}
 	return &service{
-		repo:        repo,
-		redisClient: redisClient,
+		repo:          repo,
+		redisClient:   redisClient,
+		trending:      trendingTracker,
+		objectStore:   objectStore,
+		maxUploadSize: maxUploadSize,
+		maxReplyDepth: maxReplyDepth,
+		limiter:       limiter,
+		postRate:      postRate,
+		moderation:    pipeline,
+		restoreWindow: restoreWindow,
 		// clients:     clients,
 	}
 }
@@ -62,18 +98,105 @@ func (s *service) CreatePost(ctx context.Context, requestBody *dto.RequestCreate
 	log := logger.GetLogInstance(ctx, "CreatePost")
 
 	post := dbModel.Post{
-		UserID:    userId,
-		ChannelID: requestBody.ChannelID,
-		Content:   requestBody.Content,
-		Type:      strings.ToUpper(requestBody.CommentType),
-		ParentID:  requestBody.ParentID,
-		Status:    "PUBLISHED",
+		UserID:       userId,
+		ChannelID:    requestBody.ChannelID,
+		Content:      requestBody.Content,
+		Type:         strings.ToUpper(requestBody.CommentType),
+		ParentID:     requestBody.ParentID,
+		Status:       "PUBLISHED",
+		QuotedPostID: requestBody.QuotedPostID,
+	}
+	switch post.Type {
+	case common.POST_TYPE_COMMENT, common.POST_TYPE_REPLY, common.POST_TYPE_POLL:
+	default:
+		log.Errorf("[CreatePost] invalid comment type: %s", requestBody.CommentType)
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Comment type should be one of COMMENT, REPLY or POLL")
+	}
+	if exp := s.checkChannelPost(ctx, post.ChannelID, userId); exp != nil {
+		return nil, exp
+	}
+	if exp := s.checkPostAttachments(ctx, requestBody.AttachmentIDs, userId); exp != nil {
+		return nil, exp
+	}
+	if exp := s.checkQuotedPost(ctx, requestBody.QuotedPostID, userId); exp != nil {
+		return nil, exp
+	}
+	if exp := s.placeInThread(ctx, &post); exp != nil {
+		return nil, exp
+	}
+	settings, exp := s.getChannelSettings(ctx, post.ChannelID)
+	if exp != nil {
+		return nil, exp
+	}
+	if post.ParentID != 0 && settings.RepliesDisabled {
+		log.Errorf("[CreatePost] replies are turned off in channel id: %s", post.ChannelID)
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Replies are turned off in this channel")
+	}
+	if exp := checkPostLength(settings, post.Content); exp != nil {
+		return nil, exp
+	}
+	var (
+		poll        dbModel.Poll
+		pollOptions []string
+	)
+	if post.Type == common.POST_TYPE_POLL {
+		if poll, pollOptions, exp = newPoll(post, requestBody.Poll); exp != nil {
+			return nil, exp
+		}
+	}
+	flags, exp := s.moderatePost(ctx, post, len(requestBody.AttachmentIDs) > 0)
+	if exp != nil {
+		return nil, exp
+	}
+	if settings.ChannelMode == common.CHANNEL_MODE_IDEAS && post.Type == common.POST_TYPE_COMMENT {
+		post.IdeaStatus = common.IDEA_STATUS_OPEN
 	}
+	// counted last so that a rejected post doesn't use up the author's rate
+	if exp := s.checkPostRate(ctx, settings, userId); exp != nil {
+		return nil, exp
+	}
+
+	var (
+		reply    *dbModel.Post
+		pollData *dto.ResponsePollData
+		err      error
+	)
+	if post.Type == common.POST_TYPE_POLL {
+		var exp *exceptions.Exception
+		reply, pollData, exp = s.createPoll(ctx, post, poll, pollOptions)
+		if exp != nil {
+			return nil, exp
+		}
+	} else {
+		reply, err = s.repo.InsertPostData(ctx, post)
+		if err != nil {
+			log.Errorf("[CreatePost] Error while creating post, err: %s", err)
+			return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+		}
+	}
+	s.flagPost(ctx, reply, flags)
 
-	reply, err := s.repo.InsertPostData(ctx, post)
+	var attachments []dto.ResponseAttachmentData
+	if len(requestBody.AttachmentIDs) > 0 {
+		if err := s.repo.LinkAttachments(ctx, reply.ID, userId, requestBody.AttachmentIDs); err != nil {
+			log.Errorf("[CreatePost] Error while linking attachments to post id: %d, err: %s", reply.ID, err)
+			return nil, exceptions.GetExceptionByErrorCode(exceptions.InvalidAttachmentErrorCode)
+		}
+		attachments = s.loadAttachments(ctx, []int64{reply.ID})[reply.ID]
+	}
+	mentions, err := s.resolveMentions(ctx, reply.Content, userId)
 	if err != nil {
-		log.Errorf("[CreatePost] Error while creating post, err: %s", err)
-		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+		log.Errorf("[CreatePost] Error while resolving mentions of post id: %d, err: %s", reply.ID, err)
+	} else if len(mentions) > 0 {
+		if err := s.repo.SavePostMentions(ctx, *reply, mentions); err != nil {
+			log.Errorf("[CreatePost] Error while saving mentions of post id: %d, err: %s", reply.ID, err)
+			mentions = nil
+		}
+	}
+	if tags := richtext.Tags(reply.Content); len(tags) > 0 {
+		if err := s.repo.SavePostTags(ctx, *reply, tags); err != nil {
+			log.Errorf("[CreatePost] Error while saving tags of post id: %d, err: %s", reply.ID, err)
+		}
 	}
 
 	userDetails, err := s.repo.GetUserDetailsByUserId(ctx, userId)
@@ -85,19 +208,29 @@ func (s *service) CreatePost(ctx context.Context, requestBody *dto.RequestCreate
 	//creating redis key
 	if requestBody.ParentID != 0 { // This means it's a reply
 
-		// Fetch the UserID of the parent post
-		parentUserID, err := s.repo.GetUserIDByPostID(ctx, requestBody.ParentID)
+		// nobody else sees the replies of shadow banned users, so they don't
+		// notify the parent's author or count as interactions
+		shadowBanned, err := s.isShadowBanned(ctx, userId)
 		if err != nil {
-			log.Errorf("[CreatePost] Error while fetching parent user ID, err: %s", err)
+			log.Errorf("[CreatePost] Error while fetching status of user id: %s, err: %s", userId, err)
 			return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
 		}
+		if !shadowBanned {
+			// Fetch the UserID of the parent post
+			parentUserID, err := s.repo.GetUserIDByPostID(ctx, requestBody.ParentID)
+			if err != nil {
+				log.Errorf("[CreatePost] Error while fetching parent user ID, err: %s", err)
+				return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+			}
 
-		redisKey := fmt.Sprintf("community_comment_unread:%s:%s", parentUserID, requestBody.ChannelID)
-		expiration := 7 * 24 * time.Hour
-		KeyExpiryerr := s.redisClient.SetExpiringKey(ctx, redisKey, "true", expiration)
-		if KeyExpiryerr != nil {
-			log.Errorf("[CreatePost] Error while setting Redis key, err: %s", KeyExpiryerr)
-			return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+			redisKey := fmt.Sprintf("community_comment_unread:%s:%s", parentUserID, requestBody.ChannelID)
+			expiration := 7 * 24 * time.Hour
+			KeyExpiryerr := s.redisClient.SetExpiringKey(ctx, redisKey, "true", expiration)
+			if KeyExpiryerr != nil {
+				log.Errorf("[CreatePost] Error while setting Redis key, err: %s", KeyExpiryerr)
+				return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+			}
+			s.recordTrendingInteraction(ctx, trending.InteractionReply, requestBody.ParentID, requestBody.ChannelID)
 		}
 	}
 	userName := userDetails.FirstName
@@ -119,6 +252,10 @@ func (s *service) CreatePost(ctx context.Context, requestBody *dto.RequestCreate
 		ParentID:        reply.ParentID,
 		CreatedAt:       fmt.Sprint(reply.CreatedAt.Unix()),
 		UpdatedAt:       fmt.Sprint(reply.UpdatedAt.Unix()),
+		Poll:            pollData,
+		Attachments:     attachments,
+		Mentions:        buildMentionsData(mentions),
+		QuotedPost:      s.loadQuotedPosts(ctx, []int64{reply.QuotedPostID}, userId)[reply.QuotedPostID],
 	}
 
 	return &dto.ResponseCreatePost{
@@ -128,9 +265,9 @@ func (s *service) CreatePost(ctx context.Context, requestBody *dto.RequestCreate
 	}, nil
 }
 
-func (s *service) GetPostsCount(ctx context.Context, ChannelID string) (int64, *exceptions.Exception) {
+func (s *service) GetPostsCount(ctx context.Context, ChannelID string, feedSort model.FeedSort) (int64, *exceptions.Exception) {
 	log := logger.GetLogInstance(ctx, "GetPostsCountService")
-	count, err := s.repo.GetEventPostsCount(ctx, ChannelID)
+	count, err := s.repo.GetEventPostsCount(ctx, ChannelID, feedSort)
 	if err != nil {
 		log.Errorf("[GetPostsCountService] Couldn't get comment count for corresponding channel id: %s", ChannelID)
 		return 0, exceptions.GetExceptionByErrorCode(exceptions.BadRequestErrorCode)
@@ -138,13 +275,13 @@ func (s *service) GetPostsCount(ctx context.Context, ChannelID string) (int64, *
 	return count, nil
 }
 
-func (s *service) GetUserPostsCount(ctx context.Context, ChannelID string, userId string, sortBy string) (int, *exceptions.Exception) {
+func (s *service) GetUserPostsCount(ctx context.Context, ChannelID string, userId string, sortBy string, feedSort model.FeedSort) (int, *exceptions.Exception) {
 	log := logger.GetLogInstance(ctx, "GetUserPostsCountService")
 	if strings.ToLower(sortBy) == common.IDEAS_BASED_FLOW {
 		log.Infof("[GetUserPostsCountService] No need to get comment count for corresponding channel id: %s and user id: %s because it is ideas based flow", ChannelID, userId)
 		return 0, nil
 	}
-	count, err := s.repo.GetUserSpecificPostsCount(ctx, ChannelID, userId)
+	count, err := s.repo.GetUserSpecificPostsCount(ctx, ChannelID, userId, feedSort)
 	log.Infof("[GetUserPostsCountService] user specific posts count: %d for userId: %s", count, userId)
 	if err != nil {
 		log.Errorf("[GetUserPostsCountService] Couldn't get comment count for corresponding channel id: %s and user id: %s", ChannelID, userId)
@@ -153,35 +290,57 @@ func (s *service) GetUserPostsCount(ctx context.Context, ChannelID string, userI
 	return count, nil
 }
 
-func (s *service) GetUserEventPosts(ctx context.Context, ChannelID string, limit int, currentPage int, userID string, offset int, sortBy string) ([]common.Post, *exceptions.Exception) {
+func (s *service) GetUserEventPosts(ctx context.Context, ChannelID string, limit int, currentPage int, userID string, offset int, sortBy string, feedSort model.FeedSort) ([]common.Post, *exceptions.Exception) {
 	log := logger.GetLogInstance(ctx, "GetUserEventPostsService")
 	if strings.ToLower(sortBy) == common.IDEAS_BASED_FLOW {
 		log.Infof("[GetUserEventPosts] No need to get comment count for corresponding channel id: %s and user id: %s because it is ideas based flow", ChannelID, userID)
 		return nil, nil
 	}
-	userPosts, err := s.repo.GetUserSpecificEventPosts(ctx, ChannelID, limit, currentPage, userID, offset)
+	userPosts, err := s.repo.GetUserSpecificEventPosts(ctx, ChannelID, limit, currentPage, userID, offset, feedSort)
 	if err != nil {
 		log.Errorf("[GetUserEventPosts] couldn't fetch user specific posts for corresponding channel id: %s and userId: %s", ChannelID, userID)
 	}
 	return userPosts, nil
 }
 
-func (s *service) GetPosts(ctx context.Context, ChannelID string, userID string, limit int, currentPage int, sortBy string, bookMarksOnly bool) (*dto.ResponseGetPosts, *exceptions.Exception) {
+func (s *service) GetPosts(ctx context.Context, ChannelID string, userID string, limit int, currentPage int, sortBy string, feedSort model.FeedSort, bookMarksOnly bool, cursor *model.FeedCursor) (*dto.ResponseGetPosts, *exceptions.Exception) {
 	var (
-		response      *dto.ResponseGetPosts
-		filteredPosts []*dto.ResponseGetPostsPostData
-		log           = logger.GetLogInstance(ctx, "GetPostsService")
+		response *dto.ResponseGetPosts
+		log      = logger.GetLogInstance(ctx, "GetPostsService")
 	)
-	userPostsCount, errUserPostsCount := s.GetUserPostsCount(ctx, ChannelID, userID, sortBy)
-	if errUserPostsCount != nil {
-		log.Errorf("[GetPostsService] couldn't fetch user specific posts count for corresponding channel id: %s and userId: %s", ChannelID, userID)
+	if exp := s.checkChannelRead(ctx, ChannelID, userID); exp != nil {
+		return nil, exp
+	}
+	if sortBy == "" {
+		var exp *exceptions.Exception
+		if sortBy, exp = s.defaultFlow(ctx, ChannelID); exp != nil {
+			return nil, exp
+		}
+	}
+	// authors hidden from the viewer are left out of the whole feed
+	viewer, exp := s.postViewer(ctx, ChannelID, userID, feedSort.Viewer.ModeratorView)
+	if exp != nil {
+		return nil, exp
+	}
+	feedSort.Viewer = viewer
+	if cursor != nil {
+		return s.getPostsAfterCursor(ctx, ChannelID, userID, limit, sortBy, feedSort, bookMarksOnly, cursor)
+	}
+	// bookmarks are listed on their own, without the viewer's posts first
+	var userPostsCount int
+	if !bookMarksOnly {
+		var errUserPostsCount *exceptions.Exception
+		userPostsCount, errUserPostsCount = s.GetUserPostsCount(ctx, ChannelID, userID, sortBy, feedSort)
+		if errUserPostsCount != nil {
+			log.Errorf("[GetPostsService] couldn't fetch user specific posts count for corresponding channel id: %s and userId: %s", ChannelID, userID)
+		}
 	}
 	totalPostsRequired := (currentPage - 1) * limit
 	limitOtherPosts := userPostsCount - totalPostsRequired
 	var userSpecificPosts []common.Post
 	if limitOtherPosts > 0 {
 		offsetInternal := totalPostsRequired
-		userPosts, _ := s.GetUserEventPosts(ctx, ChannelID, limit, currentPage, userID, offsetInternal, sortBy)
+		userPosts, _ := s.GetUserEventPosts(ctx, ChannelID, limit, currentPage, userID, offsetInternal, sortBy, feedSort)
 		userSpecificPosts = userPosts
 	} else {
 		limitOtherPosts = 0
@@ -199,7 +358,7 @@ func (s *service) GetPosts(ctx context.Context, ChannelID string, userID string,
 			fetchedPosts = posts
 			dbError = err
 		} else {
-			posts, err := s.repo.GetEventPosts(ctx, ChannelID, limit-limitOtherPosts, currentPage, userID, offsetOtherPosts, sortBy)
+			posts, err := s.repo.GetEventPosts(ctx, ChannelID, limit-limitOtherPosts, currentPage, userID, offsetOtherPosts, sortBy, feedSort)
 			fetchedPosts = posts
 			dbError = err
 		}
@@ -215,11 +374,58 @@ func (s *service) GetPosts(ctx context.Context, ChannelID string, userID string,
 	} else {
 		posts = userSpecificPosts
 	}
+	filteredPosts, exp := s.buildFeedPosts(ctx, posts, viewer, sortBy, bookMarksOnly)
+	if exp != nil {
+		return nil, exp
+	}
+
+	s.clearUnreadFlag(ctx, userID, ChannelID)
+
+	recordsCount, errCount := s.GetPostsCount(ctx, ChannelID, feedSort)
+	if errCount != nil {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+
+	pagination := NewPagination(int64(currentPage), int64(limit), recordsCount)
+
+	var nextCursor string
+	if len(posts) > 0 && int64(currentPage) < pagination.TotalPages {
+		var err error
+		nextCursor, err = encodeFeedCursor(posts[len(posts)-1], userID, sortBy, feedSort, bookMarksOnly)
+		if err != nil {
+			log.Errorf("[GetPostsService] couldn't build next cursor for channel id: %s, err: %v", ChannelID, err)
+			return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+		}
+	}
+
+	return &dto.ResponseGetPosts{
+		Code:       APISuccessCode,
+		Message:    APISuccessMessage,
+		Data:       dereferencePostData(filteredPosts),
+		Pagination: *pagination,
+		NextCursor: nextCursor,
+	}, nil
+
+}
+
+// buildFeedPosts attaches author details, the viewer's like and bookmark state
+// and the latest replies to a page of comments.
+func (s *service) buildFeedPosts(ctx context.Context, posts []common.Post, viewer model.Viewer, sortBy string, bookMarksOnly bool) ([]*dto.ResponseGetPostsPostData, *exceptions.Exception) {
+	var (
+		filteredPosts []*dto.ResponseGetPostsPostData
+		userID        = viewer.UserID
+		log           = logger.GetLogInstance(ctx, "GetPostsService")
+	)
 	var commentIds []int64
 	for _, post := range posts {
 		commentIds = append(commentIds, post.ID)
 	}
-	replies, _ := s.repo.GetRelevantReplies(ctx, commentIds, sortBy, bookMarksOnly)
+	replies, _ := s.repo.GetRelevantReplies(ctx, commentIds, sortBy, bookMarksOnly, viewer)
+	myVotes, err := s.repo.GetUserVotes(ctx, userID, commentIds)
+	if err != nil {
+		log.Errorf("[GetPostsService] Unable to fetch votes of user id: %s, err: %v", userID, err)
+	}
+	polls := s.loadPolls(ctx, posts, userID)
 
 	var postIds []int
 
@@ -229,6 +435,21 @@ func (s *service) GetPosts(ctx context.Context, ChannelID string, userID string,
 	for _, p := range replies {
 		postIds = append(postIds, int(p.ID))
 	}
+	feedPostIDs := append([]int64{}, commentIds...)
+	for _, p := range replies {
+		feedPostIDs = append(feedPostIDs, p.ID)
+	}
+	attachments := s.loadAttachments(ctx, feedPostIDs)
+	mentions := s.loadMentions(ctx, feedPostIDs)
+	var quotedPostIDs []int64
+	for _, p := range posts {
+		quotedPostIDs = append(quotedPostIDs, p.QuotedPostID)
+	}
+	for _, p := range replies {
+		quotedPostIDs = append(quotedPostIDs, p.QuotedPostID)
+	}
+	quotes := s.loadQuotedPosts(ctx, quotedPostIDs, userID)
+	deletedBy := s.loadDeletionRoles(ctx, feedPostIDs)
 
 	userData, err := s.repo.GetUserDetailsForPostID(ctx, postIds)
 	if err != nil {
@@ -255,7 +476,7 @@ func (s *service) GetPosts(ctx context.Context, ChannelID string, userID string,
 				likeStatus = false
 			}
 			commentIDStr := strconv.FormatInt(post.ID, 10)
-			repliesCount, errReplyCountttt := s.repo.GetCommentSpecificReplyCount(ctx, commentIDStr)
+			repliesCount, errReplyCountttt := s.repo.GetCommentSpecificReplyCount(ctx, commentIDStr, viewer)
 			if errReplyCountttt != nil {
 				log.Errorf("[GetPostsService] Unable to fetch replies count for postId: %d", post.ID)
 			}
@@ -268,22 +489,35 @@ func (s *service) GetPosts(ctx context.Context, ChannelID string, userID string,
 				userName += " " + userDataMap[post.UserID].LastName
 			}
 			comment := &dto.ResponseGetPostsPostData{
-				ID:            post.ID,
-				UserID:        post.UserID,
-				Avatar:        userDataMap[post.UserID].ProfileImageUrl,
-				UserName:      userName,
-				UserPhone:     userDataMap[post.UserID].UserPhone,
-				Content:       post.Content,
-				Type:          post.Type,
-				LikeCount:     post.LikeCount,
-				Status:        post.Status,
-				CreatedAt:     fmt.Sprint(post.CreatedAt.Unix()),
-				UpdatedAt:     fmt.Sprint(post.UpdatedAt.Unix()),
-				RepliesCount:  repliesCount,
-				IsLiked:       likeStatus,
-				BookmarkCount: post.BookmarkCount,
-				IsBookmarked:  bookmarkStatus,
-				IsPinned:      post.IsPinned,
+				ID:               post.ID,
+				UserID:           post.UserID,
+				Avatar:           userDataMap[post.UserID].ProfileImageUrl,
+				UserName:         userName,
+				UserPhone:        userDataMap[post.UserID].UserPhone,
+				Content:          shownContent(post.ID, post.Status, post.Content, deletedBy),
+				Type:             post.Type,
+				LikeCount:        post.LikeCount,
+				Status:           post.Status,
+				CreatedAt:        fmt.Sprint(post.CreatedAt.Unix()),
+				UpdatedAt:        fmt.Sprint(post.UpdatedAt.Unix()),
+				RepliesCount:     repliesCount,
+				IsLiked:          likeStatus,
+				BookmarkCount:    post.BookmarkCount,
+				IsBookmarked:     bookmarkStatus,
+				IsPinned:         post.IsPinned,
+				IdeaStatus:       post.IdeaStatus,
+				OfficialResponse: post.OfficialResponse,
+				MergedIntoID:     post.MergedIntoID,
+				VoteScore:        post.VoteScore,
+				UpvoteCount:      post.UpvoteCount,
+				DownvoteCount:    post.DownvoteCount,
+				MyVote:           myVotes[post.ID],
+				AcceptedAnswerID: post.AcceptedAnswerID,
+				Poll:             polls[post.ID],
+				Attachments:      attachments[post.ID],
+				Mentions:         mentions[post.ID],
+				QuoteCount:       post.QuoteCount,
+				QuotedPost:       quotes[post.QuotedPostID],
 			}
 			commentMap[post.ID] = comment
 			orderedComments = append(orderedComments, comment)
@@ -304,18 +538,24 @@ func (s *service) GetPosts(ctx context.Context, ChannelID string, userID string,
 				userName += " " + userDataMap[reply.UserID].LastName
 			}
 			replyDetail := &dto.ResponseGetPostsReply{
-				ID:        reply.ID,
-				UserID:    reply.UserID,
-				Avatar:    userDataMap[reply.UserID].ProfileImageUrl,
-				UserName:  userName,
-				UserPhone: userDataMap[reply.UserID].UserPhone,
-				Content:   reply.Content,
-				Type:      reply.Type,
-				LikeCount: reply.LikeCount,
-				Status:    reply.Status,
-				CreatedAt: fmt.Sprint(reply.CreatedAt.Unix()),
-				UpdatedAt: fmt.Sprint(reply.UpdatedAt.Unix()),
-				IsLiked:   likeStatus,
+				ID:               reply.ID,
+				UserID:           reply.UserID,
+				Avatar:           userDataMap[reply.UserID].ProfileImageUrl,
+				UserName:         userName,
+				UserPhone:        userDataMap[reply.UserID].UserPhone,
+				Content:          shownContent(reply.ID, reply.Status, reply.Content, deletedBy),
+				Type:             reply.Type,
+				LikeCount:        reply.LikeCount,
+				Status:           reply.Status,
+				CreatedAt:        fmt.Sprint(reply.CreatedAt.Unix()),
+				UpdatedAt:        fmt.Sprint(reply.UpdatedAt.Unix()),
+				IsLiked:          likeStatus,
+				IsAcceptedAnswer: reply.IsAcceptedAnswer,
+				RepliesCount:     reply.ReplyCount,
+				Attachments:      attachments[reply.ID],
+				Mentions:         mentions[reply.ID],
+				QuoteCount:       reply.QuoteCount,
+				QuotedPost:       quotes[reply.QuotedPostID],
 			}
 
 			if replies, exists := replyMap[reply.ParentID]; exists {
@@ -328,6 +568,9 @@ func (s *service) GetPosts(ctx context.Context, ChannelID string, userID string,
 
 	for ParentID, replies := range replyMap {
 		sort.Slice(replies, func(i, j int) bool {
+			if replies[i].IsAcceptedAnswer != replies[j].IsAcceptedAnswer {
+				return replies[i].IsAcceptedAnswer
+			}
 			if replies[i].Status == "DELETED" && replies[j].Status != "DELETED" {
 				return false
 			}
@@ -346,32 +589,50 @@ func (s *service) GetPosts(ctx context.Context, ChannelID string, userID string,
 
 	for _, commentDetail := range orderedComments {
 		postWithReplies := dto.ResponseGetPostsPostData{
-			UserID:        commentDetail.UserID,
-			ID:            commentDetail.ID,
-			Avatar:        commentDetail.Avatar,
-			UserName:      commentDetail.UserName,
-			UserPhone:     commentDetail.UserPhone,
-			Content:       commentDetail.Content,
-			Type:          commentDetail.Type,
-			LikeCount:     commentDetail.LikeCount,
-			Status:        commentDetail.Status,
-			CreatedAt:     commentDetail.CreatedAt,
-			UpdatedAt:     commentDetail.UpdatedAt,
-			IsLiked:       commentDetail.IsLiked,
-			RepliesCount:  commentDetail.RepliesCount,
-			BookmarkCount: commentDetail.BookmarkCount,
-			IsBookmarked:  commentDetail.IsBookmarked,
-			IsPinned:      commentDetail.IsPinned,
+			UserID:           commentDetail.UserID,
+			ID:               commentDetail.ID,
+			Avatar:           commentDetail.Avatar,
+			UserName:         commentDetail.UserName,
+			UserPhone:        commentDetail.UserPhone,
+			Content:          commentDetail.Content,
+			Type:             commentDetail.Type,
+			LikeCount:        commentDetail.LikeCount,
+			Status:           commentDetail.Status,
+			CreatedAt:        commentDetail.CreatedAt,
+			UpdatedAt:        commentDetail.UpdatedAt,
+			IsLiked:          commentDetail.IsLiked,
+			RepliesCount:     commentDetail.RepliesCount,
+			BookmarkCount:    commentDetail.BookmarkCount,
+			IsBookmarked:     commentDetail.IsBookmarked,
+			IsPinned:         commentDetail.IsPinned,
+			IdeaStatus:       commentDetail.IdeaStatus,
+			OfficialResponse: commentDetail.OfficialResponse,
+			MergedIntoID:     commentDetail.MergedIntoID,
+			VoteScore:        commentDetail.VoteScore,
+			UpvoteCount:      commentDetail.UpvoteCount,
+			DownvoteCount:    commentDetail.DownvoteCount,
+			MyVote:           commentDetail.MyVote,
+			AcceptedAnswerID: commentDetail.AcceptedAnswerID,
+			Poll:             commentDetail.Poll,
+			Attachments:      commentDetail.Attachments,
+			Mentions:         commentDetail.Mentions,
+			QuoteCount:       commentDetail.QuoteCount,
+			QuotedPost:       commentDetail.QuotedPost,
 			// Replies:       replyMap[commentDetail.Id],
 			Replies: dereferenceReplies(replyMap[commentDetail.ID]),
 		}
 
 		filteredPosts = append(filteredPosts, &postWithReplies)
 	}
+	return filteredPosts, nil
+}
 
+// clearUnreadFlag drops the unread replies marker once the user has loaded the feed.
+func (s *service) clearUnreadFlag(ctx context.Context, userID string, ChannelID string) {
+	log := logger.GetLogInstance(ctx, "GetPostsService")
 	// fetching redis key
 	redisKey := fmt.Sprintf("community_comment_unread:%s:%s", userID, ChannelID)
-	_, err = s.redisClient.GetKey(ctx, redisKey)
+	_, err := s.redisClient.GetKey(ctx, redisKey)
 	if err != nil {
 		log.Errorf("[GetPostsService] Error retrieving Redis key %s: %v", redisKey, err)
 	}
@@ -391,21 +652,6 @@ func (s *service) GetPosts(ctx context.Context, ChannelID string, userID string,
 	} else {
 		fmt.Println("Redis key still exists after deletion:", redisKey)
 	}
-
-	recordsCount, errCount := s.GetPostsCount(ctx, ChannelID)
-	if errCount != nil {
-		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
-	}
-
-	pagination := NewPagination(int64(currentPage), int64(limit), recordsCount)
-
-	return &dto.ResponseGetPosts{
-		Code:       APISuccessCode,
-		Message:    APISuccessMessage,
-		Data:       dereferencePostData(filteredPosts),
-		Pagination: *pagination,
-	}, nil
-
 }
 
 func dereferenceReplies(replies []*dto.ResponseGetPostsReply) []dto.ResponseGetPostsReply {
@@ -453,18 +699,78 @@ func (s *service) LikePost(ctx context.Context, postID string, action string, us
 		log.Errorf("[LikePostService] Cannot like a deleted post for postID: %s and userID: %s and action: %s", postID, userID, action)
 		return exceptions.GetExceptionByErrorCode(exceptions.DeletedPostErrorCode)
 	}
+	if post.Status == common.POST_STATUS_MERGED {
+		log.Errorf("[LikePostService] Cannot like a merged post for postID: %s and userID: %s and action: %s", postID, userID, action)
+		return exceptions.GetExceptionByErrorCode(exceptions.MergedPostErrorCode)
+	}
+	if exp := s.checkChannelRead(ctx, post.ChannelID, userID); exp != nil {
+		return exp
+	}
+	// taking a like back stays possible after being blocked or once reactions
+	// are turned off
+	if action == like {
+		liked, _, err := s.repo.FetchUserPostSpecificActionValue(ctx, post.ID, userID)
+		if err != nil {
+			return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+		}
+		if !liked {
+			if exp := s.checkNotBlockedBy(ctx, post.UserID, userID); exp != nil {
+				return exp
+			}
+			if exp := s.checkReactionsEnabled(ctx, post.ChannelID); exp != nil {
+				return exp
+			}
+		}
+	}
 
-	_, err := s.repo.ActionSpecificLikePost(ctx, postID, action, userID)
+	value, err := s.repo.ActionSpecificLikePost(ctx, postID, action, userID)
 	if err != nil {
 		log.Errorf("[LikePostService] Not found any posts for postID: %s and userID: %s and action: %s and error: %v", postID, userID, action, err)
 		return err
 	}
+	// only a new like or bookmark counts towards trending, not taking one back
+	if value == "true" {
+		interaction := trending.InteractionLike
+		if action == bookmark {
+			interaction = trending.InteractionBookmark
+		}
+		s.recordTrendingInteraction(ctx, interaction, post.ID, ChannelID)
+	}
 	return nil
 }
 
-func (s *service) DeletePost(ctx context.Context, postID string, userID string) *exceptions.Exception {
-	_, err := s.repo.DeleteSpecificPost(ctx, postID, userID)
+// DeletePost deletes a post on behalf of its author or of a moderator of its
+// channel. The content is kept aside so a moderator can restore the post.
+func (s *service) DeletePost(ctx context.Context, postID string, userID string, reason string) *exceptions.Exception {
 	log := logger.GetLogInstance(ctx, "Delete Post Service")
+	post, err := s.repo.CheckPostIDValidity(ctx, postID, "")
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode)
+		}
+		log.Errorf("[DeletePostService] couldn't fetch post id: %s, err: %v", postID, err)
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if exp := s.checkChannelRead(ctx, post.ChannelID, userID); exp != nil {
+		return exp
+	}
+	role := common.AUDIT_ROLE_AUTHOR
+	if post.UserID != userID {
+		isModerator, err := s.repo.IsChannelModerator(ctx, post.ChannelID, userID)
+		if err != nil {
+			log.Errorf("[DeletePostService] couldn't check moderator for user id: %s, err: %v", userID, err)
+			return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+		}
+		if !isModerator {
+			return exceptions.GetExceptionByErrorCode(exceptions.AccessDeniedErrorCode)
+		}
+		role = common.AUDIT_ROLE_MODERATOR
+	}
+	actor, exp := newAuditActor(ctx, userID, role, reason)
+	if exp != nil {
+		return exp
+	}
+	_, err = s.repo.DeleteSpecificPost(ctx, postID, actor)
 	if err != nil {
 		log.Errorf("[DeletePostService] Not found any posts to delete for postId: %s with error: %v", postID, err)
 		return exceptions.GetExceptionByErrorCode(exceptions.BadRequestErrorCode)
@@ -473,6 +779,16 @@ func (s *service) DeletePost(ctx context.Context, postID string, userID string)
 }
 
 func (s *service) ReportPost(ctx context.Context, requestBody *model.RequestReportPost, userId string) *exceptions.Exception {
+	post, err := s.repo.CheckPostIDValidity(ctx, requestBody.PostID, "")
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode)
+		}
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if exp := s.checkChannelRead(ctx, post.ChannelID, userId); exp != nil {
+		return exp
+	}
 
 	report := dbModel.Reports{
 		PostID:         requestBody.PostID,
@@ -480,7 +796,7 @@ func (s *service) ReportPost(ctx context.Context, requestBody *model.RequestRepo
 		ReportedBy:     userId,
 	}
 
-	_, err := s.repo.ReportPostData(ctx, report)
+	_, err = s.repo.ReportPostData(ctx, report)
 	log := logger.GetLogInstance(ctx, "Report on Post")
 
 	if err != nil {
@@ -488,13 +804,14 @@ func (s *service) ReportPost(ctx context.Context, requestBody *model.RequestRepo
 
 		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
 	}
+	s.hideOverReportThreshold(ctx, post)
 
 	return nil
 
 }
 
-func (s *service) GetRepliesCount(ctx context.Context, postID string) (int64, *exceptions.Exception) {
-	count, err := s.repo.GetCommentSpecificReplyCount(ctx, postID)
+func (s *service) GetRepliesCount(ctx context.Context, postID string, viewer model.Viewer) (int64, *exceptions.Exception) {
+	count, err := s.repo.GetCommentSpecificReplyCount(ctx, postID, viewer)
 	log := logger.GetLogInstance(ctx, "GetRepliesCountService")
 	if err != nil {
 		log.Errorf("[GetRepliesCountService] Couldn't get reply count for corresponding comment id: %s", postID)
@@ -503,7 +820,7 @@ func (s *service) GetRepliesCount(ctx context.Context, postID string) (int64, *e
 	return count, nil
 }
 
-func (s *service) AllRepliesOnPost(ctx context.Context, postId string, userId string, ChannelID string, limit int, currentPage int, sortBy string) (*dto.ResponseAllRepliesOnPost, *exceptions.Exception) {
+func (s *service) AllRepliesOnPost(ctx context.Context, postId string, userId string, ChannelID string, limit int, currentPage int, sortBy string, moderatorView bool, cursor *model.FeedCursor) (*dto.ResponseAllRepliesOnPost, *exceptions.Exception) {
 	log := logger.GetLogInstance(ctx, "AllRepliesOnPost")
 	var allReplies []*dto.ResponseAllRepliesOnPostReplies
 	comment, err := s.repo.GetPostByPostId(ctx, postId)
@@ -511,6 +828,25 @@ func (s *service) AllRepliesOnPost(ctx context.Context, postId string, userId st
 		log.Error("failed to get post by post id: error: %w", err)
 		return nil, exceptions.GetExceptionByErrorCode(exceptions.QueryFailedErrorCode)
 	}
+	if comment == nil {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode)
+	}
+	if exp := s.checkChannelRead(ctx, comment.ChannelID, userId); exp != nil {
+		return nil, exp
+	}
+	viewer, exp := s.postViewer(ctx, comment.ChannelID, userId, moderatorView)
+	if exp != nil {
+		return nil, exp
+	}
+	if exp := s.checkAuthorVisible(ctx, comment.UserId, viewer); exp != nil {
+		return nil, exp
+	}
+	if sortBy == "" {
+		var exp *exceptions.Exception
+		if sortBy, exp = s.defaultFlow(ctx, comment.ChannelID); exp != nil {
+			return nil, exp
+		}
+	}
 	postId = strings.TrimSpace(postId)
 	commentPostIdConverted, err := strconv.ParseInt(postId, 10, 32)
 	if err != nil {
@@ -520,7 +856,7 @@ func (s *service) AllRepliesOnPost(ctx context.Context, postId string, userId st
 	commentLikeStatus, bookmarkStatus, errCommentLikeStatus := s.repo.FetchUserPostSpecificActionValue(ctx, commentPostIdConverted, userId)
 	if errCommentLikeStatus != nil {
 		commentLikeStatus = false
-		log.Errorf("failed to get post by post id: error: %w : %v", err, bookmarkStatus)
+		log.Errorf("failed to get post by post id: error: %v : %v", err, bookmarkStatus)
 	}
 	comment.IsLiked = commentLikeStatus
 	comment.IsBookmarked = bookmarkStatus
@@ -553,11 +889,36 @@ func (s *service) AllRepliesOnPost(ctx context.Context, postId string, userId st
 		log.Errorf("Error parsing Updated time: %v", err)
 	}
 
-	replies, err := s.repo.GetAllRepliesOnPost(ctx, postId, ChannelID, limit, currentPage, sortBy)
+	var replies []model.ReplyPost
+	hasMore := false
+	if cursor != nil {
+		replies, err = s.repo.GetAllRepliesOnPostAfterCursor(ctx, postId, limit+1, sortBy, viewer, cursor)
+		if len(replies) > limit {
+			replies, hasMore = replies[:limit], true
+		}
+	} else {
+		replies, err = s.repo.GetAllRepliesOnPost(ctx, postId, ChannelID, limit, currentPage, sortBy, viewer)
+	}
 	if err != nil {
 		log.Errorf("[AllRepliesOnPostService] failed to fetch replies for postId: %s, got error: %s", postId, err)
 		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
 	}
+	threadPostIDs := []int64{commentPostIdConverted}
+	for _, reply := range replies {
+		threadPostIDs = append(threadPostIDs, reply.ID)
+	}
+	attachments := s.loadAttachments(ctx, threadPostIDs)
+	mentions := s.loadMentions(ctx, threadPostIDs)
+	deletedBy := s.loadDeletionRoles(ctx, threadPostIDs)
+	response.Content = shownContent(commentPostIdConverted, response.Status, response.Content, deletedBy)
+	response.Attachments = attachments[commentPostIdConverted]
+	response.Mentions = mentions[commentPostIdConverted]
+	quotedPostIDs := []int64{comment.QuotedPostID}
+	for _, reply := range replies {
+		quotedPostIDs = append(quotedPostIDs, reply.QuotedPostID)
+	}
+	quotes := s.loadQuotedPosts(ctx, quotedPostIDs, userId)
+	response.QuotedPost = quotes[comment.QuotedPostID]
 	for _, reply := range replies {
 		likeStatus, _, errLikeStatus := s.repo.FetchUserPostSpecificActionValue(ctx, reply.ID, userId)
 		if errLikeStatus != nil {
@@ -588,18 +949,24 @@ func (s *service) AllRepliesOnPost(ctx context.Context, postId string, userId st
 		}
 
 		allReplies = append(allReplies, &dto.ResponseAllRepliesOnPostReplies{
-			PostID:          fmt.Sprint(reply.ID),
-			UserName:        userName,
-			UserPhone:       reply.UserPhone,
-			ProfileImageURL: reply.ProfileImageUrl,
-			Content:         reply.Content,
-			Type:            reply.Type,
-			LikeCount:       reply.LikeCount,
-			Status:          reply.Status,
-			IsLiked:         likeStatus,
-			UserID:          reply.UserID,
-			CreatedAt:       reply.CreatedAt,
-			UpdatedAt:       reply.UpdatedAt,
+			PostID:           fmt.Sprint(reply.ID),
+			UserName:         userName,
+			UserPhone:        reply.UserPhone,
+			ProfileImageURL:  reply.ProfileImageUrl,
+			Content:          shownContent(reply.ID, reply.Status, reply.Content, deletedBy),
+			Type:             reply.Type,
+			LikeCount:        reply.LikeCount,
+			Status:           reply.Status,
+			IsLiked:          likeStatus,
+			UserID:           reply.UserID,
+			CreatedAt:        reply.CreatedAt,
+			UpdatedAt:        reply.UpdatedAt,
+			IsAcceptedAnswer: reply.IsAcceptedAnswer,
+			RepliesCount:     reply.ReplyCount,
+			Attachments:      attachments[reply.ID],
+			Mentions:         mentions[reply.ID],
+			QuoteCount:       reply.QuoteCount,
+			QuotedPost:       quotes[reply.QuotedPostID],
 		})
 	}
 	var dereferencedReplies []dto.ResponseAllRepliesOnPostReplies
@@ -608,13 +975,27 @@ func (s *service) AllRepliesOnPost(ctx context.Context, postId string, userId st
 	}
 
 	response.Replies = dereferencedReplies
-	repliesCount, errRepliesCount := s.GetRepliesCount(ctx, postId)
-	if errRepliesCount != nil {
-		repliesCount = int64(len(replies))
-		log.Errorf("[AllRepliesOnPostService] failed to fetch replies count for postId: %s, got error: %s", postId, err)
+
+	// totals are only computed for page based requests
+	pagination := &dto.ResponseGetPostsPagination{SinglePageRecordCount: int64(len(replies))}
+	if cursor == nil {
+		repliesCount, errRepliesCount := s.GetRepliesCount(ctx, postId, viewer)
+		if errRepliesCount != nil {
+			repliesCount = int64(len(replies))
+			log.Errorf("[AllRepliesOnPostService] failed to fetch replies count for postId: %s, got error: %s", postId, err)
+		}
+		pagination = NewPagination(int64(currentPage), int64(limit), int64(repliesCount))
+		hasMore = int64(currentPage) < pagination.TotalPages
 	}
 
-	pagination := NewPagination(int64(currentPage), int64(limit), int64(repliesCount))
+	var nextCursor string
+	if hasMore && len(replies) > 0 {
+		nextCursor, err = encodeReplyCursor(replies[len(replies)-1])
+		if err != nil {
+			log.Errorf("[AllRepliesOnPostService] failed to build next cursor for postId: %s, got error: %v", postId, err)
+			return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+		}
+	}
 
 	return &dto.ResponseAllRepliesOnPost{
 		Code:    APISuccessCode,
@@ -626,10 +1007,14 @@ func (s *service) AllRepliesOnPost(ctx context.Context, postId string, userId st
 			SinglePageRecordCount: pagination.SinglePageRecordCount,
 			TotalRecordCount:      pagination.TotalRecordCount,
 		},
+		NextCursor: nextCursor,
 	}, nil
 }
 
 func (s *service) MarkAsRead(ctx context.Context, userID string, ChannelID string) (*dto.ResponseMarkNotificationsAsRead, *exceptions.Exception) {
+	if exp := s.checkChannelRead(ctx, ChannelID, userID); exp != nil {
+		return nil, exp
+	}
 
 	isRead, err := s.HasUserReadPost(ctx, userID, ChannelID)
 	if err != nil {
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	logger "github.com/Abhishekjha321/community_service/log"
+)
+
+const (
+	defaultPopularTags = 20
+	maxPopularTags     = 100
+)
+
+// GetPopularTags lists the most used hashtags of a channel, or of all the
+// channels of a forum the user can read when forumID is set.
+func (s *service) GetPopularTags(ctx context.Context, channelID string, forumID int64, userID string, limit int) (*dto.ResponsePopularTags, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "GetPopularTagsService")
+
+	if limit <= 0 {
+		limit = defaultPopularTags
+	}
+	if limit > maxPopularTags {
+		limit = maxPopularTags
+	}
+	channelIDs := []string{channelID}
+	if forumID != 0 {
+		forumChannelIDs, err := s.repo.GetChannelIDsByForumID(ctx, forumID)
+		if err != nil {
+			log.Errorf("[GetPopularTagsService] couldn't fetch channels of forum id: %d, err: %v", forumID, err)
+			return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+		}
+		restricted := s.restrictedChannels(ctx, userID, forumChannelIDs)
+		channelIDs = channelIDs[:0]
+		for _, id := range forumChannelIDs {
+			if !restricted[id] {
+				channelIDs = append(channelIDs, id)
+			}
+		}
+	} else if exp := s.checkChannelRead(ctx, channelID, userID); exp != nil {
+		return nil, exp
+	}
+	tags, err := s.repo.GetPopularTags(ctx, channelIDs, limit)
+	if err != nil {
+		log.Errorf("[GetPopularTagsService] couldn't fetch popular tags, err: %v", err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	data := make([]dto.ResponseTagCount, 0, len(tags))
+	for _, tag := range tags {
+		data = append(data, dto.ResponseTagCount{Tag: tag.Tag, PostCount: tag.PostCount})
+	}
+	return &dto.ResponsePopularTags{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data:    data,
+	}, nil
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	model "github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+	dbModel "github.com/Abhishekjha321/community_service/pkg/store/db/model"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultMaxReplyDepth     = 3
+	defaultThreadBranchLimit = 5
+	maxThreadBranchLimit     = 20
+	// maxThreadNodes bounds how many posts one thread response holds. Deeper
+	// levels are not loaded once it is reached; their posts keep their
+	// replies count so clients can open them as threads of their own.
+	maxThreadNodes = 200
+)
+
+// placeInThread sets the root and depth of a new reply from its parent and
+// refuses replies nested deeper than the configured depth, as well as replies
+// to an author who blocked the replier.
+func (s *service) placeInThread(ctx context.Context, post *dbModel.Post) *exceptions.Exception {
+	if post.ParentID == 0 {
+		return nil
+	}
+	log := logger.GetLogInstance(ctx, "PlaceInThread")
+	parent, err := s.repo.CheckPostIDValidity(ctx, strconv.FormatInt(post.ParentID, 10), "")
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.PostIdErrorCode, "Parent post doesn't exist")
+		}
+		log.Errorf("[PlaceInThread] couldn't fetch parent post id: %d, err: %v", post.ParentID, err)
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if parent.ChannelID != post.ChannelID {
+		log.Errorf("[PlaceInThread] parent post id: %d isn't in channel id: %s", post.ParentID, post.ChannelID)
+		return exceptions.GetExceptionByErrorCode(exceptions.WrongChannelIdErrorCode)
+	}
+	if exp := s.checkNotBlockedBy(ctx, parent.UserID, post.UserID); exp != nil {
+		return exp
+	}
+	post.Depth = parent.Depth + 1
+	if post.Depth > s.maxReplyDepth {
+		log.Errorf("[PlaceInThread] reply to post id: %d would be at depth %d, max is %d", post.ParentID, post.Depth, s.maxReplyDepth)
+		return exceptions.GetExceptionByErrorCode(exceptions.ReplyDepthErrorCode)
+	}
+	post.RootID = parent.RootID
+	if post.RootID == 0 {
+		post.RootID = parent.ID
+	}
+	return nil
+}
+
+// GetThread returns the reply tree below postID, depth levels deep, with at
+// most limit replies per branch. A cursor continues a single branch: the
+// thread is then opened on the cursor's branch and holds its next replies.
+func (s *service) GetThread(ctx context.Context, postID int64, userID string, depth int, limit int, moderatorView bool, cursor *model.ThreadCursor) (*dto.ResponseThread, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "GetThread")
+	if cursor != nil && cursor.ParentID != postID {
+		log.Errorf("[GetThread] cursor was issued for post id: %d, requested: %d", cursor.ParentID, postID)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.InvalidCursorErrorCode)
+	}
+	if depth <= 0 || depth > s.maxReplyDepth {
+		depth = s.maxReplyDepth
+	}
+	if limit <= 0 {
+		limit = defaultThreadBranchLimit
+	}
+	if limit > maxThreadBranchLimit {
+		limit = maxThreadBranchLimit
+	}
+
+	focus, err := s.repo.GetThreadPost(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode)
+		}
+		log.Errorf("[GetThread] couldn't fetch post id: %d, err: %v", postID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if exp := s.checkChannelRead(ctx, focus.ChannelID, userID); exp != nil {
+		return nil, exp
+	}
+	viewer, exp := s.postViewer(ctx, focus.ChannelID, userID, moderatorView)
+	if exp != nil {
+		return nil, exp
+	}
+	if exp := s.checkAuthorVisible(ctx, focus.UserID, viewer); exp != nil {
+		return nil, exp
+	}
+
+	// load the tree level by level, one query per level
+	threadPosts := []model.ReplyPost{*focus}
+	children := make(map[int64][]model.ReplyPost)
+	hasMore := make(map[int64]bool)
+	parentIDs := []int64{focus.ID}
+	levelCursor := cursor
+	for level := 0; level < depth && len(parentIDs) > 0 && len(threadPosts) < maxThreadNodes; level++ {
+		replies, err := s.repo.GetThreadReplies(ctx, parentIDs, limit+1, viewer, levelCursor)
+		if err != nil {
+			log.Errorf("[GetThread] couldn't fetch replies of post id: %d, err: %v", postID, err)
+			return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+		}
+		levelCursor = nil
+		parentIDs = nil
+		for _, reply := range replies {
+			if len(children[reply.ParentID]) == limit {
+				hasMore[reply.ParentID] = true
+				continue
+			}
+			children[reply.ParentID] = append(children[reply.ParentID], reply)
+			threadPosts = append(threadPosts, reply)
+			if reply.ReplyCount > 0 {
+				parentIDs = append(parentIDs, reply.ID)
+			}
+		}
+	}
+
+	var (
+		ids           []int64
+		quotedPostIDs []int64
+	)
+	for _, post := range threadPosts {
+		ids = append(ids, post.ID)
+		quotedPostIDs = append(quotedPostIDs, post.QuotedPostID)
+	}
+	liked, err := s.repo.GetUserLikedPostIDs(ctx, userID, ids)
+	if err != nil {
+		log.Errorf("[GetThread] couldn't fetch likes of user id: %s, err: %v", userID, err)
+	}
+	thread := threadDecorations{
+		children:    children,
+		hasMore:     hasMore,
+		liked:       liked,
+		attachments: s.loadAttachments(ctx, ids),
+		mentions:    s.loadMentions(ctx, ids),
+		quotes:      s.loadQuotedPosts(ctx, quotedPostIDs, userID),
+		deletedBy:   s.loadDeletionRoles(ctx, ids),
+	}
+	root, err := thread.buildNode(*focus)
+	if err != nil {
+		log.Errorf("[GetThread] couldn't build cursors of post id: %d, err: %v", postID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+
+	return &dto.ResponseThread{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data:    root,
+	}, nil
+}
+
+// threadDecorations holds the loaded tree and everything shown along with
+// its posts.
+type threadDecorations struct {
+	children    map[int64][]model.ReplyPost
+	hasMore     map[int64]bool
+	liked       map[int64]bool
+	attachments map[int64][]dto.ResponseAttachmentData
+	mentions    map[int64][]dto.ResponseMention
+	quotes      map[int64]*dto.ResponseQuotedPost
+	deletedBy   map[int64]string
+}
+
+func (t threadDecorations) buildNode(post model.ReplyPost) (dto.ResponseThreadNode, error) {
+	node := dto.ResponseThreadNode{
+		PostID:           post.ID,
+		ParentID:         post.ParentID,
+		Depth:            post.Depth,
+		UserID:           post.UserID,
+		UserName:         buildUserName(post.FirstName, post.MiddleName, post.LastName),
+		UserPhone:        post.UserPhone,
+		ProfileImageURL:  post.ProfileImageUrl,
+		Content:          shownContent(post.ID, post.Status, post.Content, t.deletedBy),
+		Type:             post.Type,
+		Status:           post.Status,
+		LikeCount:        post.LikeCount,
+		IsLiked:          t.liked[post.ID],
+		IsAcceptedAnswer: post.IsAcceptedAnswer,
+		CreatedAt:        threadTimestamp(post.CreatedAt),
+		UpdatedAt:        threadTimestamp(post.UpdatedAt),
+		Attachments:      t.attachments[post.ID],
+		Mentions:         t.mentions[post.ID],
+		QuoteCount:       post.QuoteCount,
+		QuotedPost:       t.quotes[post.QuotedPostID],
+		RepliesCount:     post.ReplyCount,
+		Replies:          []dto.ResponseThreadNode{},
+	}
+	replies := t.children[post.ID]
+	for _, reply := range replies {
+		child, err := t.buildNode(reply)
+		if err != nil {
+			return node, err
+		}
+		node.Replies = append(node.Replies, child)
+	}
+	if t.hasMore[post.ID] && len(replies) > 0 {
+		cursor, err := encodeThreadCursor(replies[len(replies)-1])
+		if err != nil {
+			return node, err
+		}
+		node.NextCursor = cursor
+	}
+	return node, nil
+}
+
+func encodeThreadCursor(reply model.ReplyPost) (string, error) {
+	createdAt, err := time.Parse(time.RFC3339Nano, reply.CreatedAt)
+	if err != nil {
+		return "", err
+	}
+	return common.EncodeCursor(model.ThreadCursor{
+		ParentID:  reply.ParentID,
+		CreatedAt: createdAt,
+		ID:        reply.ID,
+	})
+}
+
+// threadTimestamp turns a timestamp read into a string column into the unix
+// seconds sent to clients, leaving it untouched if it can't be parsed.
+func threadTimestamp(value string) string {
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return value
+	}
+	return fmt.Sprint(parsed.Unix())
+}
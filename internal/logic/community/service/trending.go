@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/trending"
+	logger "github.com/Abhishekjha321/community_service/log"
+)
+
+const (
+	defaultTrendingLimit = 10
+	maxTrendingLimit     = 50
+)
+
+// recordTrendingInteraction feeds an interaction into the trending sets. It
+// never fails the request it is called from; a lost interaction only makes
+// the trending lists slightly less accurate. Members-only channels are kept
+// out of the trending sets, which everyone can read.
+func (s *service) recordTrendingInteraction(ctx context.Context, kind string, postID int64, channelID string) {
+	log := logger.GetLogInstance(ctx, "RecordTrendingInteraction")
+	channel, err := s.repo.GetChannel(ctx, channelID)
+	if err != nil {
+		log.Errorf("[RecordTrendingInteraction] couldn't fetch channel id: %s, err: %v", channelID, err)
+		return
+	}
+	if channel != nil && channel.Visibility == common.CHANNEL_VISIBILITY_MEMBERS {
+		return
+	}
+	forumIDs, err := s.repo.GetForumIDsByChannelID(ctx, channelID)
+	if err != nil {
+		log.Errorf("[RecordTrendingInteraction] couldn't fetch forums for channel id: %s, err: %v", channelID, err)
+	}
+	err = s.trending.Record(ctx, trending.Interaction{
+		Kind:      kind,
+		PostID:    postID,
+		ChannelID: channelID,
+		ForumIDs:  forumIDs,
+	})
+	if err != nil {
+		log.Errorf("[RecordTrendingInteraction] couldn't record %s on post id: %d, err: %v", kind, postID, err)
+	}
+}
+
+func trendingLimit(limit int) int {
+	if limit <= 0 {
+		return defaultTrendingLimit
+	}
+	if limit > maxTrendingLimit {
+		return maxTrendingLimit
+	}
+	return limit
+}
+
+// GetTrendingPosts returns the trending posts of a forum when forumID is set
+// and of a channel otherwise. Posts of channels the user can't read are left
+// out.
+func (s *service) GetTrendingPosts(ctx context.Context, channelID string, forumID int64, userID string, limit int) (*dto.ResponseTrendingPosts, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "GetTrendingPostsService")
+
+	if forumID == 0 {
+		if exp := s.checkChannelRead(ctx, channelID, userID); exp != nil {
+			return nil, exp
+		}
+	}
+	scope := trending.ChannelPostsScope(channelID)
+	if forumID != 0 {
+		scope = trending.ForumPostsScope(forumID)
+	}
+	entries, err := s.trending.Top(ctx, scope, trendingLimit(limit))
+	if err != nil {
+		log.Errorf("[GetTrendingPostsService] couldn't read trending set: %s, err: %v", scope, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+
+	postIDs := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		postID, err := strconv.ParseInt(entry.ID, 10, 64)
+		if err != nil {
+			log.Errorf("[GetTrendingPostsService] invalid post id: %s in trending set: %s", entry.ID, scope)
+			continue
+		}
+		postIDs = append(postIDs, postID)
+	}
+	posts, err := s.repo.GetTrendingPostsByIDs(ctx, postIDs)
+	if err != nil {
+		log.Errorf("[GetTrendingPostsService] couldn't fetch trending posts, err: %v", err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.QueryFailedErrorCode)
+	}
+	channelIDs := make([]string, 0, len(posts))
+	for _, post := range posts {
+		channelIDs = append(channelIDs, post.ChannelID)
+	}
+	restricted := s.restrictedChannels(ctx, userID, channelIDs)
+	postsByID := make(map[int64]int, len(posts))
+	for i, post := range posts {
+		if !restricted[post.ChannelID] {
+			postsByID[post.ID] = i
+		}
+	}
+
+	data := make([]dto.ResponseTrendingPostData, 0, len(posts))
+	for _, entry := range entries {
+		postID, _ := strconv.ParseInt(entry.ID, 10, 64)
+		i, ok := postsByID[postID]
+		if !ok {
+			continue
+		}
+		post := posts[i]
+		data = append(data, dto.ResponseTrendingPostData{
+			ID:            post.ID,
+			ChannelID:     post.ChannelID,
+			UserID:        post.UserID,
+			UserName:      buildUserName(post.FirstName, post.MiddleName, post.LastName),
+			Avatar:        post.ProfileImageUrl,
+			Content:       post.Content,
+			LikeCount:     post.LikeCount,
+			BookmarkCount: post.BookmarkCount,
+			ReplyCount:    post.ReplyCount,
+			Score:         entry.Score,
+			CreatedAt:     fmt.Sprint(post.CreatedAt.Unix()),
+		})
+	}
+
+	return &dto.ResponseTrendingPosts{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data:    data,
+	}, nil
+}
+
+// GetTrendingChannels returns the trending channels, leaving out the ones the
+// user can't read.
+func (s *service) GetTrendingChannels(ctx context.Context, userID string, limit int) (*dto.ResponseTrendingChannels, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "GetTrendingChannelsService")
+
+	entries, err := s.trending.Top(ctx, trending.ChannelsScope(), trendingLimit(limit))
+	if err != nil {
+		log.Errorf("[GetTrendingChannelsService] couldn't read trending channels, err: %v", err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	channelIDs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		channelIDs = append(channelIDs, entry.ID)
+	}
+	restricted := s.restrictedChannels(ctx, userID, channelIDs)
+	data := make([]dto.ResponseTrendingChannelData, 0, len(entries))
+	for _, entry := range entries {
+		if restricted[entry.ID] {
+			continue
+		}
+		data = append(data, dto.ResponseTrendingChannelData{
+			ChannelID: entry.ID,
+			Score:     entry.Score,
+		})
+	}
+
+	return &dto.ResponseTrendingChannels{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data:    data,
+	}, nil
+}
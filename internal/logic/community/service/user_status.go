@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	"github.com/Abhishekjha321/community_service/internal/logic/community/model"
+	logger "github.com/Abhishekjha321/community_service/log"
+)
+
+// postViewer is who the posts of a channel are read for. Only moderators of
+// the channel can ask for the moderator view, which also shows the posts of
+// shadow banned users.
+func (s *service) postViewer(ctx context.Context, channelID string, userID string, moderatorView bool) (model.Viewer, *exceptions.Exception) {
+	viewer := model.Viewer{UserID: userID}
+	if !moderatorView {
+		return viewer, nil
+	}
+	log := logger.GetLogInstance(ctx, "PostViewer")
+	if userID == "" {
+		log.Errorf("[PostViewer] moderator view asked without a user id in channel id: %s", channelID)
+		return viewer, exceptions.GetExceptionByErrorCode(exceptions.UserIDMissingErrorCode)
+	}
+	isModerator, err := s.repo.IsChannelModerator(ctx, channelID, userID)
+	if err != nil {
+		log.Errorf("[PostViewer] couldn't check moderator for user id: %s, err: %v", userID, err)
+		return viewer, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if !isModerator {
+		log.Errorf("[PostViewer] user id: %s is not a moderator of channel id: %s", userID, channelID)
+		return viewer, exceptions.GetExceptionByErrorCode(exceptions.AccessDeniedErrorCode)
+	}
+	viewer.ModeratorView = true
+	return viewer, nil
+}
+
+// checkAuthorVisible answers as if the post didn't exist when its author is
+// shadow banned and the viewer is someone else without the moderator view.
+func (s *service) checkAuthorVisible(ctx context.Context, authorID string, viewer model.Viewer) *exceptions.Exception {
+	if viewer.ModeratorView || authorID == viewer.UserID {
+		return nil
+	}
+	shadowBanned, err := s.isShadowBanned(ctx, authorID)
+	if err != nil {
+		return exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if shadowBanned {
+		return exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode)
+	}
+	return nil
+}
+
+func (s *service) isShadowBanned(ctx context.Context, userID string) (bool, error) {
+	status, err := s.repo.GetUserStatus(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return status != nil && status.Status == common.USER_STATUS_SHADOW_BANNED, nil
+}
+
+func (s *service) GetUserStatus(ctx context.Context, userID string) (*dto.ResponseUserStatus, *exceptions.Exception) {
+	status, err := s.repo.GetUserStatus(ctx, userID)
+	if err != nil {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	userStatus := common.USER_STATUS_ACTIVE
+	if status != nil && status.Status != "" {
+		userStatus = status.Status
+	}
+	return buildUserStatusResponse(userID, userStatus), nil
+}
+
+// SetUserStatus shadow bans a user or makes them active again.
+func (s *service) SetUserStatus(ctx context.Context, requestBody *dto.RequestUserStatus, actorID string) (*dto.ResponseUserStatus, *exceptions.Exception) {
+	status := strings.ToUpper(strings.TrimSpace(requestBody.Status))
+	switch status {
+	case common.USER_STATUS_ACTIVE, common.USER_STATUS_SHADOW_BANNED:
+	default:
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Status should be ACTIVE or SHADOW_BANNED")
+	}
+	actor, exp := newAuditActor(ctx, actorID, common.AUDIT_ROLE_ADMIN, requestBody.Reason)
+	if exp != nil {
+		return nil, exp
+	}
+	if err := s.repo.SetUserStatus(ctx, requestBody.UserID, status, actor); err != nil {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	return buildUserStatusResponse(requestBody.UserID, status), nil
+}
+
+func buildUserStatusResponse(userID string, status string) *dto.ResponseUserStatus {
+	return &dto.ResponseUserStatus{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data: dto.ResponseUserStatusData{
+			UserID: userID,
+			Status: status,
+		},
+	}
+}
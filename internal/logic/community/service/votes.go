@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/Abhishekjha321/community_service/dto"
+	"github.com/Abhishekjha321/community_service/exceptions"
+	"github.com/Abhishekjha321/community_service/internal/common"
+	logger "github.com/Abhishekjha321/community_service/log"
+	"gorm.io/gorm"
+)
+
+var voteValues = map[string]int{
+	common.VOTE_UP:   1,
+	common.VOTE_DOWN: -1,
+	common.VOTE_NONE: 0,
+}
+
+// VotePost records an up or down vote, or withdraws one, on a post in a
+// channel that scores by votes.
+func (s *service) VotePost(ctx context.Context, requestBody *dto.RequestVotePost, userID string) (*dto.ResponseVotePost, *exceptions.Exception) {
+	log := logger.GetLogInstance(ctx, "VotePostService")
+
+	value, ok := voteValues[strings.ToLower(requestBody.Vote)]
+	if !ok {
+		log.Errorf("[VotePostService] invalid vote: %s", requestBody.Vote)
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.BadRequestErrorCode, "Vote should be one of up, down or none")
+	}
+
+	postID := strconv.FormatInt(requestBody.PostID, 10)
+	post, err := s.repo.CheckPostIDValidity(ctx, postID, requestBody.ChannelID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode)
+		}
+		log.Errorf("[VotePostService] couldn't fetch post id: %s, err: %v", postID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if post.ID == 0 {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.PostIdErrorCode)
+	}
+	if post.Status == deleted {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.DeletedPostErrorCode)
+	}
+	if post.Status == common.POST_STATUS_MERGED {
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.MergedPostErrorCode)
+	}
+	if exp := s.checkChannelRead(ctx, post.ChannelID, userID); exp != nil {
+		return nil, exp
+	}
+	// taking a vote back stays possible after being blocked
+	if value != 0 {
+		if exp := s.checkNotBlockedBy(ctx, post.UserID, userID); exp != nil {
+			return nil, exp
+		}
+	}
+
+	settings, err := s.repo.GetChannelSettings(ctx, post.ChannelID)
+	if err != nil {
+		log.Errorf("[VotePostService] couldn't fetch settings for channel id: %s, err: %v", post.ChannelID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+	if settings.ScoringMode != common.SCORING_MODE_VOTES {
+		log.Errorf("[VotePostService] channel id: %s does not score by votes", post.ChannelID)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.VotingDisabledErrorCode)
+	}
+	if value != 0 && settings.ReactionsDisabled {
+		log.Errorf("[VotePostService] reactions are turned off in channel id: %s", post.ChannelID)
+		return nil, exceptions.GetExceptionByErrorCodeWithCustomMessage(exceptions.VotingDisabledErrorCode, "Reactions are turned off in this channel")
+	}
+
+	voted, err := s.repo.VotePost(ctx, requestBody.PostID, userID, value)
+	if err != nil {
+		log.Errorf("[VotePostService] couldn't vote on post id: %s for user id: %s, err: %v", postID, userID, err)
+		return nil, exceptions.GetExceptionByErrorCode(exceptions.SomethingWentWrongErrorCode)
+	}
+
+	return &dto.ResponseVotePost{
+		Code:    APISuccessCode,
+		Message: APISuccessMessage,
+		Data: dto.ResponseVotePostData{
+			PostID:        voted.ID,
+			VoteScore:     voted.VoteScore,
+			UpvoteCount:   voted.UpvoteCount,
+			DownvoteCount: voted.DownvoteCount,
+			MyVote:        value,
+		},
+	}, nil
+}
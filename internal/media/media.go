@@ -0,0 +1,118 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"strings"
+
+	// register the decoders image.Decode understands
+	_ "image/gif"
+	_ "image/png"
+)
+
+const (
+	ThumbnailMaxSide     = 320
+	ThumbnailContentType = "image/jpeg"
+	thumbnailQuality     = 80
+	// MaxPixels caps the width × height of images we decode. A small file can
+	// declare a huge image, and decoding it allocates 4 bytes per pixel.
+	MaxPixels = 40_000_000
+)
+
+var ErrTooManyPixels = errors.New("image has too many pixels")
+
+// TooManyPixels reports whether an image of the given size is too big to decode.
+func TooManyPixels(width int, height int) bool {
+	return int64(width)*int64(height) > MaxPixels
+}
+
+// IsImage reports whether the content type is one we can decode and thumbnail.
+func IsImage(contentType string) bool {
+	switch strings.ToLower(contentType) {
+	case "image/jpeg", "image/png", "image/gif":
+		return true
+	}
+	return false
+}
+
+// Dimensions reads only the image header, so it is cheap even for big files.
+func Dimensions(data []byte) (int, int, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, err
+	}
+	return config.Width, config.Height, nil
+}
+
+// Thumbnail decodes the image and returns a JPEG whose longest side is at most
+// ThumbnailMaxSide. Transparent areas are flattened onto white. Images over
+// MaxPixels are refused before they are decoded.
+func Thumbnail(data []byte) ([]byte, error) {
+	width, height, err := Dimensions(data)
+	if err != nil {
+		return nil, err
+	}
+	if TooManyPixels(width, height) {
+		return nil, ErrTooManyPixels
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	bounds := src.Bounds()
+	width, height = scaledSize(bounds.Dx(), bounds.Dy())
+
+	flat := image.NewRGBA(bounds)
+	draw.Draw(flat, bounds, &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(flat, bounds, src, bounds.Min, draw.Over)
+
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, downscale(flat, width, height), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func scaledSize(width int, height int) (int, int) {
+	if width <= ThumbnailMaxSide && height <= ThumbnailMaxSide {
+		return width, height
+	}
+	if width >= height {
+		return ThumbnailMaxSide, max(1, height*ThumbnailMaxSide/width)
+	}
+	return max(1, width*ThumbnailMaxSide/height), ThumbnailMaxSide
+}
+
+// downscale averages every source pixel that falls into a destination pixel,
+// which is good enough for thumbnails and needs nothing beyond the stdlib.
+func downscale(src *image.RGBA, width int, height int) *image.RGBA {
+	bounds := src.Bounds()
+	if bounds.Dx() == width && bounds.Dy() == height {
+		return src
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*bounds.Dy()/height)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*bounds.Dx()/width)
+			var r, g, b, count int
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					offset := src.PixOffset(sx, sy)
+					r += int(src.Pix[offset])
+					g += int(src.Pix[offset+1])
+					b += int(src.Pix[offset+2])
+					count++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{R: uint8(r / count), G: uint8(g / count), B: uint8(b / count), A: 0xff})
+		}
+	}
+	return dst
+}
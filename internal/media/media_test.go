@@ -0,0 +1,109 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestScaledSize(t *testing.T) {
+	tests := []struct {
+		name                  string
+		width, height         int
+		wantWidth, wantHeight int
+	}{
+		{name: "small image is kept", width: 100, height: 50, wantWidth: 100, wantHeight: 50},
+		{name: "exactly the max side", width: ThumbnailMaxSide, height: ThumbnailMaxSide, wantWidth: ThumbnailMaxSide, wantHeight: ThumbnailMaxSide},
+		{name: "landscape", width: 1280, height: 640, wantWidth: ThumbnailMaxSide, wantHeight: 160},
+		{name: "portrait", width: 640, height: 1280, wantWidth: 160, wantHeight: ThumbnailMaxSide},
+		{name: "square", width: 1000, height: 1000, wantWidth: ThumbnailMaxSide, wantHeight: ThumbnailMaxSide},
+		{name: "thin strip keeps one pixel", width: 10000, height: 1, wantWidth: ThumbnailMaxSide, wantHeight: 1},
+		{name: "tall strip keeps one pixel", width: 1, height: 10000, wantWidth: 1, wantHeight: ThumbnailMaxSide},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height := scaledSize(tt.width, tt.height)
+			if width != tt.wantWidth || height != tt.wantHeight {
+				t.Errorf("scaledSize(%d, %d) = %d, %d, want %d, %d", tt.width, tt.height, width, height, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestTooManyPixels(t *testing.T) {
+	tests := []struct {
+		width, height int
+		want          bool
+	}{
+		{width: 8000, height: 5000, want: false},
+		{width: 8000, height: 5001, want: true},
+		{width: 30000, height: 30000, want: true},
+		{width: 1<<31 - 1, height: 1<<31 - 1, want: true},
+	}
+	for _, tt := range tests {
+		if got := TooManyPixels(tt.width, tt.height); got != tt.want {
+			t.Errorf("TooManyPixels(%d, %d) = %v, want %v", tt.width, tt.height, got, tt.want)
+		}
+	}
+}
+
+func encodePNG(t *testing.T, width int, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: 200, A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+// withDeclaredSize rewrites the size in the IHDR chunk of a PNG, leaving the
+// pixel data as it is, the way a decompression bomb would.
+func withDeclaredSize(t *testing.T, data []byte, width uint32, height uint32) []byte {
+	t.Helper()
+	out := append([]byte{}, data...)
+	// 8 byte signature, then the IHDR chunk: length, type, data, crc
+	const ihdr = 8
+	if string(out[ihdr+4:ihdr+8]) != "IHDR" {
+		t.Fatal("PNG doesn't start with an IHDR chunk")
+	}
+	binary.BigEndian.PutUint32(out[ihdr+8:], width)
+	binary.BigEndian.PutUint32(out[ihdr+12:], height)
+	binary.BigEndian.PutUint32(out[ihdr+8+13:], crc32.ChecksumIEEE(out[ihdr+4:ihdr+8+13]))
+	return out
+}
+
+func TestThumbnail(t *testing.T) {
+	thumbnail, err := Thumbnail(encodePNG(t, 640, 320))
+	if err != nil {
+		t.Fatalf("Thumbnail() error = %v", err)
+	}
+	width, height, err := Dimensions(thumbnail)
+	if err != nil {
+		t.Fatalf("Dimensions() of thumbnail error = %v", err)
+	}
+	if width != ThumbnailMaxSide || height != ThumbnailMaxSide/2 {
+		t.Errorf("thumbnail is %dx%d, want %dx%d", width, height, ThumbnailMaxSide, ThumbnailMaxSide/2)
+	}
+}
+
+func TestThumbnailRefusesDecompressionBomb(t *testing.T) {
+	bomb := withDeclaredSize(t, encodePNG(t, 4, 4), 30000, 30000)
+	width, height, err := Dimensions(bomb)
+	if err != nil || width != 30000 || height != 30000 {
+		t.Fatalf("Dimensions() = %d, %d, %v, want the declared 30000x30000", width, height, err)
+	}
+	if _, err := Thumbnail(bomb); !errors.Is(err, ErrTooManyPixels) {
+		t.Errorf("Thumbnail() error = %v, want %v", err, ErrTooManyPixels)
+	}
+}
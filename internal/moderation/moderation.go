@@ -0,0 +1,111 @@
+package moderation
+
+import (
+	"context"
+	"strings"
+
+	logger "github.com/Abhishekjha321/community_service/log"
+)
+
+type Action string
+
+const (
+	ActionAllow  Action = "ALLOW"
+	ActionReject Action = "REJECT"
+	ActionFlag   Action = "FLAG"
+)
+
+// Content is a post about to be created or edited. PostID is only set when
+// an existing post is edited.
+type Content struct {
+	PostID         int64
+	UserID         string
+	ChannelID      string
+	Type           string
+	ParentID       int64
+	Text           string
+	HasAttachments bool
+}
+
+// Decision is what a stage made of the content. Stage is filled in by the
+// pipeline.
+type Decision struct {
+	Action Action
+	Stage  string
+	Reason string
+}
+
+func Allow() Decision {
+	return Decision{Action: ActionAllow}
+}
+
+func Reject(reason string) Decision {
+	return Decision{Action: ActionReject, Reason: reason}
+}
+
+func Flag(reason string) Decision {
+	return Decision{Action: ActionFlag, Reason: reason}
+}
+
+// Stage is one check of the pipeline. An external classifier can be added
+// as another stage.
+type Stage interface {
+	Name() string
+	Check(ctx context.Context, content Content) (Decision, error)
+}
+
+// Result is the outcome of running the pipeline: the rejection that stopped
+// it, if any, and the flags raised before that.
+type Result struct {
+	Rejection *Decision
+	Flags     []Decision
+}
+
+func (r Result) Rejected() bool {
+	return r.Rejection != nil
+}
+
+// Pipeline runs its stages in order until one rejects the content. A stage
+// that fails is logged and skipped, so an unavailable check doesn't stop
+// posting.
+type Pipeline struct {
+	stages []Stage
+}
+
+func NewPipeline(stages ...Stage) *Pipeline {
+	return &Pipeline{stages: stages}
+}
+
+func (p *Pipeline) Run(ctx context.Context, content Content) Result {
+	var result Result
+	if p == nil {
+		return result
+	}
+	log := logger.GetLogInstance(ctx, "ModerationPipeline")
+	for _, stage := range p.stages {
+		decision, err := stage.Check(ctx, content)
+		if err != nil {
+			log.Errorf("[ModerationPipeline] stage %s failed for user id: %s, err: %v", stage.Name(), content.UserID, err)
+			continue
+		}
+		decision.Stage = stage.Name()
+		switch decision.Action {
+		case ActionReject:
+			result.Rejection = &decision
+			return result
+		case ActionFlag:
+			result.Flags = append(result.Flags, decision)
+		}
+	}
+	return result
+}
+
+// normalize lower-cases text and collapses everything that isn't a letter or
+// a digit into single spaces, padding the result with a space on each side
+// so that whole words can be found with strings.Contains.
+func normalize(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !isWordRune(r)
+	})
+	return " " + strings.Join(words, " ") + " "
+}
@@ -0,0 +1,132 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	logger "github.com/Abhishekjha321/community_service/log"
+)
+
+func TestMain(m *testing.M) {
+	// Run logs the stages that fail
+	logger.Initialize("test", "moderation")
+	os.Exit(m.Run())
+}
+
+type fakeStage struct {
+	name     string
+	decision Decision
+	err      error
+	calls    int
+}
+
+func (s *fakeStage) Name() string {
+	return s.name
+}
+
+func (s *fakeStage) Check(ctx context.Context, content Content) (Decision, error) {
+	s.calls++
+	return s.decision, s.err
+}
+
+func TestPipelineRun(t *testing.T) {
+	tests := []struct {
+		name          string
+		stages        []*fakeStage
+		wantRejection *Decision
+		wantFlags     []Decision
+		wantCalls     []int
+	}{
+		{
+			name:      "all allow",
+			stages:    []*fakeStage{{name: "a", decision: Allow()}, {name: "b", decision: Allow()}},
+			wantCalls: []int{1, 1},
+		},
+		{
+			name: "reject stops the pipeline",
+			stages: []*fakeStage{
+				{name: "a", decision: Allow()},
+				{name: "b", decision: Reject("no")},
+				{name: "c", decision: Reject("never asked")},
+			},
+			wantRejection: &Decision{Action: ActionReject, Stage: "b", Reason: "no"},
+			wantCalls:     []int{1, 1, 0},
+		},
+		{
+			name: "flags accumulate",
+			stages: []*fakeStage{
+				{name: "a", decision: Flag("one")},
+				{name: "b", decision: Allow()},
+				{name: "c", decision: Flag("two")},
+			},
+			wantFlags: []Decision{{Action: ActionFlag, Stage: "a", Reason: "one"}, {Action: ActionFlag, Stage: "c", Reason: "two"}},
+			wantCalls: []int{1, 1, 1},
+		},
+		{
+			name: "flags raised before a rejection are kept",
+			stages: []*fakeStage{
+				{name: "a", decision: Flag("one")},
+				{name: "b", decision: Reject("no")},
+			},
+			wantRejection: &Decision{Action: ActionReject, Stage: "b", Reason: "no"},
+			wantFlags:     []Decision{{Action: ActionFlag, Stage: "a", Reason: "one"}},
+			wantCalls:     []int{1, 1},
+		},
+		{
+			name: "failing stage is skipped",
+			stages: []*fakeStage{
+				{name: "a", decision: Reject("ignored"), err: errors.New("unavailable")},
+				{name: "b", decision: Flag("one")},
+			},
+			wantFlags: []Decision{{Action: ActionFlag, Stage: "b", Reason: "one"}},
+			wantCalls: []int{1, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stages := make([]Stage, 0, len(tt.stages))
+			for _, stage := range tt.stages {
+				stages = append(stages, stage)
+			}
+			result := NewPipeline(stages...).Run(context.Background(), Content{UserID: "u1", Text: "hello"})
+			if !reflect.DeepEqual(result.Rejection, tt.wantRejection) {
+				t.Errorf("Rejection = %+v, want %+v", result.Rejection, tt.wantRejection)
+			}
+			if result.Rejected() != (tt.wantRejection != nil) {
+				t.Errorf("Rejected() = %v, want %v", result.Rejected(), tt.wantRejection != nil)
+			}
+			if !reflect.DeepEqual(result.Flags, tt.wantFlags) {
+				t.Errorf("Flags = %+v, want %+v", result.Flags, tt.wantFlags)
+			}
+			for i, stage := range tt.stages {
+				if stage.calls != tt.wantCalls[i] {
+					t.Errorf("stage %s called %d times, want %d", stage.name, stage.calls, tt.wantCalls[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNilPipelineAllows(t *testing.T) {
+	var pipeline *Pipeline
+	if result := pipeline.Run(context.Background(), Content{Text: "hello"}); result.Rejected() || len(result.Flags) > 0 {
+		t.Errorf("Run() = %+v, want an empty result", result)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	for input, want := range map[string]string{
+		"":                 "  ",
+		"Hello, World!":    " hello world ",
+		"  spaced\tout\n ": " spaced out ",
+		"don't-stop":       " don t stop ",
+		"Ünïcode 123":      " ünïcode 123 ",
+	} {
+		if got := normalize(input); got != want {
+			t.Errorf("normalize(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	DefaultMaxLength    = 10000
+	DefaultMaxLinks     = 3
+	DefaultRepeatWindow = 10 * time.Minute
+
+	// minWordsWithLinks is how many words besides its links a post needs not
+	// to be flagged as mostly links.
+	minWordsWithLinks = 3
+)
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+
+// LengthStage rejects empty posts and posts longer than the service allows.
+// Posts that only carry attachments can be empty. Channels can set a lower
+// limit of their own.
+type LengthStage struct {
+	maxLength int
+}
+
+func NewLengthStage(maxLength int) *LengthStage {
+	if maxLength <= 0 {
+		maxLength = DefaultMaxLength
+	}
+	return &LengthStage{maxLength: maxLength}
+}
+
+func (s *LengthStage) Name() string {
+	return "length"
+}
+
+func (s *LengthStage) Check(ctx context.Context, content Content) (Decision, error) {
+	if strings.TrimSpace(content.Text) == "" && !content.HasAttachments {
+		return Reject("Post can't be empty"), nil
+	}
+	if utf8.RuneCountInString(content.Text) > s.maxLength {
+		return Reject(fmt.Sprintf("Post can't be longer than %d characters", s.maxLength)), nil
+	}
+	return Allow(), nil
+}
+
+// WordLists gives the words blocked in a channel.
+type WordLists interface {
+	GetChannelBlockedWords(ctx context.Context, channelID string) ([]string, error)
+}
+
+// BlocklistStage rejects posts using a word or phrase blocked everywhere or
+// in the post's channel. Words match whole and regardless of case.
+type BlocklistStage struct {
+	lists  WordLists
+	global []string
+}
+
+func NewBlocklistStage(lists WordLists, globalWords []string) *BlocklistStage {
+	global := make([]string, 0, len(globalWords))
+	for _, word := range globalWords {
+		if word = NormalizeWord(word); word != "" {
+			global = append(global, word)
+		}
+	}
+	return &BlocklistStage{lists: lists, global: global}
+}
+
+func (s *BlocklistStage) Name() string {
+	return "blocklist"
+}
+
+func (s *BlocklistStage) Check(ctx context.Context, content Content) (Decision, error) {
+	text := normalize(content.Text)
+	for _, word := range s.global {
+		if strings.Contains(text, " "+word+" ") {
+			return Reject("Post contains blocked words"), nil
+		}
+	}
+	channelWords, err := s.lists.GetChannelBlockedWords(ctx, content.ChannelID)
+	if err != nil {
+		return Decision{}, err
+	}
+	for _, word := range channelWords {
+		if word = NormalizeWord(word); word != "" && strings.Contains(text, " "+word+" ") {
+			return Reject("Post contains words blocked in this channel"), nil
+		}
+	}
+	return Allow(), nil
+}
+
+// LinkSpamStage rejects posts with more links than allowed and flags posts
+// that are little more than links.
+type LinkSpamStage struct {
+	maxLinks int
+}
+
+func NewLinkSpamStage(maxLinks int) *LinkSpamStage {
+	if maxLinks <= 0 {
+		maxLinks = DefaultMaxLinks
+	}
+	return &LinkSpamStage{maxLinks: maxLinks}
+}
+
+func (s *LinkSpamStage) Name() string {
+	return "link_spam"
+}
+
+func (s *LinkSpamStage) Check(ctx context.Context, content Content) (Decision, error) {
+	links := linkPattern.FindAllString(content.Text, -1)
+	if len(links) == 0 {
+		return Allow(), nil
+	}
+	if len(links) > s.maxLinks {
+		return Reject(fmt.Sprintf("Post can't have more than %d links", s.maxLinks)), nil
+	}
+	if len(strings.Fields(normalize(linkPattern.ReplaceAllString(content.Text, " ")))) < minWordsWithLinks {
+		return Flag("Post is mostly links"), nil
+	}
+	return Allow(), nil
+}
+
+// PostHistory tells whether a user recently posted the same content in a
+// post other than excludePostID.
+type PostHistory interface {
+	HasRecentPost(ctx context.Context, userID string, content string, since time.Time, excludePostID int64) (bool, error)
+}
+
+// RepeatStage rejects content the user already posted within the window. An
+// edited post isn't compared with itself.
+type RepeatStage struct {
+	history PostHistory
+	window  time.Duration
+}
+
+func NewRepeatStage(history PostHistory, window time.Duration) *RepeatStage {
+	if window <= 0 {
+		window = DefaultRepeatWindow
+	}
+	return &RepeatStage{history: history, window: window}
+}
+
+func (s *RepeatStage) Name() string {
+	return "repeat"
+}
+
+func (s *RepeatStage) Check(ctx context.Context, content Content) (Decision, error) {
+	text := strings.TrimSpace(content.Text)
+	if text == "" {
+		return Allow(), nil
+	}
+	repeated, err := s.history.HasRecentPost(ctx, content.UserID, text, time.Now().Add(-s.window), content.PostID)
+	if err != nil {
+		return Decision{}, err
+	}
+	if repeated {
+		return Reject("You already posted this recently"), nil
+	}
+	return Allow(), nil
+}
+
+// NormalizeWord brings a blocked word or phrase to the form it is matched in.
+func NormalizeWord(word string) string {
+	return strings.TrimSpace(normalize(word))
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,137 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeWordLists struct {
+	words []string
+	err   error
+}
+
+func (f fakeWordLists) GetChannelBlockedWords(ctx context.Context, channelID string) ([]string, error) {
+	return f.words, f.err
+}
+
+type fakePostHistory struct {
+	repeated      bool
+	err           error
+	content       string
+	excludePostID int64
+}
+
+func (f *fakePostHistory) HasRecentPost(ctx context.Context, userID string, content string, since time.Time, excludePostID int64) (bool, error) {
+	f.content = content
+	f.excludePostID = excludePostID
+	return f.repeated, f.err
+}
+
+func checkStage(t *testing.T, stage Stage, content Content, want Action, wantErr bool) {
+	t.Helper()
+	decision, err := stage.Check(context.Background(), content)
+	if (err != nil) != wantErr {
+		t.Fatalf("%s: Check(%+v) error = %v, wantErr %v", stage.Name(), content, err, wantErr)
+	}
+	if err == nil && decision.Action != want {
+		t.Errorf("%s: Check(%+v) = %s (%s), want %s", stage.Name(), content, decision.Action, decision.Reason, want)
+	}
+}
+
+func TestLengthStage(t *testing.T) {
+	tests := []struct {
+		name    string
+		content Content
+		want    Action
+	}{
+		{name: "short", content: Content{Text: "hello"}, want: ActionAllow},
+		{name: "empty", content: Content{Text: "  "}, want: ActionReject},
+		{name: "empty with attachments", content: Content{HasAttachments: true}, want: ActionAllow},
+		{name: "at the limit in runes", content: Content{Text: strings.Repeat("é", 10)}, want: ActionAllow},
+		{name: "over the limit", content: Content{Text: strings.Repeat("a", 11)}, want: ActionReject},
+	}
+	stage := NewLengthStage(10)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkStage(t, stage, tt.content, tt.want, false)
+		})
+	}
+	if got := NewLengthStage(0).maxLength; got != DefaultMaxLength {
+		t.Errorf("NewLengthStage(0).maxLength = %d, want %d", got, DefaultMaxLength)
+	}
+}
+
+func TestBlocklistStage(t *testing.T) {
+	tests := []struct {
+		name    string
+		lists   fakeWordLists
+		text    string
+		want    Action
+		wantErr bool
+	}{
+		{name: "clean", text: "hello there", want: ActionAllow},
+		{name: "global word", text: "you are a Spammer!", want: ActionReject},
+		{name: "global phrase", text: "buy  NOW, please", want: ActionReject},
+		{name: "part of a word", text: "spammers unite", want: ActionAllow},
+		{name: "channel word", lists: fakeWordLists{words: []string{"Rust"}}, text: "I like rust.", want: ActionReject},
+		{name: "channel list fails", lists: fakeWordLists{err: errors.New("down")}, text: "hello", wantErr: true},
+		{name: "global words checked before the channel list", lists: fakeWordLists{err: errors.New("down")}, text: "spammer", want: ActionReject},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stage := NewBlocklistStage(tt.lists, []string{"spammer", "Buy now", " ", "!!"})
+			checkStage(t, stage, Content{Text: tt.text}, tt.want, tt.wantErr)
+		})
+	}
+}
+
+func TestLinkSpamStage(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want Action
+	}{
+		{name: "no links", text: "just words", want: ActionAllow},
+		{name: "link with text", text: "see https://example.com for the whole story", want: ActionAllow},
+		{name: "mostly links", text: "look https://example.com", want: ActionFlag},
+		{name: "at the limit", text: "one http://a.com two www.b.com and more words here", want: ActionAllow},
+		{name: "over the limit", text: "http://a.com http://b.com http://c.com with lots of words", want: ActionReject},
+	}
+	stage := NewLinkSpamStage(2)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkStage(t, stage, Content{Text: tt.text}, tt.want, false)
+		})
+	}
+}
+
+func TestRepeatStage(t *testing.T) {
+	tests := []struct {
+		name    string
+		history *fakePostHistory
+		content Content
+		want    Action
+		wantErr bool
+	}{
+		{name: "new content", history: &fakePostHistory{}, content: Content{Text: "hello"}, want: ActionAllow},
+		{name: "repeated", history: &fakePostHistory{repeated: true}, content: Content{Text: "hello"}, want: ActionReject},
+		{name: "empty text isn't checked", history: &fakePostHistory{repeated: true}, content: Content{Text: " "}, want: ActionAllow},
+		{name: "history fails", history: &fakePostHistory{err: errors.New("down")}, content: Content{Text: "hello"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkStage(t, NewRepeatStage(tt.history, time.Minute), tt.content, tt.want, tt.wantErr)
+		})
+	}
+}
+
+func TestRepeatStageExcludesEditedPost(t *testing.T) {
+	history := &fakePostHistory{}
+	checkStage(t, NewRepeatStage(history, time.Minute), Content{PostID: 42, Text: "  hello  "}, ActionAllow, false)
+	if history.excludePostID != 42 || history.content != "hello" {
+		t.Errorf("HasRecentPost called with content %q excluding %d, want %q excluding 42", history.content, history.excludePostID, "hello")
+	}
+}
@@ -0,0 +1,109 @@
+package ratelimit
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Client is who a request is counted against. Requests are counted per API
+// key when a known one is sent, then per user, then per IP. APIKey must only
+// be set once the key has been checked against a KeySet. The user id is sent
+// by the client, so requests with one are counted per IP as well.
+type Client struct {
+	APIKey string
+	UserID string
+	IP     string
+}
+
+// Key identifies the client's bucket.
+func (c Client) Key() string {
+	switch {
+	case c.APIKey != "":
+		return "key:" + c.APIKey
+	case c.UserID != "":
+		return "user:" + c.UserID
+	default:
+		return "ip:" + c.IP
+	}
+}
+
+// Keys identifies every bucket the client's requests are counted in, the
+// IP's bucket coming first.
+func (c Client) Keys() []string {
+	if c.APIKey == "" && c.UserID != "" {
+		return []string{"ip:" + c.IP, c.Key()}
+	}
+	return []string{c.Key()}
+}
+
+// KeySet holds the API keys that clients can be told apart by.
+type KeySet map[string]bool
+
+// NewKeySet builds a KeySet from the configured keys, skipping blank ones.
+func NewKeySet(keys []string) KeySet {
+	set := make(KeySet, len(keys))
+	for _, key := range keys {
+		if key = strings.TrimSpace(key); key != "" {
+			set[key] = true
+		}
+	}
+	return set
+}
+
+// Contains reports whether key is a known API key.
+func (s KeySet) Contains(key string) bool {
+	return key != "" && s[key]
+}
+
+// ClientList matches clients by API key, user id, IP or IP range.
+type ClientList struct {
+	ids    map[string]bool
+	ranges []*net.IPNet
+}
+
+// NewClientList parses a list of API keys, user ids, IPs and CIDR ranges.
+func NewClientList(entries []string) (*ClientList, error) {
+	list := &ClientList{ids: make(map[string]bool)}
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if strings.Contains(entry, "/") {
+			_, ipRange, err := net.ParseCIDR(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ip range %q: %w", entry, err)
+			}
+			list.ranges = append(list.ranges, ipRange)
+			continue
+		}
+		list.ids[entry] = true
+	}
+	return list, nil
+}
+
+// Contains reports whether the client's API key, user id or IP is listed.
+func (l *ClientList) Contains(client Client) bool {
+	if l == nil {
+		return false
+	}
+	for _, id := range []string{client.APIKey, client.UserID, client.IP} {
+		if id != "" && l.ids[id] {
+			return true
+		}
+	}
+	if len(l.ranges) == 0 {
+		return false
+	}
+	ip := net.ParseIP(client.IP)
+	if ip == nil {
+		return false
+	}
+	for _, ipRange := range l.ranges {
+		if ipRange.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClientKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		client Client
+		want   string
+	}{
+		{name: "api key wins", client: Client{APIKey: "k1", UserID: "u1", IP: "10.0.0.1"}, want: "key:k1"},
+		{name: "user before ip", client: Client{UserID: "u1", IP: "10.0.0.1"}, want: "user:u1"},
+		{name: "ip", client: Client{IP: "10.0.0.1"}, want: "ip:10.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.client.Key(); got != tt.want {
+				t.Errorf("Key() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientKeys(t *testing.T) {
+	tests := []struct {
+		name   string
+		client Client
+		want   []string
+	}{
+		{name: "api key only", client: Client{APIKey: "k1", UserID: "u1", IP: "10.0.0.1"}, want: []string{"key:k1"}},
+		{name: "user and ip", client: Client{UserID: "u1", IP: "10.0.0.1"}, want: []string{"ip:10.0.0.1", "user:u1"}},
+		{name: "ip", client: Client{IP: "10.0.0.1"}, want: []string{"ip:10.0.0.1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.client.Keys(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Keys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeySet(t *testing.T) {
+	keys := NewKeySet([]string{" k1 ", "", "k2"})
+	for key, want := range map[string]bool{"k1": true, "k2": true, "": false, " k1 ": false, "k3": false} {
+		if got := keys.Contains(key); got != want {
+			t.Errorf("Contains(%q) = %v, want %v", key, got, want)
+		}
+	}
+	if NewKeySet(nil).Contains("k1") {
+		t.Error("empty key set contains k1")
+	}
+}
+
+func TestNewClientListInvalidRange(t *testing.T) {
+	if _, err := NewClientList([]string{"10.0.0.0/33"}); err == nil {
+		t.Error("NewClientList() accepted an invalid range")
+	}
+}
+
+func TestClientListContains(t *testing.T) {
+	list, err := NewClientList([]string{"k1", " u1 ", "", "10.0.0.1", "192.168.0.0/16", "2001:db8::/32"})
+	if err != nil {
+		t.Fatalf("NewClientList() error = %v", err)
+	}
+	tests := []struct {
+		name   string
+		client Client
+		want   bool
+	}{
+		{name: "api key", client: Client{APIKey: "k1", IP: "1.1.1.1"}, want: true},
+		{name: "user id", client: Client{UserID: "u1", IP: "1.1.1.1"}, want: true},
+		{name: "ip", client: Client{IP: "10.0.0.1"}, want: true},
+		{name: "ip in range", client: Client{IP: "192.168.4.20"}, want: true},
+		{name: "ipv6 in range", client: Client{IP: "2001:db8::1"}, want: true},
+		{name: "ip outside range", client: Client{IP: "192.169.0.1"}},
+		{name: "unlisted", client: Client{APIKey: "k2", UserID: "u2", IP: "1.1.1.1"}},
+		{name: "invalid ip", client: Client{IP: "not-an-ip"}},
+		{name: "empty client", client: Client{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := list.Contains(tt.client); got != tt.want {
+				t.Errorf("Contains(%+v) = %v, want %v", tt.client, got, tt.want)
+			}
+		})
+	}
+
+	var nilList *ClientList
+	if nilList.Contains(Client{IP: "10.0.0.1"}) {
+		t.Error("nil list contains a client")
+	}
+}
@@ -0,0 +1,207 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Abhishekjha321/community_service/storage/cache"
+)
+
+const (
+	libraryName  = "community_ratelimit"
+	hitFunction  = "community_ratelimit_hit"
+	takeFunction = "community_ratelimit_take"
+
+	keyPrefix = "ratelimit:"
+)
+
+// library holds two functions. The hit function counts a hit against every
+// key passed to it, a limit and a window in milliseconds being passed for each
+// key. When any key is already at its limit nothing is counted and it returns
+// how long until that key's window ends, with the key's position; otherwise it
+// returns zeros.
+//
+// The take function takes a token from the bucket kept at its key, the bucket
+// holding up to a limit of tokens and refilling fully over a window. It
+// returns whether a token was taken, the whole tokens left, how long until
+// the next token and how long until the bucket is full, in milliseconds.
+const library = `#!lua name=` + libraryName + `
+redis.register_function('` + hitFunction + `', function(keys, args)
+  local wait, refused = 0, 0
+  for i, key in ipairs(keys) do
+    local limit = tonumber(args[2 * i - 1])
+    local window = tonumber(args[2 * i])
+    local count = tonumber(redis.call('GET', key) or '0')
+    if count >= limit then
+      local ttl = redis.call('PTTL', key)
+      if ttl < 0 then
+        redis.call('PEXPIRE', key, window)
+        ttl = window
+      end
+      if ttl > wait then
+        wait, refused = ttl, i
+      end
+    end
+  end
+  if refused > 0 then
+    return {wait, refused}
+  end
+  for i, key in ipairs(keys) do
+    if redis.call('INCR', key) == 1 then
+      redis.call('PEXPIRE', key, args[2 * i])
+    end
+  end
+  return {0, 0}
+end)
+
+redis.register_function('` + takeFunction + `', function(keys, args)
+  local limit = tonumber(args[1])
+  local window = tonumber(args[2])
+  local time = redis.call('TIME')
+  local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+  local state = redis.call('HMGET', keys[1], 'tokens', 'ts')
+  local tokens = tonumber(state[1]) or limit
+  local ts = tonumber(state[2]) or now
+  tokens = math.min(limit, tokens + math.max(0, now - ts) * limit / window)
+  local taken, wait = 0, 0
+  if tokens >= 1 then
+    tokens, taken = tokens - 1, 1
+  else
+    wait = math.ceil((1 - tokens) * window / limit)
+  end
+  redis.call('HSET', keys[1], 'tokens', tostring(tokens), 'ts', now)
+  redis.call('PEXPIRE', keys[1], window)
+  return {taken, math.floor(tokens), wait, math.ceil((limit - tokens) * window / limit)}
+end)
+`
+
+// Rate is how many hits are allowed per window.
+type Rate struct {
+	Limit  int
+	Window time.Duration
+}
+
+// Rule applies a rate to the hits counted under a key.
+type Rule struct {
+	Key string
+	Rate
+}
+
+// Bucket is the state of a token bucket after taking a token from it.
+type Bucket struct {
+	Taken     bool
+	Remaining int
+	// RetryAfter is how long until the next token when none was taken.
+	RetryAfter time.Duration
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+}
+
+// Limiter counts hits in fixed windows kept in Redis. The counting is done by
+// a Redis function so that checking and counting several rules is atomic.
+type Limiter struct {
+	cache cache.CacheBase
+}
+
+func NewLimiter(cache cache.CacheBase) *Limiter {
+	return &Limiter{cache: cache}
+}
+
+// Key builds the key of a rule. The scope and id form the key's hash tag, so
+// rules of the same scope and id can be hit together on a Redis cluster.
+func Key(scope string, id string, parts ...string) string {
+	key := keyPrefix + "{" + scope + ":" + id + "}"
+	for _, part := range parts {
+		key += ":" + part
+	}
+	return key
+}
+
+// Load registers the Redis function, replacing a library loaded by an older
+// version of the service.
+func (l *Limiter) Load(ctx context.Context) error {
+	if _, err := l.cache.FunctionLoad(ctx, library); err != nil {
+		if !strings.Contains(err.Error(), "already exists") {
+			return fmt.Errorf("failed to load rate limit function: %w", err)
+		}
+		if _, err := l.cache.FunctionReload(ctx, libraryName, library); err != nil {
+			return fmt.Errorf("failed to reload rate limit function: %w", err)
+		}
+	}
+	return nil
+}
+
+// Hit counts a hit against every rule. When a rule is at its limit nothing is
+// counted, and the rule is returned with how long until it allows hits again.
+func (l *Limiter) Hit(ctx context.Context, rules ...Rule) (*Rule, time.Duration, error) {
+	var active []Rule
+	for _, rule := range rules {
+		if rule.Limit > 0 && rule.Window > 0 {
+			active = append(active, rule)
+		}
+	}
+	if len(active) == 0 {
+		return nil, 0, nil
+	}
+	keys := make([]string, 0, len(active))
+	args := make([]interface{}, 0, 2*len(active))
+	for _, rule := range active {
+		keys = append(keys, rule.Key)
+		args = append(args, rule.Limit, rule.Window.Milliseconds())
+	}
+
+	values, err := l.call(ctx, hitFunction, keys, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(values) != 2 {
+		return nil, 0, fmt.Errorf("unexpected rate limit result: %v", values)
+	}
+	wait, refused := values[0], values[1]
+	if refused <= 0 || refused > int64(len(active)) {
+		return nil, 0, nil
+	}
+	return &active[refused-1], time.Duration(wait) * time.Millisecond, nil
+}
+
+// Take takes a token from the bucket kept at key, which holds up to the
+// rate's limit of tokens and refills fully over its window. A rate without a
+// limit always gives a token.
+func (l *Limiter) Take(ctx context.Context, key string, rate Rate) (*Bucket, error) {
+	if rate.Limit <= 0 || rate.Window <= 0 {
+		return &Bucket{Taken: true}, nil
+	}
+	values, err := l.call(ctx, takeFunction, []string{key}, rate.Limit, rate.Window.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != 4 {
+		return nil, fmt.Errorf("unexpected rate limit result: %v", values)
+	}
+	return &Bucket{
+		Taken:      values[0] == 1,
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+		Reset:      time.Duration(values[3]) * time.Millisecond,
+	}, nil
+}
+
+func (l *Limiter) call(ctx context.Context, function string, keys []string, args ...interface{}) ([]int64, error) {
+	res, err := l.cache.FunctionCall(ctx, function, keys, args...)
+	if err != nil && strings.Contains(err.Error(), "Function not found") {
+		// the library is lost when Redis restarts without persistence
+		if err = l.Load(ctx); err == nil {
+			res, err = l.cache.FunctionCall(ctx, function, keys, args...)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("rate limit call failed: %w", err)
+	}
+	values, err := res.Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected rate limit result: %w", err)
+	}
+	return values, nil
+}
@@ -0,0 +1,117 @@
+package richtext
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	mentionPrefix     = '@'
+	maxMentionNameLen = 50
+	hashtagPrefix     = '#'
+	maxHashtagLen     = 50
+)
+
+// Span is a token found in post content. Start and End are offsets in
+// characters (unicode code points), End being exclusive, so clients can
+// highlight the token without re-parsing the content.
+type Span struct {
+	Value string
+	Start int
+	End   int
+}
+
+// Mentions returns the @username tokens of content in order of appearance.
+// Value holds the name without the @. A mention must not follow a letter or
+// digit, which keeps e-mail addresses out, and a trailing dot is treated as
+// punctuation.
+func Mentions(content string) []Span {
+	return prefixedTokens(content, mentionPrefix, isMentionRune, maxMentionNameLen)
+}
+
+// Hashtags returns the #tag tokens of content in order of appearance, Value
+// holding the tag without the #. Tags may use letters of any script, digits
+// and underscores but need at least one letter, so "#1" is not a tag.
+func Hashtags(content string) []Span {
+	var spans []Span
+	for _, span := range prefixedTokens(content, hashtagPrefix, isHashtagRune, maxHashtagLen) {
+		if strings.IndexFunc(span.Value, unicode.IsLetter) >= 0 {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// Tags returns the distinct hashtags of content, normalised with NormalizeTag.
+func Tags(content string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, span := range Hashtags(content) {
+		tag := NormalizeTag(span.Value)
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// NormalizeTag lower-cases a tag and drops a leading #, so "#Go" and "go"
+// name the same tag.
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), string(hashtagPrefix)))
+}
+
+// Excerpt returns content on a single line, cut to at most maxLen characters.
+// A cut is made at the last space when there is one and is marked with an
+// ellipsis.
+func Excerpt(content string, maxLen int) string {
+	text := strings.Join(strings.Fields(content), " ")
+	runes := []rune(text)
+	if len(runes) <= maxLen {
+		return text
+	}
+	cut := string(runes[:maxLen])
+	if i := strings.LastIndexByte(cut, ' '); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " .,;:") + "…"
+}
+
+func isHashtagRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+func isMentionRune(r rune) bool {
+	return r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.')
+}
+
+// prefixedTokens finds the tokens made of a prefix followed by runes accepted
+// by isTokenRune. Tokens longer than maxLen are skipped.
+func prefixedTokens(content string, prefix rune, isTokenRune func(rune) bool, maxLen int) []Span {
+	var spans []Span
+	runes := []rune(content)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != prefix {
+			continue
+		}
+		if i > 0 && (unicode.IsLetter(runes[i-1]) || unicode.IsDigit(runes[i-1]) || runes[i-1] == '_' || runes[i-1] == prefix) {
+			continue
+		}
+		end := i + 1
+		for end < len(runes) && isTokenRune(runes[end]) {
+			end++
+		}
+		for end > i+1 && runes[end-1] == '.' {
+			end--
+		}
+		if end == i+1 || end-i-1 > maxLen {
+			i = end - 1
+			continue
+		}
+		spans = append(spans, Span{Value: string(runes[i+1 : end]), Start: i, End: end})
+		i = end - 1
+	}
+	return spans
+}
@@ -0,0 +1,108 @@
+package richtext
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMentions(t *testing.T) {
+	long := strings.Repeat("a", maxMentionNameLen)
+	tests := []struct {
+		name    string
+		content string
+		want    []Span
+	}{
+		{name: "none", content: "hello world"},
+		{name: "single", content: "hi @alice", want: []Span{{Value: "alice", Start: 3, End: 9}}},
+		{name: "several in order", content: "@bob and @carol_1", want: []Span{{Value: "bob", Start: 0, End: 4}, {Value: "carol_1", Start: 9, End: 17}}},
+		{name: "e-mail address", content: "mail me at bob@example.com"},
+		{name: "after underscore", content: "x_@bob"},
+		{name: "trailing dot", content: "thanks @alice.", want: []Span{{Value: "alice", Start: 7, End: 13}}},
+		{name: "trailing dots", content: "@alice...", want: []Span{{Value: "alice", Start: 0, End: 6}}},
+		{name: "dot inside name", content: "@alice.smith!", want: []Span{{Value: "alice.smith", Start: 0, End: 12}}},
+		{name: "double at", content: "@@x"},
+		{name: "lone at", content: "@ home"},
+		{name: "only dots", content: "@..."},
+		{name: "unicode offsets", content: "héllo 👋 @bob", want: []Span{{Value: "bob", Start: 8, End: 12}}},
+		{name: "non ascii name stops the token", content: "@zoë", want: []Span{{Value: "zo", Start: 0, End: 3}}},
+		{name: "max length", content: "@" + long, want: []Span{{Value: long, Start: 0, End: maxMentionNameLen + 1}}},
+		{name: "over max length is skipped", content: "@" + long + "a @bob", want: []Span{{Value: "bob", Start: maxMentionNameLen + 3, End: maxMentionNameLen + 7}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Mentions(tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Mentions(%q) = %+v, want %+v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHashtags(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Span
+	}{
+		{name: "single", content: "love #golang", want: []Span{{Value: "golang", Start: 5, End: 12}}},
+		{name: "digits only isn't a tag", content: "issue #1"},
+		{name: "letters and digits", content: "#go1", want: []Span{{Value: "go1", Start: 0, End: 4}}},
+		{name: "inside a word", content: "c#sharp"},
+		{name: "double hash", content: "##go"},
+		{name: "any script", content: "#café #東京", want: []Span{{Value: "café", Start: 0, End: 5}, {Value: "東京", Start: 6, End: 9}}},
+		{name: "punctuation ends the tag", content: "#go, #rust.", want: []Span{{Value: "go", Start: 0, End: 3}, {Value: "rust", Start: 5, End: 10}}},
+		{name: "over max length is skipped", content: "#" + strings.Repeat("x", maxHashtagLen+1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Hashtags(tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Hashtags(%q) = %+v, want %+v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTags(t *testing.T) {
+	tests := []struct {
+		content string
+		want    []string
+	}{
+		{content: "no tags"},
+		{content: "#Go #go #GO", want: []string{"go"}},
+		{content: "#Rust then #go then #rust", want: []string{"rust", "go"}},
+		{content: "mail a#b or #1", want: nil},
+	}
+	for _, tt := range tests {
+		if got := Tags(tt.content); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Tags(%q) = %v, want %v", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeTag(t *testing.T) {
+	for input, want := range map[string]string{"#Go": "go", " go ": "go", "GoLang": "golang", "##go": "#go"} {
+		if got := NormalizeTag(input); got != want {
+			t.Errorf("NormalizeTag(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		content string
+		maxLen  int
+		want    string
+	}{
+		{content: "short", maxLen: 10, want: "short"},
+		{content: "  spread\n over\tlines  ", maxLen: 50, want: "spread over lines"},
+		{content: "cut at the last space", maxLen: 12, want: "cut at the…"},
+		{content: "sentence. more", maxLen: 12, want: "sentence…"},
+		{content: "unbrokenword", maxLen: 5, want: "unbro…"},
+		{content: "héllo wörld", maxLen: 8, want: "héllo…"},
+	}
+	for _, tt := range tests {
+		if got := Excerpt(tt.content, tt.maxLen); got != tt.want {
+			t.Errorf("Excerpt(%q, %d) = %q, want %q", tt.content, tt.maxLen, got, tt.want)
+		}
+	}
+}
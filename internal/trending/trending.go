@@ -0,0 +1,246 @@
+package trending
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	logger "github.com/Abhishekjha321/community_service/log"
+	"github.com/Abhishekjha321/community_service/storage/cache"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	InteractionLike     = "like"
+	InteractionReply    = "reply"
+	InteractionBookmark = "bookmark"
+
+	DefaultWindow          = 48 * time.Hour
+	DefaultHalfLife        = 6 * time.Hour
+	DefaultRefreshInterval = time.Minute
+
+	// bucketSize is the granularity at which interactions are counted. Decay
+	// is applied per bucket when the trending sets are rebuilt.
+	bucketSize = time.Hour
+	// minScore is the score below which a member is considered decayed and
+	// pruned from a trending set.
+	minScore = 0.01
+
+	keyPrefix = "trending:"
+	scopesKey = keyPrefix + "scopes"
+)
+
+// interactionWeights is how much a single interaction of each kind adds to
+// the score of a post or channel.
+var interactionWeights = map[string]float64{
+	InteractionLike:     1,
+	InteractionReply:    3,
+	InteractionBookmark: 2,
+}
+
+// Interaction is a like, reply or bookmark on a post.
+type Interaction struct {
+	Kind      string
+	PostID    int64
+	ChannelID string
+	ForumIDs  []int64
+}
+
+// Entry is a member of a trending set with its decayed score.
+type Entry struct {
+	ID    string
+	Score float64
+}
+
+// Tracker keeps trending scores in Redis sorted sets. Every interaction is
+// added to a sorted set per scope and hour; Refresh periodically folds the
+// buckets of the window into one set per scope, weighting each bucket by its
+// age, and prunes members and scopes that have decayed away.
+type Tracker struct {
+	cache           cache.CacheBase
+	window          time.Duration
+	halfLife        time.Duration
+	refreshInterval time.Duration
+}
+
+func NewTracker(cache cache.CacheBase, window time.Duration, halfLife time.Duration, refreshInterval time.Duration) *Tracker {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	if halfLife <= 0 {
+		halfLife = DefaultHalfLife
+	}
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultRefreshInterval
+	}
+	return &Tracker{
+		cache:           cache,
+		window:          window,
+		halfLife:        halfLife,
+		refreshInterval: refreshInterval,
+	}
+}
+
+func ChannelPostsScope(channelID string) string {
+	return "posts:channel:" + channelID
+}
+
+func ForumPostsScope(forumID int64) string {
+	return "posts:forum:" + strconv.FormatInt(forumID, 10)
+}
+
+func ChannelsScope() string {
+	return "channels"
+}
+
+func scopeKey(scope string) string {
+	return keyPrefix + scope
+}
+
+func bucketKey(scope string, bucket time.Time) string {
+	return fmt.Sprintf("%s%s:%d", keyPrefix, scope, bucket.Unix())
+}
+
+// Record adds an interaction to the current bucket of the post's channel and
+// forums and to the channel's score in the global channel set.
+func (t *Tracker) Record(ctx context.Context, interaction Interaction) error {
+	weight, ok := interactionWeights[interaction.Kind]
+	if !ok {
+		return fmt.Errorf("unknown trending interaction: %s", interaction.Kind)
+	}
+	now := time.Now()
+	bucket := now.Truncate(bucketSize)
+	postID := strconv.FormatInt(interaction.PostID, 10)
+
+	scores := map[string]string{
+		ChannelPostsScope(interaction.ChannelID): postID,
+		ChannelsScope():                          interaction.ChannelID,
+	}
+	for _, forumID := range interaction.ForumIDs {
+		scores[ForumPostsScope(forumID)] = postID
+	}
+	for scope, member := range scores {
+		key := bucketKey(scope, bucket)
+		if _, err := t.cache.ZIncrBy(ctx, key, weight, member); err != nil {
+			return err
+		}
+		if err := t.cache.ExpireKey(ctx, key, t.window+bucketSize); err != nil {
+			return err
+		}
+		if err := t.cache.ZAdd(ctx, scopesKey, redis.Z{Score: float64(now.Unix()), Member: scope}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Top returns the highest scoring members of a scope as of the last refresh.
+func (t *Tracker) Top(ctx context.Context, scope string, limit int) ([]Entry, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	members, err := t.cache.ZRevRangeWithScores(ctx, scopeKey(scope), 0, int64(limit-1))
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(members))
+	for _, member := range members {
+		id, ok := member.Member.(string)
+		if !ok {
+			continue
+		}
+		entries = append(entries, Entry{ID: id, Score: member.Score})
+	}
+	return entries, nil
+}
+
+// Start refreshes the trending sets until ctx is cancelled.
+func (t *Tracker) Start(ctx context.Context) {
+	log := logger.GetLogInstance(ctx, "TrendingTracker")
+	ticker := time.NewTicker(t.refreshInterval)
+	defer ticker.Stop()
+	for {
+		if refreshed, err := t.Refresh(ctx); err != nil {
+			log.Errorf("[TrendingTracker] failed to refresh trending sets: %v", err)
+		} else {
+			log.Debugf("[TrendingTracker] refreshed %d trending sets", refreshed)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh rebuilds the trending set of every scope that saw an interaction
+// within the window and drops the scopes that did not. It returns the number
+// of sets rebuilt.
+func (t *Tracker) Refresh(ctx context.Context) (int, error) {
+	now := time.Now()
+	cutoff := now.Add(-t.window)
+
+	idle, err := t.cache.ZRangeByScore(ctx, scopesKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: "(" + strconv.FormatInt(cutoff.Unix(), 10),
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, scope := range idle {
+		if err := t.cache.DeleteKey(ctx, scopeKey(scope)); err != nil {
+			return 0, err
+		}
+	}
+	if _, err := t.cache.ZRemRangeByScore(ctx, scopesKey, "-inf", "("+strconv.FormatInt(cutoff.Unix(), 10)); err != nil {
+		return 0, err
+	}
+
+	scopes, err := t.cache.ZRange(ctx, scopesKey, 0, -1)
+	if err != nil {
+		return 0, err
+	}
+	keys, weights := t.bucketWeights(now)
+	for i, scope := range scopes {
+		if err := t.rebuild(ctx, scope, keys, weights); err != nil {
+			return i, err
+		}
+	}
+	return len(scopes), nil
+}
+
+// bucketWeights returns the start of every bucket in the window with the
+// decay factor for its age, halving every halfLife.
+func (t *Tracker) bucketWeights(now time.Time) ([]time.Time, []float64) {
+	var (
+		buckets []time.Time
+		weights []float64
+	)
+	for bucket := now.Truncate(bucketSize); now.Sub(bucket) <= t.window; bucket = bucket.Add(-bucketSize) {
+		// age is measured to the middle of the bucket
+		age := now.Sub(bucket.Add(bucketSize / 2))
+		if age < 0 {
+			age = 0
+		}
+		buckets = append(buckets, bucket)
+		weights = append(weights, math.Pow(0.5, age.Hours()/t.halfLife.Hours()))
+	}
+	return buckets, weights
+}
+
+func (t *Tracker) rebuild(ctx context.Context, scope string, buckets []time.Time, weights []float64) error {
+	keys := make([]string, 0, len(buckets))
+	for _, bucket := range buckets {
+		keys = append(keys, bucketKey(scope, bucket))
+	}
+	destination := scopeKey(scope)
+	if _, err := t.cache.ZUnionStore(ctx, destination, keys, weights); err != nil {
+		return err
+	}
+	if _, err := t.cache.ZRemRangeByScore(ctx, destination, "-inf", "("+strconv.FormatFloat(minScore, 'f', -1, 64)); err != nil {
+		return err
+	}
+	return t.cache.ExpireKey(ctx, destination, t.window)
+}
@@ -0,0 +1,4 @@
+ALTER TABLE posts DROP COLUMN IF EXISTS controversy_score;
+ALTER TABLE posts DROP COLUMN IF EXISTS hot_score;
+ALTER TABLE posts DROP COLUMN IF EXISTS report_count;
+ALTER TABLE posts DROP COLUMN IF EXISTS reply_count;
@@ -0,0 +1,17 @@
+-- Backfills the counters and scores of posts written before these columns existed.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS reply_count BIGINT DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS report_count BIGINT DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS hot_score DOUBLE PRECISION DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS controversy_score DOUBLE PRECISION DEFAULT 0;
+
+UPDATE posts p SET
+    reply_count = (SELECT COUNT(*) FROM posts c WHERE c.parent_id = p.id),
+    report_count = (SELECT COUNT(*) FROM reports r WHERE r.post_id = p.id::text);
+
+UPDATE posts SET
+    hot_score = LOG(GREATEST(like_count + 2 * reply_count, 1)) + EXTRACT(EPOCH FROM created_at) / 45000,
+    controversy_score = CASE
+        WHEN like_count + reply_count > 0 AND report_count > 0 THEN
+            POWER(like_count + reply_count + report_count, LEAST(like_count + reply_count, report_count)::float8 / GREATEST(like_count + reply_count, report_count))
+        ELSE 0
+    END;
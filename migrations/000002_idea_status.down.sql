@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_posts_idea_status;
+ALTER TABLE posts DROP COLUMN IF EXISTS idea_status_updated_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS official_response_by;
+ALTER TABLE posts DROP COLUMN IF EXISTS official_response;
+ALTER TABLE posts DROP COLUMN IF EXISTS idea_status;
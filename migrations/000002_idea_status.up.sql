@@ -0,0 +1,9 @@
+-- Opens every idea posted before the ideas board had statuses.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS idea_status TEXT;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS official_response TEXT;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS official_response_by TEXT;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS idea_status_updated_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_posts_idea_status ON posts (idea_status);
+
+UPDATE posts SET idea_status = 'OPEN'
+WHERE channel_id = 'community-ideas' AND type = 'COMMENT' AND (idea_status IS NULL OR idea_status = '');
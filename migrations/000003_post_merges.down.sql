@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS post_merges;
+ALTER TABLE posts DROP COLUMN IF EXISTS merged_into_id;
@@ -0,0 +1,18 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS merged_into_id BIGINT DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS post_merges (
+    id BIGSERIAL PRIMARY KEY,
+    source_post_id BIGINT,
+    target_post_id BIGINT,
+    merged_by TEXT,
+    source_content TEXT,
+    source_status TEXT,
+    moved_likes JSONB,
+    moved_reply_ids JSONB,
+    reverted_by TEXT,
+    reverted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_post_merges_source_post_id ON post_merges (source_post_id);
+CREATE INDEX IF NOT EXISTS idx_post_merges_target_post_id ON post_merges (target_post_id);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS post_votes;
+DROP TABLE IF EXISTS channel_settings;
+DROP INDEX IF EXISTS idx_posts_channel_vote_score;
+ALTER TABLE posts DROP COLUMN IF EXISTS downvote_count;
+ALTER TABLE posts DROP COLUMN IF EXISTS upvote_count;
+ALTER TABLE posts DROP COLUMN IF EXISTS vote_score;
@@ -0,0 +1,23 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS vote_score BIGINT DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS upvote_count BIGINT DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS downvote_count BIGINT DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_posts_channel_vote_score ON posts (channel_id, vote_score DESC);
+
+CREATE TABLE IF NOT EXISTS channel_settings (
+    id BIGSERIAL PRIMARY KEY,
+    channel_id TEXT,
+    scoring_mode TEXT DEFAULT 'likes',
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_channel_settings_channel_id ON channel_settings (channel_id);
+
+CREATE TABLE IF NOT EXISTS post_votes (
+    id BIGSERIAL PRIMARY KEY,
+    post_id BIGINT,
+    user_id TEXT,
+    value INTEGER,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_post_votes_post_user ON post_votes (post_id, user_id);
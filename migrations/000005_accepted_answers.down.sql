@@ -0,0 +1,3 @@
+ALTER TABLE channel_settings DROP COLUMN IF EXISTS channel_mode;
+ALTER TABLE posts DROP COLUMN IF EXISTS is_accepted_answer;
+ALTER TABLE posts DROP COLUMN IF EXISTS accepted_answer_id;
@@ -0,0 +1,3 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS accepted_answer_id BIGINT DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS is_accepted_answer BOOLEAN DEFAULT false;
+ALTER TABLE channel_settings ADD COLUMN IF NOT EXISTS channel_mode TEXT DEFAULT 'discussion';
@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS polls (
+    id BIGSERIAL PRIMARY KEY,
+    post_id BIGINT,
+    multi_choice BOOLEAN DEFAULT false,
+    hide_results_until_voted BOOLEAN DEFAULT false,
+    closes_at TIMESTAMPTZ,
+    voter_count BIGINT DEFAULT 0,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_polls_post_id ON polls (post_id);
+
+CREATE TABLE IF NOT EXISTS poll_options (
+    id BIGSERIAL PRIMARY KEY,
+    post_id BIGINT,
+    position INTEGER,
+    text TEXT,
+    vote_count BIGINT DEFAULT 0,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_poll_options_post_id ON poll_options (post_id);
+
+CREATE TABLE IF NOT EXISTS poll_ballots (
+    id BIGSERIAL PRIMARY KEY,
+    post_id BIGINT,
+    user_id TEXT,
+    created_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_poll_ballots_post_user ON poll_ballots (post_id, user_id);
+
+CREATE TABLE IF NOT EXISTS poll_votes (
+    id BIGSERIAL PRIMARY KEY,
+    post_id BIGINT,
+    option_id BIGINT,
+    user_id TEXT,
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_poll_votes_post_user ON poll_votes (post_id, user_id);
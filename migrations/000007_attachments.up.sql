@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS attachments (
+    id BIGSERIAL PRIMARY KEY,
+    post_id BIGINT,
+    user_id TEXT,
+    storage_key TEXT,
+    thumbnail_key TEXT,
+    file_name TEXT,
+    content_type TEXT,
+    size BIGINT,
+    width BIGINT,
+    height BIGINT,
+    status TEXT DEFAULT 'PENDING',
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_attachments_post_id ON attachments (post_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_user_id ON attachments (user_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_user_details_user_name_lower;
+DROP TABLE IF EXISTS post_mentions;
@@ -0,0 +1,14 @@
+-- The user name index backs mention lookup and autocomplete, which match
+-- names case-insensitively.
+CREATE TABLE IF NOT EXISTS post_mentions (
+    id BIGSERIAL PRIMARY KEY,
+    post_id BIGINT,
+    user_id TEXT,
+    user_name TEXT,
+    start_offset BIGINT,
+    end_offset BIGINT,
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_post_mentions_post_id ON post_mentions (post_id);
+CREATE INDEX IF NOT EXISTS idx_post_mentions_user_id ON post_mentions (user_id);
+CREATE INDEX IF NOT EXISTS idx_user_details_user_name_lower ON user_details (LOWER(user_name) text_pattern_ops);
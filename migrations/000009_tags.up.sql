@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS post_tags (
+    id BIGSERIAL PRIMARY KEY,
+    post_id BIGINT,
+    tag TEXT,
+    channel_id TEXT,
+    created_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_post_tags_post_tag ON post_tags (post_id, tag);
+CREATE INDEX IF NOT EXISTS idx_post_tags_tag ON post_tags (tag);
+
+CREATE TABLE IF NOT EXISTS channel_tags (
+    id BIGSERIAL PRIMARY KEY,
+    channel_id TEXT,
+    tag TEXT,
+    post_count BIGINT DEFAULT 0,
+    last_used_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_channel_tags_channel_tag ON channel_tags (channel_id, tag);
@@ -0,0 +1,3 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS quoted_post_id BIGINT DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS quote_count BIGINT DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_posts_quoted_post_id ON posts (quoted_post_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_posts_root_id;
+ALTER TABLE posts DROP COLUMN IF EXISTS depth;
+ALTER TABLE posts DROP COLUMN IF EXISTS root_id;
@@ -0,0 +1,13 @@
+-- Backfills the thread position of replies written before these columns existed.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS root_id BIGINT DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS depth BIGINT DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_posts_root_id ON posts (root_id);
+
+WITH RECURSIVE thread AS (
+    SELECT id, id AS root_id, 0 AS depth FROM posts WHERE parent_id = 0
+    UNION ALL
+    SELECT p.id, t.root_id, t.depth + 1 FROM posts p JOIN thread t ON p.parent_id = t.id
+)
+UPDATE posts SET root_id = thread.root_id, depth = thread.depth
+FROM thread
+WHERE posts.id = thread.id AND thread.depth > 0;
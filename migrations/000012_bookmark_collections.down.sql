@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS bookmark_details;
+DROP TABLE IF EXISTS bookmark_collections;
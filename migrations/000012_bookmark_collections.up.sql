@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS bookmark_collections (
+    id BIGSERIAL PRIMARY KEY,
+    user_id TEXT,
+    name TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bookmark_collections_user_name ON bookmark_collections (user_id, name);
+
+CREATE TABLE IF NOT EXISTS bookmark_details (
+    id BIGSERIAL PRIMARY KEY,
+    user_id TEXT,
+    post_id BIGINT,
+    collection_id BIGINT DEFAULT 0,
+    note TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bookmark_details_user_post ON bookmark_details (user_id, post_id);
+CREATE INDEX IF NOT EXISTS idx_bookmark_details_collection_id ON bookmark_details (collection_id);
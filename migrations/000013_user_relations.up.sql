@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS user_relations (
+    id BIGSERIAL PRIMARY KEY,
+    user_id TEXT,
+    target_user_id TEXT,
+    type TEXT,
+    created_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_relations_user_target_type ON user_relations (user_id, target_user_id, type);
+CREATE INDEX IF NOT EXISTS idx_user_relations_target_user_id ON user_relations (target_user_id);
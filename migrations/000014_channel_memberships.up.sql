@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS channels (
+    id BIGSERIAL PRIMARY KEY,
+    channel_id TEXT,
+    visibility TEXT DEFAULT 'public',
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_channels_channel_id ON channels (channel_id);
+
+CREATE TABLE IF NOT EXISTS channel_members (
+    id BIGSERIAL PRIMARY KEY,
+    channel_id TEXT,
+    user_id TEXT,
+    status TEXT,
+    invited_by TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_channel_members_channel_user ON channel_members (channel_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_channel_members_user_id ON channel_members (user_id);
@@ -0,0 +1,13 @@
+UPDATE channel_settings SET channel_mode = 'discussion' WHERE channel_mode = 'ideas';
+ALTER TABLE channel_settings DROP COLUMN IF EXISTS report_threshold;
+ALTER TABLE channel_settings DROP COLUMN IF EXISTS slow_mode_seconds;
+ALTER TABLE channel_settings DROP COLUMN IF EXISTS max_post_length;
+ALTER TABLE channel_settings DROP COLUMN IF EXISTS reactions_disabled;
+ALTER TABLE channel_settings DROP COLUMN IF EXISTS replies_disabled;
+ALTER TABLE channel_settings DROP COLUMN IF EXISTS default_flow;
+
+DROP INDEX IF EXISTS idx_channels_owner_id;
+ALTER TABLE channels DROP COLUMN IF EXISTS archived;
+ALTER TABLE channels DROP COLUMN IF EXISTS owner_id;
+ALTER TABLE channels DROP COLUMN IF EXISTS description;
+ALTER TABLE channels DROP COLUMN IF EXISTS title;
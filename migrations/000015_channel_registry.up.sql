@@ -0,0 +1,18 @@
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS title TEXT;
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS description TEXT;
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS owner_id TEXT;
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS archived BOOLEAN DEFAULT false;
+CREATE INDEX IF NOT EXISTS idx_channels_owner_id ON channels (owner_id);
+
+ALTER TABLE channel_settings ADD COLUMN IF NOT EXISTS default_flow TEXT DEFAULT 'user-based';
+ALTER TABLE channel_settings ADD COLUMN IF NOT EXISTS replies_disabled BOOLEAN DEFAULT false;
+ALTER TABLE channel_settings ADD COLUMN IF NOT EXISTS reactions_disabled BOOLEAN DEFAULT false;
+ALTER TABLE channel_settings ADD COLUMN IF NOT EXISTS max_post_length INTEGER DEFAULT 0;
+ALTER TABLE channel_settings ADD COLUMN IF NOT EXISTS slow_mode_seconds INTEGER DEFAULT 0;
+ALTER TABLE channel_settings ADD COLUMN IF NOT EXISTS report_threshold INTEGER DEFAULT 0;
+
+-- The ideas channel used to be recognised by its id; it is now an ideas mode
+-- channel like any other.
+INSERT INTO channel_settings (channel_id, scoring_mode, channel_mode, default_flow, created_at, updated_at)
+VALUES ('community-ideas', 'likes', 'ideas', 'ideas-based', NOW(), NOW())
+ON CONFLICT (channel_id) DO UPDATE SET channel_mode = 'ideas', default_flow = 'ideas-based', updated_at = NOW();
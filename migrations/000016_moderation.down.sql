@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS post_flags;
+DROP TABLE IF EXISTS channel_blocked_words;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS channel_blocked_words (
+    id BIGSERIAL PRIMARY KEY,
+    channel_id TEXT,
+    word TEXT,
+    created_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_channel_blocked_words_channel_word ON channel_blocked_words (channel_id, word);
+
+CREATE TABLE IF NOT EXISTS post_flags (
+    id BIGSERIAL PRIMARY KEY,
+    post_id BIGINT,
+    channel_id TEXT,
+    user_id TEXT,
+    stage TEXT,
+    reason TEXT,
+    status TEXT DEFAULT 'OPEN',
+    reviewed_by TEXT,
+    reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_post_flags_post_id ON post_flags (post_id);
+CREATE INDEX IF NOT EXISTS idx_post_flags_channel_status ON post_flags (channel_id, status);
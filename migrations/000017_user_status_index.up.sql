@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_user_statuses_user_id ON user_statuses (user_id);
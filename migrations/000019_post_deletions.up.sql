@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS post_deletions (
+    id BIGSERIAL PRIMARY KEY,
+    post_id BIGINT,
+    channel_id TEXT,
+    content TEXT,
+    status TEXT,
+    deleted_by TEXT,
+    deleted_by_role TEXT,
+    restored_by TEXT,
+    restored_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_post_deletions_post_id ON post_deletions (post_id);
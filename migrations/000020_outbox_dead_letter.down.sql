@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS outbox_events DROP COLUMN IF EXISTS failed_at;
+ALTER TABLE IF EXISTS outbox_events DROP COLUMN IF EXISTS claimed_until;
@@ -0,0 +1,3 @@
+-- outbox_events itself has no migration and only exists once AutoMigrate has run.
+ALTER TABLE IF EXISTS outbox_events ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMPTZ;
+ALTER TABLE IF EXISTS outbox_events ADD COLUMN IF NOT EXISTS failed_at TIMESTAMPTZ;
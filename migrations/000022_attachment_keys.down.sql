@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_attachments_thumbnail_key;
+DROP INDEX IF EXISTS idx_attachments_storage_key;
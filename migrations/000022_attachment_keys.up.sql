@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS idx_attachments_storage_key ON attachments (storage_key);
+CREATE INDEX IF NOT EXISTS idx_attachments_thumbnail_key ON attachments (thumbnail_key);
@@ -0,0 +1,2 @@
+ALTER TABLE post_votes DROP COLUMN IF EXISTS counted;
+ALTER TABLE IF EXISTS user_actions DROP COLUMN IF EXISTS counted;
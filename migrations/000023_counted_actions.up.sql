@@ -0,0 +1,3 @@
+-- user_actions has no migration and only exists once AutoMigrate has run.
+ALTER TABLE IF EXISTS user_actions ADD COLUMN IF NOT EXISTS counted BOOLEAN DEFAULT true;
+ALTER TABLE post_votes ADD COLUMN IF NOT EXISTS counted BOOLEAN DEFAULT true;
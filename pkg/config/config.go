@@ -24,8 +24,89 @@ type RedisConfig struct {
 type Logger struct {
 	Filename string
 }
+// Server configures the HTTP server. TrustedProxies lists the IPs and CIDR
+// ranges of the proxies whose forwarding headers give the client IP; when it
+// is empty the IP the request came from is used.
 type Server struct {
-	Port int
+	Port           int
+	TrustedProxies []string
+}
+
+type Outbox struct {
+	Publisher     string
+	StreamName    string
+	StreamMaxLen  int64
+	BatchSize     int
+	RelayInterval time.Duration
+	MaxAttempts   int
+}
+
+type Trending struct {
+	Window          time.Duration
+	HalfLife        time.Duration
+	RefreshInterval time.Duration
+}
+
+// ObjectStore configures where attachments are kept. Driver is "local" or
+// "s3"; the S3 settings also work with a local S3 compatible stand-in.
+type ObjectStore struct {
+	Driver          string
+	LocalDir        string
+	PublicURL       string
+	UploadURL       string
+	SigningSecret   string
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKey       string
+	SecretKey       string
+	UploadURLExpiry time.Duration
+	MaxUploadSize   int64
+}
+
+// Threads configures nested replies. MaxDepth is how deep a reply can sit
+// below its top level comment, direct replies being at depth 1.
+type Threads struct {
+	MaxDepth int
+}
+
+// RouteRateLimit lets a client make Limit requests per Window to a route
+// group, in bursts of up to Limit.
+type RouteRateLimit struct {
+	Limit  int
+	Window time.Duration
+}
+
+// RateLimit configures how fast users can post and call the API. A user can
+// make PostLimit posts per PostWindow across all channels; a negative
+// PostLimit turns the limit off. Slow mode is set per channel, and channel
+// moderators are exempt from both.
+//
+// Routes limits the "public" and "private" route groups; a group left out
+// isn't limited. APIKeys are the keys clients can send in x-api-key to be
+// counted per key; any other key is ignored. AllowList and DenyList hold API
+// keys, user ids, IPs and CIDR ranges: allowed clients are never limited and
+// denied ones are refused.
+type RateLimit struct {
+	PostLimit  int
+	PostWindow time.Duration
+	Routes     map[string]RouteRateLimit
+	APIKeys    []string
+	AllowList  []string
+	DenyList   []string
+}
+
+// Moderation configures the checks new posts go through. MaxLength caps every
+// post, BlockedWords are blocked in all channels on top of each channel's own
+// list, MaxLinks is how many links a post can carry and RepeatWindow is how
+// long a user can't post the same content again. RestoreWindow is how long
+// after a delete moderators can still restore a post.
+type Moderation struct {
+	MaxLength     int
+	BlockedWords  []string
+	MaxLinks      int
+	RepeatWindow  time.Duration
+	RestoreWindow time.Duration
 }
 
 var Config = &struct {
@@ -39,6 +120,12 @@ var Config = &struct {
 	Logger                   Logger
 	RedisConfig              RedisConfig
 	UserInfoDelay time.Duration
+	Outbox                   Outbox
+	Trending                 Trending
+	ObjectStore              ObjectStore
+	Threads                  Threads
+	RateLimit                RateLimit
+	Moderation               Moderation
 }{}
 
 func Initialize() error {
@@ -16,6 +16,20 @@ const (
 	SortDirectionDESC = "DESC"
 )
 
+// auditLogAppendOnly makes audit_logs append-only. It is the same trigger as
+// migration 000018 so that a database set up by AutoMigrate alone gets it too.
+var auditLogAppendOnly = []string{
+	`CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql`,
+	`DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs`,
+	`CREATE TRIGGER audit_logs_append_only
+    BEFORE UPDATE OR DELETE ON audit_logs
+    FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only()`,
+}
+
 type Store struct {
 	MasterDB *gorm.DB
 	SlaveDB  *gorm.DB
@@ -35,10 +49,44 @@ func NewPostgresStorage() (*Store, error) {
 		model.Forum{},
 		model.ForumEventLink{},
 		model.Reports{},
+		model.OutboxEvent{},
+		model.ChannelModerator{},
+		model.Notification{},
+		model.PostMerge{},
+		model.ChannelSetting{},
+		model.PostVote{},
+		model.Poll{},
+		model.PollOption{},
+		model.PollBallot{},
+		model.PollVote{},
+		model.Attachment{},
+		model.PostMention{},
+		model.PostTag{},
+		model.ChannelTag{},
+		model.BookmarkCollection{},
+		model.BookmarkDetail{},
+		model.UserRelation{},
+		model.Channel{},
+		model.ChannelMember{},
+		model.ChannelBlockedWord{},
+		model.PostFlag{},
+		model.AuditLog{},
+		model.PostDeletion{},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("auto migrate: %w", err)
 	}
+	err = s.MasterDB.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range auditLogAppendOnly {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("audit log trigger: %w", err)
+	}
 	fmt.Println("Connected to db")
 	return s, nil
 }
@@ -18,33 +18,64 @@ type UserDetails struct {
 
 type Post struct {
 	ID            int64     `gorm:"primary_key;column:id;autoIncrement;"`
-	ChannelID     string    `gorm:"column:channel_id"`
+	ChannelID     string    `gorm:"column:channel_id;index:idx_posts_channel_created,priority:1;index:idx_posts_channel_likes,priority:1;index:idx_posts_channel_hot,priority:1;index:idx_posts_channel_controversy,priority:1;index:idx_posts_channel_vote_score,priority:1"`
 	UserID        string    `gorm:"column:user_id"`
 	Content       string    `gorm:"column:content"`
 	Type          string    `gorm:"column:type"`
 	ParentID      int64     `gorm:"column:parent_id"`
-	LikeCount     int64     `gorm:"column:like_count"`
+	LikeCount     int64     `gorm:"column:like_count;index:idx_posts_channel_likes,priority:2,sort:desc"`
 	BookMarkCount int64     `gorm:"column:bookmark_count"`
 	IsPinned      bool      `gorm:"column:is_pinned"`
 	Status        string    `gorm:"column:status"`
-	CreatedAt     time.Time `gorm:"column:created_at"`
+	CreatedAt     time.Time `gorm:"column:created_at;index:idx_posts_channel_created,priority:2,sort:desc"`
 	UpdatedAt     time.Time `gorm:"column:updated_at"`
 	DeletedAt     time.Time `gorm:"column:deleted_at;default:NULL"`
+	// counters and scores backing the newest/top/hot/controversial feed orders
+	ReplyCount       int64   `gorm:"column:reply_count;default:0"`
+	ReportCount      int64   `gorm:"column:report_count;default:0"`
+	HotScore         float64 `gorm:"column:hot_score;default:0;index:idx_posts_channel_hot,priority:2,sort:desc"`
+	ControversyScore float64 `gorm:"column:controversy_score;default:0;index:idx_posts_channel_controversy,priority:2,sort:desc"`
+	// ideas board state, only set on comments in the ideas channel
+	IdeaStatus          string     `gorm:"column:idea_status;index"`
+	OfficialResponse    string     `gorm:"column:official_response"`
+	OfficialResponseBy  string     `gorm:"column:official_response_by"`
+	IdeaStatusUpdatedAt *time.Time `gorm:"column:idea_status_updated_at"`
+	// MergedIntoID points a merged duplicate at the post it was merged into
+	MergedIntoID int64 `gorm:"column:merged_into_id;default:0"`
+	// net up/down vote score, used instead of likes in channels scoring by votes
+	VoteScore     int64 `gorm:"column:vote_score;default:0;index:idx_posts_channel_vote_score,priority:2,sort:desc"`
+	UpvoteCount   int64 `gorm:"column:upvote_count;default:0"`
+	DownvoteCount int64 `gorm:"column:downvote_count;default:0"`
+	// Q&A state: a question points at its accepted reply and that reply is flagged
+	AcceptedAnswerID int64 `gorm:"column:accepted_answer_id;default:0"`
+	IsAcceptedAnswer bool  `gorm:"column:is_accepted_answer;default:false"`
+	// QuotedPostID points a quote at the post it quotes, QuoteCount counts the live quotes of this post
+	QuotedPostID int64 `gorm:"column:quoted_post_id;default:0;index"`
+	QuoteCount   int64 `gorm:"column:quote_count;default:0"`
+	// thread position: RootID is the top level comment a reply belongs to (0 on comments), Depth how far below it the reply is
+	RootID int64 `gorm:"column:root_id;default:0;index"`
+	Depth  int   `gorm:"column:depth;default:0"`
+	// SearchVector is generated by postgres from content, so it stays in sync on insert and edit
+	SearchVector string `gorm:"column:search_vector;type:tsvector GENERATED ALWAYS AS (to_tsvector('english', coalesce(content, ''))) STORED;index:idx_posts_search_vector,type:gin;->"`
 }
 
+// UserActions is a user's like or bookmark of a post. Counted is false when
+// the action was taken while the user was shadow banned and so isn't in the
+// post's counters.
 type UserActions struct {
 	ID        int64     `gorm:"primary_key;column:id;autoIncrement"`
 	UserID    string    `gorm:"column:user_id"`
 	PostID    int64     `gorm:"column:post_id"`
 	Action    string    `gorm:"column:action"`
 	Value     *bool     `gorm:"column:value"`
+	Counted   *bool     `gorm:"column:counted;default:true"`
 	CreatedAt time.Time `gorm:"column:created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at"`
 }
 
 type UserStatus struct {
 	ID            int64     `gorm:"primary_key;column:id;autoIncrement"`
-	UserID        string    `gorm:"column:user_id"`
+	UserID        string    `gorm:"column:user_id;index"`
 	WarningsCount int64     `gorm:"column:warnings_count"`
 	Status        string    `gorm:"column:status"`
 	BlockedUntil  time.Time `gorm:"column:blocked_until"`
@@ -85,3 +116,299 @@ type ForumEventLink struct {
 	ForumID   int64  `gorm:"column:forum_id"`
 	ChannelID string `gorm:"column:channel_id"`
 }
+
+type OutboxEvent struct {
+	ID            int64      `gorm:"primary_key;column:id;autoIncrement"`
+	EventID       string     `gorm:"column:event_id;uniqueIndex"`
+	EventType     string     `gorm:"column:event_type"`
+	AggregateType string     `gorm:"column:aggregate_type"`
+	AggregateID   string     `gorm:"column:aggregate_id"`
+	Payload       string     `gorm:"column:payload;type:jsonb"`
+	Attempts      int        `gorm:"column:attempts"`
+	LastError     string     `gorm:"column:last_error"`
+	PublishedAt   *time.Time `gorm:"column:published_at;index"`
+	// ClaimedUntil keeps a batch being published from other relays and
+	// FailedAt parks an event that ran out of attempts.
+	ClaimedUntil *time.Time `gorm:"column:claimed_until"`
+	FailedAt     *time.Time `gorm:"column:failed_at"`
+	CreatedAt    time.Time  `gorm:"column:created_at"`
+	UpdatedAt    time.Time  `gorm:"column:updated_at"`
+}
+
+type ChannelModerator struct {
+	ID        int64     `gorm:"primary_key;column:id;autoIncrement"`
+	ChannelID string    `gorm:"column:channel_id;uniqueIndex:idx_channel_moderators_channel_user,priority:1"`
+	UserID    string    `gorm:"column:user_id;uniqueIndex:idx_channel_moderators_channel_user,priority:2"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+type Notification struct {
+	ID        int64      `gorm:"primary_key;column:id;autoIncrement"`
+	UserID    string     `gorm:"column:user_id;index:idx_notifications_user_id,priority:1"`
+	Type      string     `gorm:"column:type"`
+	ChannelID string     `gorm:"column:channel_id"`
+	PostID    int64      `gorm:"column:post_id"`
+	ActorID   string     `gorm:"column:actor_id"`
+	Message   string     `gorm:"column:message"`
+	ReadAt    *time.Time `gorm:"column:read_at"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+	UpdatedAt time.Time  `gorm:"column:updated_at"`
+}
+
+// PostMerge is the audit record of a duplicate post merged into a canonical
+// one. It keeps everything needed to reverse the merge.
+type PostMerge struct {
+	ID            int64      `gorm:"primary_key;column:id;autoIncrement"`
+	SourcePostID  int64      `gorm:"column:source_post_id;index"`
+	TargetPostID  int64      `gorm:"column:target_post_id;index"`
+	MergedBy      string     `gorm:"column:merged_by"`
+	SourceContent string     `gorm:"column:source_content"`
+	SourceStatus  string     `gorm:"column:source_status"`
+	MovedLikes    string     `gorm:"column:moved_likes;type:jsonb"`
+	MovedReplyIDs string     `gorm:"column:moved_reply_ids;type:jsonb"`
+	RevertedBy    string     `gorm:"column:reverted_by"`
+	RevertedAt    *time.Time `gorm:"column:reverted_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at"`
+}
+
+// ChannelSetting configures how a channel behaves. Replies and reactions are
+// stored as disabled flags so that the zero value keeps them on. A zero
+// MaxPostLength, SlowModeSeconds or ReportThreshold turns the limit off.
+type ChannelSetting struct {
+	ID                int64     `gorm:"primary_key;column:id;autoIncrement"`
+	ChannelID         string    `gorm:"column:channel_id;uniqueIndex"`
+	ScoringMode       string    `gorm:"column:scoring_mode;default:likes"`
+	ChannelMode       string    `gorm:"column:channel_mode;default:discussion"`
+	DefaultFlow       string    `gorm:"column:default_flow;default:user-based"`
+	RepliesDisabled   bool      `gorm:"column:replies_disabled;default:false"`
+	ReactionsDisabled bool      `gorm:"column:reactions_disabled;default:false"`
+	MaxPostLength     int       `gorm:"column:max_post_length;default:0"`
+	SlowModeSeconds   int       `gorm:"column:slow_mode_seconds;default:0"`
+	ReportThreshold   int       `gorm:"column:report_threshold;default:0"`
+	CreatedAt         time.Time `gorm:"column:created_at"`
+	UpdatedAt         time.Time `gorm:"column:updated_at"`
+}
+
+// PostVote is a user's vote on a post in a channel scoring by votes. Value is
+// 1 for an upvote and -1 for a downvote; withdrawn votes are deleted. Counted
+// is false for votes cast while the user was shadow banned, which aren't in
+// the post's counters.
+type PostVote struct {
+	ID        int64     `gorm:"primary_key;column:id;autoIncrement"`
+	PostID    int64     `gorm:"column:post_id;uniqueIndex:idx_post_votes_post_user,priority:1"`
+	UserID    string    `gorm:"column:user_id;uniqueIndex:idx_post_votes_post_user,priority:2"`
+	Value     int       `gorm:"column:value"`
+	Counted   *bool     `gorm:"column:counted;default:true"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+// Poll holds the settings of a post of type POLL. Tallies live on the options
+// and VoterCount, updated in the same transaction as each ballot.
+type Poll struct {
+	ID                    int64      `gorm:"primary_key;column:id;autoIncrement"`
+	PostID                int64      `gorm:"column:post_id;uniqueIndex"`
+	MultiChoice           bool       `gorm:"column:multi_choice;default:false"`
+	HideResultsUntilVoted bool       `gorm:"column:hide_results_until_voted;default:false"`
+	ClosesAt              *time.Time `gorm:"column:closes_at"`
+	VoterCount            int64      `gorm:"column:voter_count;default:0"`
+	CreatedAt             time.Time  `gorm:"column:created_at"`
+	UpdatedAt             time.Time  `gorm:"column:updated_at"`
+}
+
+type PollOption struct {
+	ID        int64     `gorm:"primary_key;column:id;autoIncrement"`
+	PostID    int64     `gorm:"column:post_id;index"`
+	Position  int       `gorm:"column:position"`
+	Text      string    `gorm:"column:text"`
+	VoteCount int64     `gorm:"column:vote_count;default:0"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+// PollBallot records that a user voted on a poll; its unique index is what
+// limits every user to a single ballot per poll.
+type PollBallot struct {
+	ID        int64     `gorm:"primary_key;column:id;autoIncrement"`
+	PostID    int64     `gorm:"column:post_id;uniqueIndex:idx_poll_ballots_post_user,priority:1"`
+	UserID    string    `gorm:"column:user_id;uniqueIndex:idx_poll_ballots_post_user,priority:2"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+// PollVote is one option picked on a ballot; multi-choice ballots have several.
+type PollVote struct {
+	ID        int64     `gorm:"primary_key;column:id;autoIncrement"`
+	PostID    int64     `gorm:"column:post_id;index:idx_poll_votes_post_user,priority:1"`
+	OptionID  int64     `gorm:"column:option_id"`
+	UserID    string    `gorm:"column:user_id;index:idx_poll_votes_post_user,priority:2"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+// Attachment is a file uploaded to the object store. It is PENDING until its
+// content has been stored and inspected, and stays unlinked (PostID 0) until
+// the post that carries it is created.
+type Attachment struct {
+	ID           int64     `gorm:"primary_key;column:id;autoIncrement"`
+	PostID       int64     `gorm:"column:post_id;index"`
+	UserID       string    `gorm:"column:user_id;index"`
+	StorageKey   string    `gorm:"column:storage_key;index"`
+	ThumbnailKey string    `gorm:"column:thumbnail_key;index"`
+	FileName     string    `gorm:"column:file_name"`
+	ContentType  string    `gorm:"column:content_type"`
+	Size         int64     `gorm:"column:size"`
+	Width        int       `gorm:"column:width"`
+	Height       int       `gorm:"column:height"`
+	Status       string    `gorm:"column:status;default:PENDING"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+	UpdatedAt    time.Time `gorm:"column:updated_at"`
+}
+
+// PostMention is an @username in a post resolved to a user. StartOffset and
+// EndOffset locate it in the content in characters.
+type PostMention struct {
+	ID          int64     `gorm:"primary_key;column:id;autoIncrement"`
+	PostID      int64     `gorm:"column:post_id;index"`
+	UserID      string    `gorm:"column:user_id;index"`
+	UserName    string    `gorm:"column:user_name"`
+	StartOffset int       `gorm:"column:start_offset"`
+	EndOffset   int       `gorm:"column:end_offset"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+}
+
+// PostTag is a #hashtag used in a post.
+type PostTag struct {
+	ID        int64     `gorm:"primary_key;column:id;autoIncrement"`
+	PostID    int64     `gorm:"column:post_id;uniqueIndex:idx_post_tags_post_tag,priority:1"`
+	Tag       string    `gorm:"column:tag;uniqueIndex:idx_post_tags_post_tag,priority:2;index:idx_post_tags_tag"`
+	ChannelID string    `gorm:"column:channel_id"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+// ChannelTag counts the live posts of a channel using a tag. It is kept in
+// step with PostTag when posts are created, edited and deleted.
+type ChannelTag struct {
+	ID         int64     `gorm:"primary_key;column:id;autoIncrement"`
+	ChannelID  string    `gorm:"column:channel_id;uniqueIndex:idx_channel_tags_channel_tag,priority:1"`
+	Tag        string    `gorm:"column:tag;uniqueIndex:idx_channel_tags_channel_tag,priority:2"`
+	PostCount  int64     `gorm:"column:post_count;default:0"`
+	LastUsedAt time.Time `gorm:"column:last_used_at"`
+}
+
+// BookmarkCollection is a named list a user files bookmarks into.
+type BookmarkCollection struct {
+	ID        int64     `gorm:"primary_key;column:id;autoIncrement"`
+	UserID    string    `gorm:"column:user_id;uniqueIndex:idx_bookmark_collections_user_name,priority:1"`
+	Name      string    `gorm:"column:name;uniqueIndex:idx_bookmark_collections_user_name,priority:2"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+// BookmarkDetail files a bookmark into a collection and keeps the user's
+// private note on it. The bookmark itself stays a user action.
+type BookmarkDetail struct {
+	ID           int64     `gorm:"primary_key;column:id;autoIncrement"`
+	UserID       string    `gorm:"column:user_id;uniqueIndex:idx_bookmark_details_user_post,priority:1"`
+	PostID       int64     `gorm:"column:post_id;uniqueIndex:idx_bookmark_details_user_post,priority:2"`
+	CollectionID int64     `gorm:"column:collection_id;default:0;index"`
+	Note         string    `gorm:"column:note"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+	UpdatedAt    time.Time `gorm:"column:updated_at"`
+}
+
+// UserRelation is a block or a mute of TargetUserID by UserID. Both hide the
+// target's posts from the user; a block also keeps the target from replying
+// to, mentioning or reacting to the user's posts.
+type UserRelation struct {
+	ID           int64     `gorm:"primary_key;column:id;autoIncrement"`
+	UserID       string    `gorm:"column:user_id;uniqueIndex:idx_user_relations_user_target_type,priority:1"`
+	TargetUserID string    `gorm:"column:target_user_id;uniqueIndex:idx_user_relations_user_target_type,priority:2;index"`
+	Type         string    `gorm:"column:type;uniqueIndex:idx_user_relations_user_target_type,priority:3"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+}
+
+// Channel registers a channel with its details and visibility. Channels
+// without a row are public and open. Archived channels are read only.
+type Channel struct {
+	ID          int64     `gorm:"primary_key;column:id;autoIncrement"`
+	ChannelID   string    `gorm:"column:channel_id;uniqueIndex"`
+	Title       string    `gorm:"column:title"`
+	Description string    `gorm:"column:description"`
+	OwnerID     string    `gorm:"column:owner_id;index"`
+	Visibility  string    `gorm:"column:visibility;default:public"`
+	Archived    bool      `gorm:"column:archived;default:false"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+}
+
+// ChannelMember is a user's membership of a channel. Invited users become
+// active members once they join.
+type ChannelMember struct {
+	ID        int64     `gorm:"primary_key;column:id;autoIncrement"`
+	ChannelID string    `gorm:"column:channel_id;uniqueIndex:idx_channel_members_channel_user,priority:1"`
+	UserID    string    `gorm:"column:user_id;uniqueIndex:idx_channel_members_channel_user,priority:2;index"`
+	Status    string    `gorm:"column:status"`
+	InvitedBy string    `gorm:"column:invited_by"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+// ChannelBlockedWord is a word or phrase posts in a channel can't use. Words
+// are stored normalized, in lower case with single spaces.
+type ChannelBlockedWord struct {
+	ID        int64     `gorm:"primary_key;column:id;autoIncrement"`
+	ChannelID string    `gorm:"column:channel_id;uniqueIndex:idx_channel_blocked_words_channel_word,priority:1"`
+	Word      string    `gorm:"column:word;uniqueIndex:idx_channel_blocked_words_channel_word,priority:2"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+// PostFlag is a post the moderation pipeline flagged for review. It stays
+// OPEN until a moderator approves the post or removes it.
+type PostFlag struct {
+	ID         int64      `gorm:"primary_key;column:id;autoIncrement"`
+	PostID     int64      `gorm:"column:post_id;index"`
+	ChannelID  string     `gorm:"column:channel_id;index:idx_post_flags_channel_status,priority:1"`
+	UserID     string     `gorm:"column:user_id"`
+	Stage      string     `gorm:"column:stage"`
+	Reason     string     `gorm:"column:reason"`
+	Status     string     `gorm:"column:status;default:OPEN;index:idx_post_flags_channel_status,priority:2"`
+	ReviewedBy string     `gorm:"column:reviewed_by"`
+	ReviewedAt *time.Time `gorm:"column:reviewed_at"`
+	CreatedAt  time.Time  `gorm:"column:created_at"`
+	UpdatedAt  time.Time  `gorm:"column:updated_at"`
+}
+
+// AuditLog records a moderation action: who took it, on what, the state of
+// the target before and after and why. Rows are only ever inserted.
+type AuditLog struct {
+	ID         int64     `gorm:"primary_key;column:id;autoIncrement"`
+	ActorID    string    `gorm:"column:actor_id;index"`
+	ActorRole  string    `gorm:"column:actor_role"`
+	Action     string    `gorm:"column:action;index"`
+	TargetType string    `gorm:"column:target_type;index:idx_audit_logs_target,priority:1"`
+	TargetID   string    `gorm:"column:target_id;index:idx_audit_logs_target,priority:2"`
+	ChannelID  string    `gorm:"column:channel_id;index"`
+	BeforeData string    `gorm:"column:before_data;type:jsonb"`
+	AfterData  string    `gorm:"column:after_data;type:jsonb"`
+	Reason     string    `gorm:"column:reason"`
+	RequestID  string    `gorm:"column:request_id"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+}
+
+// PostDeletion keeps what a deleted post said and its status before the
+// delete, so a moderator can restore it. Only the placeholder is left in the
+// post itself.
+type PostDeletion struct {
+	ID            int64      `gorm:"primary_key;column:id;autoIncrement"`
+	PostID        int64      `gorm:"column:post_id;index"`
+	ChannelID     string     `gorm:"column:channel_id"`
+	Content       string     `gorm:"column:content"`
+	Status        string     `gorm:"column:status"`
+	DeletedBy     string     `gorm:"column:deleted_by"`
+	DeletedByRole string     `gorm:"column:deleted_by_role"`
+	RestoredBy    string     `gorm:"column:restored_by"`
+	RestoredAt    *time.Time `gorm:"column:restored_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at"`
+}
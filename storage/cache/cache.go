@@ -43,6 +43,9 @@ type CacheBase interface {
 	ZRange(ctx context.Context, key string, start, stop int64) ([]string, error)
 	ZRangeByScore(ctx context.Context, key string, opt *redis.ZRangeBy) ([]string, error)
 	ZRemRangeByScore(ctx context.Context, key, min, max string) (int64, error)
+	ZIncrBy(ctx context.Context, key string, increment float64, member string) (float64, error)
+	ZUnionStore(ctx context.Context, destination string, keys []string, weights []float64) (int64, error)
+	ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) ([]redis.Z, error)
 	//Keys will return matching pattern ex: [key:one key:three key:two] , try to avoid using this request may be expensive
 	Keys(ctx context.Context, keyPattern string) ([]string, error)
 	SetNXExpiringKey(ctx context.Context, key, value string, expiration time.Duration) error
@@ -51,4 +54,5 @@ type CacheBase interface {
 	FunctionCall(ctx context.Context, functionName string, keys []string, args ...interface{}) (*redis.Cmd, error)
 	FunctionReload(ctx context.Context, libName string, script string) (*redis.StringCmd, error)
 	KeyExists(ctx context.Context, key string) (exists bool, err error) 
+	XAdd(ctx context.Context, stream string, maxLen int64, values map[string]interface{}) (id string, err error)
 }
@@ -371,6 +371,41 @@ func (c *RedisClient) ZRemRangeByScore(ctx context.Context, key, min, max string
 	return result.Val(), nil
 }
 
+func (c *RedisClient) ZIncrBy(ctx context.Context, key string, increment float64, member string) (float64, error) {
+	result := c.Client.ZIncrBy(ctx, key, increment, member)
+	if result.Err() != nil {
+		err := fmt.Errorf("Redis ZIncrBy error: " + result.Err().Error())
+		return 0, err
+	}
+
+	return result.Val(), nil
+}
+
+// ZUnionStore sums the given sorted sets into destination, multiplying the
+// scores of each input by the weight at the same index
+func (c *RedisClient) ZUnionStore(ctx context.Context, destination string, keys []string, weights []float64) (int64, error) {
+	result := c.Client.ZUnionStore(ctx, destination, &redis.ZStore{
+		Keys:    keys,
+		Weights: weights,
+	})
+	if result.Err() != nil {
+		err := fmt.Errorf("Redis ZUnionStore error: " + result.Err().Error())
+		return 0, err
+	}
+
+	return result.Val(), nil
+}
+
+func (c *RedisClient) ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) ([]redis.Z, error) {
+	result := c.Client.ZRevRangeWithScores(ctx, key, start, stop)
+	if result.Err() != nil {
+		err := fmt.Errorf("Redis ZRevRangeWithScores error: " + result.Err().Error())
+		return nil, err
+	}
+
+	return result.Val(), nil
+}
+
 // Keys will return matching pattern ex: [key:one key:three key:two] , try to avoid using this request may be expensive
 func (c *RedisClient) Keys(ctx context.Context, keyPattern string) (keys []string, err error) {
 	pattern := strings.TrimSpace(keyPattern)
@@ -404,7 +439,7 @@ func (c *RedisClient) FunctionLoad(ctx context.Context, script string) (*redis.S
 }
 
 func (c *RedisClient) FunctionCall(ctx context.Context, functionName string, keys []string, args ...interface{}) (*redis.Cmd, error) {
-	loadCmd := c.Client.FCall(ctx, functionName, keys, args)
+	loadCmd := c.Client.FCall(ctx, functionName, keys, args...)
 	if loadCmd.Err() != nil {
 		return nil, loadCmd.Err()
 	}
@@ -432,3 +467,20 @@ func (c *RedisClient) KeyExists(ctx context.Context, key string) (exists bool, e
 	exists = count > 0
 	return
 }
+
+// XAdd appends an entry to a stream, trimming it to roughly maxLen entries when maxLen is positive
+func (c *RedisClient) XAdd(ctx context.Context, stream string, maxLen int64, values map[string]interface{}) (id string, err error) {
+	args := &redis.XAddArgs{
+		Stream: stream,
+		Values: values,
+	}
+	if maxLen > 0 {
+		args.MaxLen = maxLen
+		args.Approx = true
+	}
+	id, err = c.Client.XAdd(ctx, args).Result()
+	if err != nil {
+		err = fmt.Errorf("redis xadd error: %v", err)
+	}
+	return
+}
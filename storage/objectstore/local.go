@@ -0,0 +1,142 @@
+package objectstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalStore keeps objects on the local filesystem. Its signed upload URLs
+// point at the service, which checks them with VerifyUpload before storing
+// the body.
+type LocalStore struct {
+	dir       string
+	publicURL string
+	uploadURL string
+	secret    []byte
+	expiry    time.Duration
+}
+
+func NewLocalStore(options Options) (*LocalStore, error) {
+	if options.LocalDir == "" {
+		return nil, errors.New("local object store needs a directory")
+	}
+	if err := os.MkdirAll(options.LocalDir, 0o755); err != nil {
+		return nil, fmt.Errorf("create object store directory %s: %w", options.LocalDir, err)
+	}
+	return &LocalStore{
+		dir:       options.LocalDir,
+		publicURL: strings.TrimRight(options.PublicURL, "/"),
+		uploadURL: options.UploadURL,
+		secret:    []byte(options.SigningSecret),
+		expiry:    options.UploadURLExpiry,
+	}, nil
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(cleaned)), nil
+}
+
+// Put writes the object to a temporary file first so readers never see a
+// partially written object.
+func (s *LocalStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("create directory for %s: %w", key, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("create temp file for %s: %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write %s: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", key, err)
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return fmt.Errorf("move %s into place: %w", key, err)
+	}
+	return nil
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(target)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", key, err)
+	}
+	return file, nil
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("delete %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *LocalStore) URL(key string) string {
+	return s.publicURL + "/" + key
+}
+
+func (s *LocalStore) SignedUploadURL(ctx context.Context, key string, contentType string) (string, time.Time, error) {
+	if _, err := cleanKey(key); err != nil {
+		return "", time.Time{}, err
+	}
+	if len(s.secret) == 0 || s.uploadURL == "" {
+		return "", time.Time{}, errors.New("local object store isn't configured for signed uploads")
+	}
+	expiresAt := time.Now().Add(s.expiry)
+	query := url.Values{}
+	query.Set("key", key)
+	query.Set("content_type", contentType)
+	query.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	query.Set("signature", s.sign(key, contentType, expiresAt.Unix()))
+	return s.uploadURL + "?" + query.Encode(), expiresAt, nil
+}
+
+func (s *LocalStore) VerifyUpload(key string, contentType string, expires int64, signature string) error {
+	if len(s.secret) == 0 || time.Now().Unix() > expires {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.sign(key, contentType, expires))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *LocalStore) sign(key string, contentType string, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%s\n%s\n%d", key, contentType, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
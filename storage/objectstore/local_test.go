@@ -0,0 +1,74 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func newTestLocalStore(t *testing.T, secret string) *LocalStore {
+	t.Helper()
+	store, err := NewLocalStore(Options{
+		LocalDir:        t.TempDir(),
+		UploadURL:       "/upload",
+		SigningSecret:   secret,
+		UploadURLExpiry: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+	return store
+}
+
+func TestLocalStoreVerifyUpload(t *testing.T) {
+	store := newTestLocalStore(t, "secret")
+	const key, contentType = "attachments/abc/photo.png", "image/png"
+	signedURL, _, err := store.SignedUploadURL(context.Background(), key, contentType)
+	if err != nil {
+		t.Fatalf("SignedUploadURL() error = %v", err)
+	}
+	parsed, err := url.Parse(signedURL)
+	if err != nil {
+		t.Fatalf("url.Parse(%q) error = %v", signedURL, err)
+	}
+	query := parsed.Query()
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil {
+		t.Fatalf("expires = %q, err = %v", query.Get("expires"), err)
+	}
+	signature := query.Get("signature")
+	past := time.Now().Add(-time.Second).Unix()
+
+	tests := []struct {
+		name        string
+		store       *LocalStore
+		key         string
+		contentType string
+		expires     int64
+		signature   string
+		wantErr     bool
+	}{
+		{name: "valid", store: store, key: key, contentType: contentType, expires: expires, signature: signature},
+		{name: "other key", store: store, key: "attachments/abc/other.png", contentType: contentType, expires: expires, signature: signature, wantErr: true},
+		{name: "other content type", store: store, key: key, contentType: "text/html", expires: expires, signature: signature, wantErr: true},
+		{name: "extended expiry", store: store, key: key, contentType: contentType, expires: expires + 3600, signature: signature, wantErr: true},
+		{name: "expired", store: store, key: key, contentType: contentType, expires: past, signature: store.sign(key, contentType, past), wantErr: true},
+		{name: "empty signature", store: store, key: key, contentType: contentType, expires: expires, wantErr: true},
+		{name: "other secret", store: newTestLocalStore(t, "other"), key: key, contentType: contentType, expires: expires, signature: signature, wantErr: true},
+		{name: "no secret", store: newTestLocalStore(t, ""), key: key, contentType: contentType, expires: expires, signature: signature, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.store.VerifyUpload(tt.key, tt.contentType, tt.expires, tt.signature)
+			if tt.wantErr && !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("VerifyUpload() error = %v, want %v", err, ErrInvalidSignature)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("VerifyUpload() error = %v, want nil", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+
+	DefaultUploadURLExpiry = 15 * time.Minute
+	DefaultLocalDir        = "data/objects"
+)
+
+var (
+	ErrNotFound         = errors.New("object not found")
+	ErrInvalidKey       = errors.New("invalid object key")
+	ErrInvalidSignature = errors.New("invalid or expired upload signature")
+)
+
+// ObjectStore keeps the files uploaded to the service. Keys are slash
+// separated relative paths such as "attachments/<id>/photo.jpg".
+type ObjectStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// URL is where clients can download the object from.
+	URL(key string) string
+	// SignedUploadURL lets a client PUT the object directly without going
+	// through the service's own upload endpoint.
+	SignedUploadURL(ctx context.Context, key string, contentType string) (string, time.Time, error)
+}
+
+// UploadVerifier is implemented by stores whose signed upload URLs point back
+// at this service, which then has to check the signature itself.
+type UploadVerifier interface {
+	VerifyUpload(key string, contentType string, expires int64, signature string) error
+}
+
+type Options struct {
+	// LocalDir is the root directory of the local driver.
+	LocalDir string
+	// PublicURL is the base URL objects are downloaded from. For the local
+	// driver it points at the service's file endpoint.
+	PublicURL string
+	// UploadURL is the service's signed upload endpoint, local driver only.
+	UploadURL       string
+	SigningSecret   string
+	UploadURLExpiry time.Duration
+
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+func New(driver string, options Options) (ObjectStore, error) {
+	if options.UploadURLExpiry <= 0 {
+		options.UploadURLExpiry = DefaultUploadURLExpiry
+	}
+	switch strings.ToLower(driver) {
+	case "", DriverLocal:
+		if options.LocalDir == "" {
+			options.LocalDir = DefaultLocalDir
+		}
+		return NewLocalStore(options)
+	case DriverS3:
+		return NewS3Store(options)
+	}
+	return nil, fmt.Errorf("unknown object store driver: %s", driver)
+}
+
+// cleanKey rejects keys that are empty, absolute or would escape the store.
+func cleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+	cleaned := path.Clean(key)
+	if cleaned != key || cleaned == "." || strings.HasPrefix(cleaned, "../") || cleaned == ".." {
+		return "", ErrInvalidKey
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,41 @@
+package objectstore
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCleanKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{key: "attachments/abc/photo.png", want: "attachments/abc/photo.png"},
+		{key: "photo.png", want: "photo.png"},
+		{key: "", wantErr: true},
+		{key: ".", wantErr: true},
+		{key: "..", wantErr: true},
+		{key: "/etc/passwd", wantErr: true},
+		{key: "../secret", wantErr: true},
+		{key: "attachments/../../secret", wantErr: true},
+		{key: "attachments/./photo.png", wantErr: true},
+		{key: "attachments//photo.png", wantErr: true},
+		{key: "attachments/", wantErr: true},
+		{key: "attachments\\photo.png", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := cleanKey(tt.key)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidKey) {
+					t.Errorf("cleanKey(%q) = %q, %v, want %v", tt.key, got, err, ErrInvalidKey)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("cleanKey(%q) = %q, %v, want %q", tt.key, got, err, tt.want)
+			}
+		})
+	}
+}